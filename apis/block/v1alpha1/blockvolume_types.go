@@ -0,0 +1,128 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BlockVolumeSpec defines the desired state of BlockVolume
+type BlockVolumeSpec struct {
+	// VolumeID is the ID of the volume
+	// If empty it will create a new volume
+	// If set it will use this ID as the volume ID
+	// This field is immutable after creation
+	// +optional
+	VolumeID string `json:"volumeID,omitempty"`
+	// Zone is the zone of the volume
+	// This field is immutable after creation
+	// Defaults to the controller default zone
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// Size is the size of the volume
+	// It can only be increased
+	// Defaults to the snapshot size when created from a snapshot
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// PerfIOPS is the IOPS class of the volume
+	// +kubebuilder:validation:Enum=5000;15000
+	// +optional
+	PerfIOPS *int32 `json:"perfIOPS,omitempty"`
+	// FromSnapshotID is the ID of the snapshot the volume is created from
+	// This field is immutable after creation
+	// +optional
+	FromSnapshotID string `json:"fromSnapshotID,omitempty"`
+	// ServerID is the ID of the Instance server the volume is attached to
+	// The server must be in the same zone as the volume
+	// +optional
+	ServerID string `json:"serverID,omitempty"`
+	// SnapshotPolicy defines the scheduled snapshots of the volume
+	// +optional
+	SnapshotPolicy *BlockSnapshotPolicy `json:"snapshotPolicy,omitempty"`
+}
+
+// BlockSnapshotPolicy defines the scheduled snapshots of a BlockVolume
+type BlockSnapshotPolicy struct {
+	// Interval is the interval between two snapshots
+	Interval metav1.Duration `json:"interval"`
+	// Retention is the number of scheduled snapshots kept
+	// +kubebuilder:validation:Minimum=1
+	Retention int32 `json:"retention"`
+}
+
+// BlockVolumeStatus defines the observed state of BlockVolume
+type BlockVolumeStatus struct {
+	// Size is the current size of the volume
+	Size *resource.Quantity `json:"size,omitempty"`
+	// PerfIOPS is the current IOPS class of the volume
+	PerfIOPS int32 `json:"perfIOPS,omitempty"`
+	// StorageClass is the storage class of the volume
+	StorageClass string `json:"storageClass,omitempty"`
+	// State is the state of the volume
+	State string `json:"state,omitempty"`
+	// AttachedServerID is the ID of the Instance server the volume is currently attached to
+	AttachedServerID string `json:"attachedServerID,omitempty"`
+	// AttachmentState is the state of the attachment to the Instance server
+	AttachmentState string `json:"attachmentState,omitempty"`
+	// LastSnapshotTime is the creation time of the last scheduled snapshot
+	LastSnapshotTime *metav1.Time `json:"lastSnapshotTime,omitempty"`
+	// Conditions is the current conditions of the BlockVolume
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=blockvol
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".spec.volumeID"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".status.size"
+// +kubebuilder:printcolumn:name="IOPS",type="integer",JSONPath=".status.perfIOPS"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Server",type="string",JSONPath=".status.attachedServerID"
+
+// BlockVolume is the Schema for the blockvolumes API
+type BlockVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BlockVolumeSpec   `json:"spec,omitempty"`
+	Status BlockVolumeStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *BlockVolume) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *BlockVolume) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// BlockVolumeList contains a list of BlockVolume
+type BlockVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlockVolume `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BlockVolume{}, &BlockVolumeList{})
+}
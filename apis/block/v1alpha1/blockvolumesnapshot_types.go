@@ -0,0 +1,110 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BlockVolumeSnapshotSpec defines the desired state of BlockVolumeSnapshot
+type BlockVolumeSnapshotSpec struct {
+	// SnapshotID is the ID of the snapshot
+	// If empty it will create a new snapshot
+	// If set it will use this ID as the snapshot ID
+	// This field is immutable after creation
+	// +optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+	// Zone is the zone of the snapshot
+	// Defaults to the zone of the volume
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// VolumeRef represents the reference to the snapshotted volume
+	// This field is immutable after creation
+	VolumeRef BlockVolumeRef `json:"volumeRef"`
+}
+
+// BlockVolumeRef defines a reference to a block volume
+// Only one of ExternalID/Zone or Name/Namespace must be specified
+type BlockVolumeRef struct {
+	// ExternalID is the ID of a volume not managed by the operator
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+	// Zone is the zone of a volume not managed by the operator
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// Name is the name of the BlockVolume
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the BlockVolume
+	// If empty, it will use the namespace of the snapshot
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BlockVolumeSnapshotStatus defines the observed state of BlockVolumeSnapshot
+type BlockVolumeSnapshotStatus struct {
+	// Size is the size of the snapshot
+	Size *resource.Quantity `json:"size,omitempty"`
+	// State is the state of the snapshot, such as creating or available
+	State string `json:"state,omitempty"`
+	// VolumeID is the ID of the snapshotted volume
+	VolumeID string `json:"volumeID,omitempty"`
+	// Conditions is the current conditions of the BlockVolumeSnapshot
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=blocksnap
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".spec.snapshotID"
+// +kubebuilder:printcolumn:name="Volume",type="string",JSONPath=".status.volumeID"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".status.size"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+
+// BlockVolumeSnapshot is the Schema for the blockvolumesnapshots API
+type BlockVolumeSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BlockVolumeSnapshotSpec   `json:"spec,omitempty"`
+	Status BlockVolumeSnapshotStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *BlockVolumeSnapshot) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *BlockVolumeSnapshot) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// BlockVolumeSnapshotList contains a list of BlockVolumeSnapshot
+type BlockVolumeSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlockVolumeSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BlockVolumeSnapshot{}, &BlockVolumeSnapshotList{})
+}
@@ -0,0 +1,147 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSRecordSpec defines the desired state of DNSRecord
+type DNSRecordSpec struct {
+	// RecordID is the ID of the record
+	// If empty it will create a new record
+	// If set it will use this ID as the record ID
+	// This field is immutable after creation
+	// +optional
+	RecordID string `json:"recordID,omitempty"`
+	// DNSZone is the DNS zone of the record, such as internal.example.com
+	// This field is immutable after creation
+	DNSZone string `json:"dnsZone"`
+	// Name is the name of the record, relative to the DNS zone
+	// Empty for the zone apex
+	// This field is immutable after creation
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Type is the type of the record
+	// This field is immutable after creation
+	Type DNSRecordType `json:"type"`
+	// TTL is the time to live of the record, in seconds
+	// Defaults to 3600
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	TTL *int32 `json:"ttl,omitempty"`
+	// Priority is the priority of the record
+	// Only used for MX records
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+	// Value is the raw value of the record
+	// One of Value or ValueFrom must be specified
+	// +optional
+	Value string `json:"value,omitempty"`
+	// ValueFrom represents a value from a field of another object
+	// One of Value or ValueFrom must be specified
+	// +optional
+	ValueFrom *DNSRecordValueFrom `json:"valueFrom,omitempty"`
+}
+
+// DNSRecordType defines the type of a DNSRecord
+// +kubebuilder:validation:Enum=A;AAAA;CNAME;TXT;MX
+type DNSRecordType string
+
+const (
+	// RecordTypeA is the A record type
+	RecordTypeA DNSRecordType = "A"
+	// RecordTypeAAAA is the AAAA record type
+	RecordTypeAAAA DNSRecordType = "AAAA"
+	// RecordTypeCNAME is the CNAME record type
+	RecordTypeCNAME DNSRecordType = "CNAME"
+	// RecordTypeTXT is the TXT record type
+	RecordTypeTXT DNSRecordType = "TXT"
+	// RecordTypeMX is the MX record type
+	RecordTypeMX DNSRecordType = "MX"
+)
+
+// DNSRecordValueFrom defines a source to get a record value from
+type DNSRecordValueFrom struct {
+	// ObjectRef is the reference to the object holding the value
+	// The object must be in the namespace of the record and be a Service,
+	// a RDBInstance or a RedisCluster
+	ObjectRef DNSRecordObjectRef `json:"objectRef"`
+	// FieldPath is the path to the field holding the value, such as status.endpoint.ip
+	FieldPath string `json:"fieldPath"`
+}
+
+// DNSRecordObjectRef defines a reference to an object
+type DNSRecordObjectRef struct {
+	// APIVersion is the API version of the object, such as rdb.scaleway.com/v1alpha1
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the object, such as RDBInstance
+	Kind string `json:"kind"`
+	// Name is the name of the object
+	Name string `json:"name"`
+}
+
+// DNSRecordStatus defines the observed state of DNSRecord
+type DNSRecordStatus struct {
+	// FQDN is the fully qualified domain name of the record
+	FQDN string `json:"fqdn,omitempty"`
+	// Value is the current value of the record
+	Value string `json:"value,omitempty"`
+	// Conditions is the current conditions of the DNSRecord
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=dnsr;dnsrecord
+// +kubebuilder:printcolumn:name="FQDN",type="string",JSONPath=".status.fqdn"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Value",type="string",JSONPath=".status.value"
+
+// DNSRecord is the Schema for the dnsrecords API
+type DNSRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSRecordSpec   `json:"spec,omitempty"`
+	Status DNSRecordStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *DNSRecord) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *DNSRecord) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// DNSRecordList contains a list of DNSRecord
+type DNSRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSRecord `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSRecord{}, &DNSRecordList{})
+}
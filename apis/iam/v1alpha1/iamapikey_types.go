@@ -0,0 +1,109 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IAMAPIKeySpec defines the desired state of IAMAPIKey
+type IAMAPIKeySpec struct {
+	// AccessKey is the access key of the API key
+	// If empty it will create a new API key
+	// It is updated by the operator when the API key is rotated
+	// +optional
+	AccessKey string `json:"accessKey,omitempty"`
+	// ApplicationRef represents the reference to the application owning the API key
+	// This field is immutable after creation
+	ApplicationRef IAMApplicationRef `json:"applicationRef"`
+	// DefaultProjectID is the default project ID of the API key
+	// Defaults to the organization default project
+	// +optional
+	DefaultProjectID string `json:"defaultProjectID,omitempty"`
+	// Description is the description of the API key
+	// +optional
+	Description string `json:"description,omitempty"`
+	// RotationInterval is the interval at which the API key is rotated
+	// The API key is only rotated on demand if not set
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+	// RotationGracePeriod is the duration during which the previous API key
+	// is kept after a rotation, so it can still be used until workloads reload the secret
+	// Defaults to 1h
+	// +optional
+	RotationGracePeriod *metav1.Duration `json:"rotationGracePeriod,omitempty"`
+	// PreviousAccessKey is the access key of the API key replaced by the last rotation
+	// It is deleted at the end of the rotation grace period
+	// It is updated by the operator when the API key is rotated
+	// +optional
+	PreviousAccessKey string `json:"previousAccessKey,omitempty"`
+}
+
+// IAMAPIKeyStatus defines the observed state of IAMAPIKey
+type IAMAPIKeyStatus struct {
+	// ApplicationID is the ID of the application owning the API key
+	ApplicationID string `json:"applicationID,omitempty"`
+	// SecretName is the name of the secret holding the API key
+	SecretName string `json:"secretName,omitempty"`
+	// LastRotationTime is the creation time of the current API key
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// LastRotationRequest is the value of the rotate annotation
+	// which triggered the last rotation
+	LastRotationRequest string `json:"lastRotationRequest,omitempty"`
+	// Conditions is the current conditions of the IAMAPIKey
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=iamkey
+// +kubebuilder:printcolumn:name="Access Key",type="string",JSONPath=".spec.accessKey"
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".status.secretName"
+// +kubebuilder:printcolumn:name="Last Rotation",type="date",JSONPath=".status.lastRotationTime"
+
+// IAMAPIKey is the Schema for the iamapikeys API
+type IAMAPIKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IAMAPIKeySpec   `json:"spec,omitempty"`
+	Status IAMAPIKeyStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *IAMAPIKey) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *IAMAPIKey) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// IAMAPIKeyList contains a list of IAMAPIKey
+type IAMAPIKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IAMAPIKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IAMAPIKey{}, &IAMAPIKeyList{})
+}
@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IAMApplicationSpec defines the desired state of IAMApplication
+type IAMApplicationSpec struct {
+	// ApplicationID is the ID of the application
+	// If empty it will create a new application
+	// If set it will use this ID as the application ID
+	// This field is immutable after creation
+	// +optional
+	ApplicationID string `json:"applicationID,omitempty"`
+	// Description is the description of the application
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Tags are the tags of the application
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// IAMApplicationRef defines a reference to an IAM application
+// Only one of ExternalID or Name/Namespace must be specified
+type IAMApplicationRef struct {
+	// ExternalID is the ID of an application not managed by the operator
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+	// Name is the name of the IAMApplication
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the IAMApplication
+	// If empty, it will use the namespace of the referencing object
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// IAMApplicationStatus defines the observed state of IAMApplication
+type IAMApplicationStatus struct {
+	// OrganizationID is the ID of the organization of the application
+	OrganizationID string `json:"organizationID,omitempty"`
+	// Conditions is the current conditions of the IAMApplication
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=iamapp
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".spec.applicationID"
+
+// IAMApplication is the Schema for the iamapplications API
+type IAMApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IAMApplicationSpec   `json:"spec,omitempty"`
+	Status IAMApplicationStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *IAMApplication) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *IAMApplication) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// IAMApplicationList contains a list of IAMApplication
+type IAMApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IAMApplication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IAMApplication{}, &IAMApplicationList{})
+}
@@ -0,0 +1,110 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IAMPolicySpec defines the desired state of IAMPolicy
+type IAMPolicySpec struct {
+	// PolicyID is the ID of the policy
+	// If empty it will create a new policy
+	// If set it will use this ID as the policy ID
+	// This field is immutable after creation
+	// +optional
+	PolicyID string `json:"policyID,omitempty"`
+	// Description is the description of the policy
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Tags are the tags of the policy
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// ApplicationRef represents the reference to the application the policy is attached to
+	// The policy has no principal if empty
+	// +optional
+	ApplicationRef *IAMApplicationRef `json:"applicationRef,omitempty"`
+	// Rules are the rules of the policy
+	// +kubebuilder:validation:MinItems=1
+	Rules []IAMPolicyRule `json:"rules"`
+}
+
+// IAMPolicyRule defines a rule of an IAMPolicy
+type IAMPolicyRule struct {
+	// PermissionSetNames are the names of the permission sets granted by the rule,
+	// such as ObjectStorageFullAccess
+	// +kubebuilder:validation:MinItems=1
+	PermissionSetNames []string `json:"permissionSetNames"`
+	// ProjectIDs are the IDs of the projects the rule is scoped to
+	// One of ProjectIDs or OrganizationScope must be specified
+	// +optional
+	ProjectIDs []string `json:"projectIDs,omitempty"`
+	// OrganizationScope represents whether the rule is scoped to the whole organization
+	// One of ProjectIDs or OrganizationScope must be specified
+	// +optional
+	OrganizationScope bool `json:"organizationScope,omitempty"`
+	// Condition is the condition of the rule
+	// +optional
+	Condition string `json:"condition,omitempty"`
+}
+
+// IAMPolicyStatus defines the observed state of IAMPolicy
+type IAMPolicyStatus struct {
+	// ApplicationID is the ID of the application the policy is attached to
+	ApplicationID string `json:"applicationID,omitempty"`
+	// Conditions is the current conditions of the IAMPolicy
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=iampol
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".spec.policyID"
+// +kubebuilder:printcolumn:name="Application",type="string",JSONPath=".status.applicationID"
+
+// IAMPolicy is the Schema for the iampolicies API
+type IAMPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IAMPolicySpec   `json:"spec,omitempty"`
+	Status IAMPolicyStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *IAMPolicy) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *IAMPolicy) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// IAMPolicyList contains a list of IAMPolicy
+type IAMPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IAMPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IAMPolicy{}, &IAMPolicyList{})
+}
@@ -0,0 +1,128 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FlexibleIPSpec defines the desired state of FlexibleIP
+type FlexibleIPSpec struct {
+	// IPID is the ID of the flexible IP
+	// If empty it will reserve a new flexible IP
+	// If set it will use this ID as the flexible IP ID
+	// This field is immutable after creation
+	// +optional
+	IPID string `json:"ipID,omitempty"`
+	// Zone is the zone of the flexible IP
+	// This field is immutable after creation
+	// Defaults to the controller default zone
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// Type is the product the flexible IP is reserved for
+	// This field is immutable after creation
+	// Defaults to Instance
+	// +kubebuilder:validation:Enum=Instance;LoadBalancer
+	// +optional
+	Type FlexibleIPType `json:"type,omitempty"`
+	// Reverse is the reverse DNS of the flexible IP
+	// Defaults to the reverse generated by Scaleway
+	// +optional
+	Reverse string `json:"reverse,omitempty"`
+	// Tags are the tags of the flexible IP, in addition to the ones generated from the labels
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// ReclaimPolicy defines what happens to the flexible IP when the FlexibleIP is deleted
+	// Retain keeps the flexible IP reserved, Delete releases it
+	// Defaults to Retain
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +optional
+	ReclaimPolicy FlexibleIPReclaimPolicy `json:"reclaimPolicy,omitempty"`
+}
+
+// FlexibleIPType defines the product a flexible IP is reserved for
+type FlexibleIPType string
+
+const (
+	// FlexibleIPTypeInstance is a flexible IP for Instance servers
+	FlexibleIPTypeInstance FlexibleIPType = "Instance"
+	// FlexibleIPTypeLoadBalancer is a flexible IP for Load Balancers
+	FlexibleIPTypeLoadBalancer FlexibleIPType = "LoadBalancer"
+)
+
+// FlexibleIPReclaimPolicy defines what happens to a flexible IP when the FlexibleIP is deleted
+type FlexibleIPReclaimPolicy string
+
+const (
+	// FlexibleIPReclaimPolicyRetain keeps the flexible IP reserved
+	FlexibleIPReclaimPolicyRetain FlexibleIPReclaimPolicy = "Retain"
+	// FlexibleIPReclaimPolicyDelete releases the flexible IP
+	FlexibleIPReclaimPolicyDelete FlexibleIPReclaimPolicy = "Delete"
+)
+
+// FlexibleIPStatus defines the observed state of FlexibleIP
+type FlexibleIPStatus struct {
+	// Address is the allocated address of the flexible IP
+	Address string `json:"address,omitempty"`
+	// Reverse is the current reverse DNS of the flexible IP
+	Reverse string `json:"reverse,omitempty"`
+	// AttachedTo is the ID of the server or Load Balancer using the flexible IP
+	AttachedTo string `json:"attachedTo,omitempty"`
+	// Conditions is the current conditions of the FlexibleIP
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=fip
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Address",type="string",JSONPath=".status.address"
+// +kubebuilder:printcolumn:name="Reverse",type="string",JSONPath=".status.reverse"
+// +kubebuilder:printcolumn:name="Attached To",type="string",JSONPath=".status.attachedTo"
+
+// FlexibleIP is the Schema for the flexibleips API
+type FlexibleIP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlexibleIPSpec   `json:"spec,omitempty"`
+	Status FlexibleIPStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *FlexibleIP) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *FlexibleIP) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// FlexibleIPList contains a list of FlexibleIP
+type FlexibleIPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FlexibleIP `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FlexibleIP{}, &FlexibleIPList{})
+}
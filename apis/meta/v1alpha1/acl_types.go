@@ -0,0 +1,22 @@
+package v1alpha1
+
+// ACL defines the IP based access control list of a Scaleway database
+type ACL struct {
+	// Rules represents the ACL rules
+	// +optional
+	Rules []ACLRule `json:"rules,omitempty"`
+
+	// AllowCluster represents wether the nodes in the cluster
+	// should be allowed
+	// +optional
+	AllowCluster bool `json:"allowCluster,omitempty"`
+}
+
+// ACLRule defines a rule for an ACL
+type ACLRule struct {
+	// IPRange represents a CIDR IP range
+	IPRange string `json:"ipRange"`
+	// Description is the description associated with this ACL rule
+	// +optional
+	Description string `json:"description,omitempty"`
+}
@@ -8,6 +8,12 @@ import (
 // Status defines the observed state of a Scaleway resource
 type Status struct {
 	Conditions []Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the generation of the spec last successfully reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// PlannedActions are the actions the operator would execute, when running in dry-run mode
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
 }
 
 // Condition contains details for the current condition of this Scaleway resource.
@@ -35,6 +41,10 @@ const (
 	Reconciled ConditionType = "Reconciled"
 	// Ready indicates whether the resource is considered ready
 	Ready ConditionType = "Ready"
+	// Synced indicates whether the remote state of the resource matches its spec
+	Synced ConditionType = "Synced"
+	// Paused indicates whether the reconciliation of the resource is paused
+	Paused ConditionType = "Paused"
 )
 
 //func init() {
@@ -0,0 +1,90 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MNQNatsAccountSpec defines the desired state of MNQNatsAccount
+type MNQNatsAccountSpec struct {
+	// AccountID is the ID of the NATS account
+	// If empty it will create a new account
+	// If set it will use this ID as the account ID
+	// This field is immutable after creation
+	// +optional
+	AccountID string `json:"accountID,omitempty"`
+	// Region is the region in which the MNQNatsAccount will be created
+	// This field is immutable after creation
+	// Defaults to the controller default region
+	// +optional
+	Region string `json:"region,omitempty"`
+	// CredentialsID is the ID of the credentials generated by the operator
+	// It is updated by the operator when the credentials are generated again
+	// +optional
+	CredentialsID string `json:"credentialsID,omitempty"`
+}
+
+// MNQNatsAccountStatus defines the observed state of MNQNatsAccount
+type MNQNatsAccountStatus struct {
+	// Endpoint is the NATS endpoint of the MNQNatsAccount
+	Endpoint string `json:"endpoint,omitempty"`
+	// CredentialsSecret is the name of the secret holding
+	// the credentials of the MNQNatsAccount
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+	// Conditions is the current conditions of the MNQNatsAccount
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=natsaccount
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.endpoint"
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".status.credentialsSecret"
+
+// MNQNatsAccount is the Schema for the mnqnatsaccounts API
+type MNQNatsAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MNQNatsAccountSpec   `json:"spec,omitempty"`
+	Status MNQNatsAccountStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *MNQNatsAccount) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *MNQNatsAccount) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// MNQNatsAccountList contains a list of MNQNatsAccount
+type MNQNatsAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MNQNatsAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MNQNatsAccount{}, &MNQNatsAccountList{})
+}
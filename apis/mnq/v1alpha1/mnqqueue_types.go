@@ -0,0 +1,132 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MNQQueueSpec defines the desired state of MNQQueue
+type MNQQueueSpec struct {
+	// QueueURL is the URL of the queue
+	// If empty it will look for the queue by name, or create a new queue
+	// It is updated by the operator when the queue is created again
+	// +optional
+	QueueURL string `json:"queueURL,omitempty"`
+	// CredentialsID is the ID of the credentials generated by the operator
+	// It is updated by the operator when the credentials are generated again
+	// +optional
+	CredentialsID string `json:"credentialsID,omitempty"`
+	// Region is the region in which the MNQQueue will be created
+	// This field is immutable after creation
+	// Defaults to the controller default region
+	// +optional
+	Region string `json:"region,omitempty"`
+	// QueueName is the name of the queue
+	// The .fifo suffix is added for FIFO queues if missing
+	// This field is immutable after creation
+	// Defaults to the name of the MNQQueue
+	// +optional
+	QueueName string `json:"queueName,omitempty"`
+	// FIFO represents whether the queue is a FIFO queue
+	// This field is immutable after creation
+	// +optional
+	FIFO bool `json:"fifo,omitempty"`
+	// ContentBasedDeduplication represents whether content based deduplication
+	// is enabled on the queue. Only available for FIFO queues
+	// +optional
+	ContentBasedDeduplication bool `json:"contentBasedDeduplication,omitempty"`
+	// VisibilityTimeout is the duration during which a received message
+	// is hidden from other consumers
+	// +optional
+	VisibilityTimeout *metav1.Duration `json:"visibilityTimeout,omitempty"`
+	// MessageRetentionPeriod is the duration during which messages are kept
+	// +optional
+	MessageRetentionPeriod *metav1.Duration `json:"messageRetentionPeriod,omitempty"`
+	// MaximumMessageSize is the maximum size of a message, in bytes
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=262144
+	// +optional
+	MaximumMessageSize *int32 `json:"maximumMessageSize,omitempty"`
+	// Permissions are the permissions of the generated credentials
+	// Defaults to publish and receive
+	// +optional
+	Permissions *MNQPermissions `json:"permissions,omitempty"`
+}
+
+// MNQPermissions defines the permissions of generated credentials
+type MNQPermissions struct {
+	// CanPublish allows to publish messages
+	// +optional
+	CanPublish bool `json:"canPublish,omitempty"`
+	// CanReceive allows to receive messages
+	// +optional
+	CanReceive bool `json:"canReceive,omitempty"`
+	// CanManage allows to manage the queues and topics
+	// +optional
+	CanManage bool `json:"canManage,omitempty"`
+}
+
+// MNQQueueStatus defines the observed state of MNQQueue
+type MNQQueueStatus struct {
+	// AttributesHash is the hash of the last applied queue attributes
+	AttributesHash string `json:"attributesHash,omitempty"`
+	// CredentialsSecret is the name of the secret holding
+	// the credentials of the MNQQueue
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+	// Conditions is the current conditions of the MNQQueue
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=queue
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.queueURL"
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".status.credentialsSecret"
+
+// MNQQueue is the Schema for the mnqqueues API
+type MNQQueue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MNQQueueSpec   `json:"spec,omitempty"`
+	Status MNQQueueStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *MNQQueue) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *MNQQueue) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// MNQQueueList contains a list of MNQQueue
+type MNQQueueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MNQQueue `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MNQQueue{}, &MNQQueueList{})
+}
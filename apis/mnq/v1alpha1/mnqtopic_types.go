@@ -0,0 +1,107 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MNQTopicSpec defines the desired state of MNQTopic
+type MNQTopicSpec struct {
+	// TopicARN is the ARN of the topic
+	// If empty it will create a new topic, or use the existing topic with the same name
+	// It is updated by the operator when the topic is created again
+	// +optional
+	TopicARN string `json:"topicARN,omitempty"`
+	// CredentialsID is the ID of the credentials generated by the operator
+	// It is updated by the operator when the credentials are generated again
+	// +optional
+	CredentialsID string `json:"credentialsID,omitempty"`
+	// Region is the region in which the MNQTopic will be created
+	// This field is immutable after creation
+	// Defaults to the controller default region
+	// +optional
+	Region string `json:"region,omitempty"`
+	// TopicName is the name of the topic
+	// The .fifo suffix is added for FIFO topics if missing
+	// This field is immutable after creation
+	// Defaults to the name of the MNQTopic
+	// +optional
+	TopicName string `json:"topicName,omitempty"`
+	// FIFO represents whether the topic is a FIFO topic
+	// This field is immutable after creation
+	// +optional
+	FIFO bool `json:"fifo,omitempty"`
+	// ContentBasedDeduplication represents whether content based deduplication
+	// is enabled on the topic. Only available for FIFO topics
+	// +optional
+	ContentBasedDeduplication bool `json:"contentBasedDeduplication,omitempty"`
+	// Permissions are the permissions of the generated credentials
+	// Defaults to publish and receive
+	// +optional
+	Permissions *MNQPermissions `json:"permissions,omitempty"`
+}
+
+// MNQTopicStatus defines the observed state of MNQTopic
+type MNQTopicStatus struct {
+	// AttributesHash is the hash of the last applied topic attributes
+	AttributesHash string `json:"attributesHash,omitempty"`
+	// CredentialsSecret is the name of the secret holding
+	// the credentials of the MNQTopic
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+	// Conditions is the current conditions of the MNQTopic
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=topic
+// +kubebuilder:printcolumn:name="ARN",type="string",JSONPath=".spec.topicARN"
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".status.credentialsSecret"
+
+// MNQTopic is the Schema for the mnqtopics API
+type MNQTopic struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MNQTopicSpec   `json:"spec,omitempty"`
+	Status MNQTopicStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *MNQTopic) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *MNQTopic) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// MNQTopicList contains a list of MNQTopic
+type MNQTopicList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MNQTopic `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MNQTopic{}, &MNQTopicList{})
+}
@@ -0,0 +1,302 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	"github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+)
+
+const (
+	// passwordSecretKey is the key of the secret v1alpha1 RDBUsers read their password from
+	passwordSecretKey = "password"
+	// passwordSecretKeyAnnotation holds the key of the password secret of a RDBUser
+	// converted from a v1beta1 RDBUser using another key than passwordSecretKey
+	passwordSecretKeyAnnotation = "rdb.scaleway.com/password-secret-key"
+	// aclSourcesAnnotation holds the JSON encoded ACL sources of a RDBInstance
+	// converted from a v1beta1 RDBInstance, since v1alpha1 has no ACL sources
+	aclSourcesAnnotation = "rdb.scaleway.com/acl-sources"
+	// aclOwnershipAnnotation holds the ACL ownership of a RDBInstance
+	// converted from a v1beta1 RDBInstance, since v1alpha1 manages the full ACL
+	aclOwnershipAnnotation = "rdb.scaleway.com/acl-ownership"
+)
+
+// ConvertTo converts this RDBInstance to the Hub version (v1beta1).
+// The ACL sources and ownership are restored from their annotations
+func (src *RDBInstance) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.RDBInstance)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.InstanceID = src.Spec.InstanceID
+	dst.Spec.Region = src.Spec.Region
+	if src.Spec.InstanceFrom != nil {
+		instanceFrom := convertInstanceRefTo(*src.Spec.InstanceFrom)
+		dst.Spec.InstanceFrom = &instanceFrom
+	}
+	dst.Spec.Engine = src.Spec.Engine
+	dst.Spec.NodeType = src.Spec.NodeType
+	dst.Spec.IsHaCluster = src.Spec.IsHaCluster
+	if src.Spec.AutoBackup != nil {
+		dst.Spec.AutoBackup = (*v1beta1.RDBInstanceAutoBackup)(src.Spec.AutoBackup.DeepCopy())
+	}
+	if src.Spec.ACL != nil {
+		dst.Spec.ACL = &v1beta1.RDBACL{
+			AllowCluster: src.Spec.ACL.AllowCluster,
+		}
+		if src.Spec.ACL.Rules != nil {
+			dst.Spec.ACL.Rules = make([]scalewaymetav1alpha1.ACLRule, len(src.Spec.ACL.Rules))
+			copy(dst.Spec.ACL.Rules, src.Spec.ACL.Rules)
+		}
+		if sources, ok := src.Annotations[aclSourcesAnnotation]; ok {
+			err := json.Unmarshal([]byte(sources), &dst.Spec.ACL.Sources)
+			if err != nil {
+				return err
+			}
+		}
+		dst.Spec.ACL.Ownership = v1beta1.RDBACLOwnership(src.Annotations[aclOwnershipAnnotation])
+	}
+	delete(dst.Annotations, aclSourcesAnnotation)
+	delete(dst.Annotations, aclOwnershipAnnotation)
+
+	dst.Status.Endpoint = v1beta1.RDBInstanceEndpoint(src.Status.Endpoint)
+	dst.Status.Status = *src.Status.Status.DeepCopy()
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+// The ACL sources and ownership are kept in annotations
+func (dst *RDBInstance) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.RDBInstance)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.InstanceID = src.Spec.InstanceID
+	dst.Spec.Region = src.Spec.Region
+	if src.Spec.InstanceFrom != nil {
+		instanceFrom := convertInstanceRefFrom(*src.Spec.InstanceFrom)
+		dst.Spec.InstanceFrom = &instanceFrom
+	}
+	dst.Spec.Engine = src.Spec.Engine
+	dst.Spec.NodeType = src.Spec.NodeType
+	dst.Spec.IsHaCluster = src.Spec.IsHaCluster
+	if src.Spec.AutoBackup != nil {
+		dst.Spec.AutoBackup = (*RDBInstanceAutoBackup)(src.Spec.AutoBackup.DeepCopy())
+	}
+	if src.Spec.ACL != nil {
+		dst.Spec.ACL = &scalewaymetav1alpha1.ACL{
+			AllowCluster: src.Spec.ACL.AllowCluster,
+		}
+		if src.Spec.ACL.Rules != nil {
+			dst.Spec.ACL.Rules = make([]scalewaymetav1alpha1.ACLRule, len(src.Spec.ACL.Rules))
+			copy(dst.Spec.ACL.Rules, src.Spec.ACL.Rules)
+		}
+		if len(src.Spec.ACL.Sources) > 0 {
+			sources, err := json.Marshal(src.Spec.ACL.Sources)
+			if err != nil {
+				return err
+			}
+			if dst.Annotations == nil {
+				dst.Annotations = map[string]string{}
+			}
+			dst.Annotations[aclSourcesAnnotation] = string(sources)
+		}
+		if src.Spec.ACL.Ownership != "" {
+			if dst.Annotations == nil {
+				dst.Annotations = map[string]string{}
+			}
+			dst.Annotations[aclOwnershipAnnotation] = string(src.Spec.ACL.Ownership)
+		}
+	}
+
+	dst.Status.Endpoint = RDBInstanceEndpoint(src.Status.Endpoint)
+	dst.Status.Status = *src.Status.Status.DeepCopy()
+
+	return nil
+}
+
+// ConvertTo converts this RDBDatabase to the Hub version (v1beta1).
+func (src *RDBDatabase) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.RDBDatabase)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.InstanceRef = convertInstanceRefTo(src.Spec.InstanceRef)
+	dst.Spec.OverrideName = src.Spec.OverrideName
+
+	if src.Status.Size != nil {
+		size := src.Status.Size.DeepCopy()
+		dst.Status.Size = &size
+	}
+	dst.Status.Managed = src.Status.Managed
+	dst.Status.Owner = src.Status.Owner
+	dst.Status.Status = *src.Status.Status.DeepCopy()
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *RDBDatabase) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.RDBDatabase)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.InstanceRef = convertInstanceRefFrom(src.Spec.InstanceRef)
+	dst.Spec.OverrideName = src.Spec.OverrideName
+
+	if src.Status.Size != nil {
+		size := src.Status.Size.DeepCopy()
+		dst.Status.Size = &size
+	}
+	dst.Status.Managed = src.Status.Managed
+	dst.Status.Owner = src.Status.Owner
+	dst.Status.Status = *src.Status.Status.DeepCopy()
+
+	return nil
+}
+
+// ConvertTo converts this RDBUser to the Hub version (v1beta1).
+// A secret in another namespace than the user is kept in an annotation, since
+// v1beta1 only reads secrets from the namespace of the user
+func (src *RDBUser) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.RDBUser)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.UserName = src.Spec.UserName
+	dst.Spec.Admin = src.Spec.Admin
+	for _, privilege := range src.Spec.Privileges {
+		dst.Spec.Privileges = append(dst.Spec.Privileges, v1beta1.RDBPrivilege{
+			DatabaseName: privilege.DatabaseName,
+			Permission:   v1beta1.RDBPermission(privilege.Permission),
+		})
+	}
+	dst.Spec.InstanceRef = convertInstanceRefTo(src.Spec.InstanceRef)
+
+	if src.Spec.Password.Value != nil {
+		value := *src.Spec.Password.Value
+		dst.Spec.Password.Value = &value
+	}
+	if src.Spec.Password.ValueFrom != nil {
+		key := passwordSecretKey
+		if annotationKey, ok := dst.Annotations[passwordSecretKeyAnnotation]; ok {
+			key = annotationKey
+		}
+		dst.Spec.Password.ValueFrom = &v1beta1.RDBUserPasswordSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: src.Spec.Password.ValueFrom.SecretKeyRef.Name,
+				},
+				Key: key,
+			},
+		}
+		secretNamespace := src.Spec.Password.ValueFrom.SecretKeyRef.Namespace
+		if secretNamespace != "" && secretNamespace != src.Namespace {
+			if dst.Annotations == nil {
+				dst.Annotations = map[string]string{}
+			}
+			dst.Annotations[v1beta1.PasswordSecretNamespaceAnnotation] = secretNamespace
+		}
+	}
+	delete(dst.Annotations, passwordSecretKeyAnnotation)
+
+	dst.Status.Status = *src.Status.Status.DeepCopy()
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+// A secret key other than the one read by v1alpha1 is kept in an annotation
+func (dst *RDBUser) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.RDBUser)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.UserName = src.Spec.UserName
+	dst.Spec.Admin = src.Spec.Admin
+	for _, privilege := range src.Spec.Privileges {
+		dst.Spec.Privileges = append(dst.Spec.Privileges, RDBPrivilege{
+			DatabaseName: privilege.DatabaseName,
+			Permission:   RDBPermission(privilege.Permission),
+		})
+	}
+	dst.Spec.InstanceRef = convertInstanceRefFrom(src.Spec.InstanceRef)
+
+	if src.Spec.Password.Value != nil {
+		value := *src.Spec.Password.Value
+		dst.Spec.Password.Value = &value
+	}
+	if src.Spec.Password.ValueFrom != nil && src.Spec.Password.ValueFrom.SecretKeyRef != nil {
+		secretKeyRef := src.Spec.Password.ValueFrom.SecretKeyRef
+		dst.Spec.Password.ValueFrom = &RDBInstancePasswordValueFrom{
+			SecretKeyRef: corev1.SecretReference{
+				Name:      secretKeyRef.Name,
+				Namespace: src.Annotations[v1beta1.PasswordSecretNamespaceAnnotation],
+			},
+		}
+		if secretKeyRef.Key != "" && secretKeyRef.Key != passwordSecretKey {
+			if dst.Annotations == nil {
+				dst.Annotations = map[string]string{}
+			}
+			dst.Annotations[passwordSecretKeyAnnotation] = secretKeyRef.Key
+		}
+	}
+	delete(dst.Annotations, v1beta1.PasswordSecretNamespaceAnnotation)
+
+	dst.Status.Status = *src.Status.Status.DeepCopy()
+
+	return nil
+}
+
+// convertInstanceRefTo converts a v1alpha1 instance reference to a v1beta1 one
+// The region is only kept for external instances, since it is ignored otherwise
+func convertInstanceRefTo(src RDBInstanceRef) v1beta1.RDBInstanceRef {
+	dst := v1beta1.RDBInstanceRef{}
+	if src.Name != "" {
+		dst.Object = &v1beta1.RDBInstanceObjectRef{
+			Name:      src.Name,
+			Namespace: src.Namespace,
+		}
+	}
+	if src.ExternalID != "" {
+		dst.External = &v1beta1.RDBInstanceExternalRef{
+			ID:     src.ExternalID,
+			Region: src.Region,
+		}
+	}
+	return dst
+}
+
+// convertInstanceRefFrom converts a v1beta1 instance reference to a v1alpha1 one
+func convertInstanceRefFrom(src v1beta1.RDBInstanceRef) RDBInstanceRef {
+	dst := RDBInstanceRef{}
+	if src.Object != nil {
+		dst.Name = src.Object.Name
+		dst.Namespace = src.Object.Namespace
+	}
+	if src.External != nil {
+		dst.ExternalID = src.External.ID
+		dst.Region = src.External.Region
+	}
+	return dst
+}
@@ -0,0 +1,222 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	"github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+)
+
+func Test_RDBInstanceConversion(t *testing.T) {
+	frequency := int32(12)
+
+	cases := []*RDBInstance{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+			Spec: RDBInstanceSpec{
+				Region:     "fr-par",
+				Engine:     "PostgreSQL-12",
+				NodeType:   "db-dev-s",
+				AutoBackup: &RDBInstanceAutoBackup{Frequency: &frequency},
+				ACL: &scalewaymetav1alpha1.ACL{
+					Rules:        []scalewaymetav1alpha1.ACLRule{{IPRange: "1.2.3.4/32", Description: "office"}},
+					AllowCluster: true,
+				},
+			},
+			Status: RDBInstanceStatus{
+				Endpoint: RDBInstanceEndpoint{IP: "1.2.3.4", Port: 5432},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "clone", Namespace: "default"},
+			Spec: RDBInstanceSpec{
+				InstanceFrom: &RDBInstanceRef{Name: "instance", Namespace: "other"},
+				Engine:       "PostgreSQL-12",
+				NodeType:     "db-dev-s",
+			},
+		},
+	}
+
+	for i, c := range cases {
+		hub := &v1beta1.RDBInstance{}
+		if err := c.ConvertTo(hub); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		instance := &RDBInstance{}
+		if err := instance.ConvertFrom(hub); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(instance, c) {
+			t.Errorf("case %d: got %+v instead of %+v", i, instance, c)
+		}
+	}
+}
+
+func Test_RDBInstanceACLConversion(t *testing.T) {
+	hub := &v1beta1.RDBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+		Spec: v1beta1.RDBInstanceSpec{
+			Engine:   "PostgreSQL-12",
+			NodeType: "db-dev-s",
+			ACL: &v1beta1.RDBACL{
+				Rules:     []scalewaymetav1alpha1.ACLRule{{IPRange: "1.2.3.4/32"}},
+				Ownership: v1beta1.RDBACLOwnershipOwned,
+				Sources: []v1beta1.RDBACLSource{
+					{Description: "egress", Service: &v1beta1.RDBACLSourceRef{Name: "lb"}},
+					{ConfigMap: &v1beta1.RDBACLSourceConfigMapRef{
+						RDBACLSourceRef: v1beta1.RDBACLSourceRef{Name: "ips", Namespace: "other"},
+						Key:             "allowed",
+					}},
+				},
+			},
+		},
+	}
+
+	instance := &RDBInstance{}
+	if err := instance.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := instance.Annotations[aclSourcesAnnotation]; !ok {
+		t.Errorf("got no %s annotation", aclSourcesAnnotation)
+	}
+
+	converted := &v1beta1.RDBInstance{}
+	if err := instance.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(converted.Spec.ACL, hub.Spec.ACL) {
+		t.Errorf("got %+v instead of %+v", converted.Spec.ACL, hub.Spec.ACL)
+	}
+	for _, annotation := range []string{aclSourcesAnnotation, aclOwnershipAnnotation} {
+		if _, ok := converted.Annotations[annotation]; ok {
+			t.Errorf("got unexpected %s annotation", annotation)
+		}
+	}
+}
+
+func Test_RDBDatabaseConversion(t *testing.T) {
+	cases := []struct {
+		database    *RDBDatabase
+		instanceRef v1beta1.RDBInstanceRef
+	}{
+		{
+			&RDBDatabase{
+				ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "default"},
+				Spec: RDBDatabaseSpec{
+					InstanceRef:  RDBInstanceRef{Name: "instance", Namespace: "other"},
+					OverrideName: "db",
+				},
+			},
+			v1beta1.RDBInstanceRef{Object: &v1beta1.RDBInstanceObjectRef{Name: "instance", Namespace: "other"}},
+		},
+		{
+			&RDBDatabase{
+				ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "default"},
+				Spec: RDBDatabaseSpec{
+					InstanceRef: RDBInstanceRef{ExternalID: "11111111-1111-1111-1111-111111111111", Region: "nl-ams"},
+				},
+			},
+			v1beta1.RDBInstanceRef{External: &v1beta1.RDBInstanceExternalRef{ID: "11111111-1111-1111-1111-111111111111", Region: "nl-ams"}},
+		},
+	}
+
+	for i, c := range cases {
+		hub := &v1beta1.RDBDatabase{}
+		if err := c.database.ConvertTo(hub); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(hub.Spec.InstanceRef, c.instanceRef) {
+			t.Errorf("case %d: got %+v instead of %+v", i, hub.Spec.InstanceRef, c.instanceRef)
+		}
+		database := &RDBDatabase{}
+		if err := database.ConvertFrom(hub); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(database, c.database) {
+			t.Errorf("case %d: got %+v instead of %+v", i, database, c.database)
+		}
+	}
+}
+
+func Test_RDBUserConversion(t *testing.T) {
+	cases := []struct {
+		user        *RDBUser
+		annotations map[string]string
+	}{
+		{
+			&RDBUser{
+				ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "default"},
+				Spec: RDBUserSpec{
+					UserName: "user",
+					Admin:    true,
+					Password: RDBInstancePassword{
+						ValueFrom: &RDBInstancePasswordValueFrom{
+							SecretKeyRef: corev1.SecretReference{Name: "secret"},
+						},
+					},
+					Privileges:  []RDBPrivilege{{DatabaseName: "db", Permission: PermissionReadOnly}},
+					InstanceRef: RDBInstanceRef{Name: "instance"},
+				},
+			},
+			nil,
+		},
+		{
+			&RDBUser{
+				ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "default"},
+				Spec: RDBUserSpec{
+					UserName: "user",
+					Password: RDBInstancePassword{
+						ValueFrom: &RDBInstancePasswordValueFrom{
+							SecretKeyRef: corev1.SecretReference{Name: "secret", Namespace: "secrets"},
+						},
+					},
+					InstanceRef: RDBInstanceRef{Name: "instance"},
+				},
+			},
+			map[string]string{v1beta1.PasswordSecretNamespaceAnnotation: "secrets"},
+		},
+		{
+			&RDBUser{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "user",
+					Namespace:   "default",
+					Annotations: map[string]string{passwordSecretKeyAnnotation: "pwd"},
+				},
+				Spec: RDBUserSpec{
+					UserName: "user",
+					Password: RDBInstancePassword{
+						ValueFrom: &RDBInstancePasswordValueFrom{
+							SecretKeyRef: corev1.SecretReference{Name: "secret"},
+						},
+					},
+					InstanceRef: RDBInstanceRef{Name: "instance"},
+				},
+			},
+			map[string]string{},
+		},
+	}
+
+	for i, c := range cases {
+		hub := &v1beta1.RDBUser{}
+		if err := c.user.ConvertTo(hub); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(hub.Annotations, c.annotations) {
+			t.Errorf("case %d: got annotations %v instead of %v", i, hub.Annotations, c.annotations)
+		}
+		user := &RDBUser{}
+		if err := user.ConvertFrom(hub); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(user.Spec, c.user.Spec) {
+			t.Errorf("case %d: got %+v instead of %+v", i, user.Spec, c.user.Spec)
+		}
+		if user.Annotations[passwordSecretKeyAnnotation] != c.user.Annotations[passwordSecretKeyAnnotation] {
+			t.Errorf("case %d: got key annotation %s instead of %s", i, user.Annotations[passwordSecretKeyAnnotation], c.user.Annotations[passwordSecretKeyAnnotation])
+		}
+	}
+}
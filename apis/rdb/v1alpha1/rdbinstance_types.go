@@ -50,35 +50,14 @@ type RDBInstanceSpec struct {
 	NodeType string `json:"nodeType"`
 	// IsHaCluster represents whether the RDBInstance should be in HA mode
 	// Defaults to false
-	// +kubebuilder:default:false
+	// +kubebuilder:default=false
 	// +optional
 	IsHaCluster bool `json:"isHaCluster,omitempty"`
 	// AutoBackup represents the RDBInstance auto backup policy
 	// +optional
 	AutoBackup *RDBInstanceAutoBackup `json:"autoBackup,omitempty"`
 	// ACL represents the ACL rules of the RDBInstance
-	ACL *RDBACL `json:"acl,omitempty"`
-}
-
-// RDBACL defines the acl of a RDBInstance
-type RDBACL struct {
-	// Rules represents the RDB ACL rules
-	// +optional
-	Rules []RDBACLRule `json:"rules,omitempty"`
-
-	// AllowCluster represents wether the nodes in the cluster
-	// should be allowed
-	// +optional
-	AllowCluster bool `json:"allowCluster,omitempty"`
-}
-
-// RDBACLRule defines a rule for a RDB ACL
-type RDBACLRule struct {
-	// IPRange represents a CIDR IP range
-	IPRange string `json:"ipRange"`
-	// Description is the description associated with this ACL rule
-	// +optional
-	Description string `json:"description,omitempty"`
+	ACL *scalewaymetav1alpha1.ACL `json:"acl,omitempty"`
 }
 
 // RDBInstanceAutoBackup defines the auto backup state of a RDBInstance
@@ -87,13 +66,13 @@ type RDBInstanceAutoBackup struct {
 	Disabled bool `json:"disabled,omitempty"`
 	// Frequency represents the frequency, in hour, at which auto backups are made
 	// Default to 24
-	// +kubebuilder:default:24
+	// +kubebuilder:default=24
 	// +kubebuilder:validation:Minimum=0
 	// +optional
 	Frequency *int32 `json:"frequency,omitempty"`
 	// Retention represents the number of days the autobackup are kept
 	// Default to 7
-	// +kubebuilder:default:7
+	// +kubebuilder:default=7
 	// +kubebuilder:validation:Minimum=0
 	// +optional
 	Retention *int32 `json:"retention,omitempty"`
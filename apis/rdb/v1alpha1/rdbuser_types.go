@@ -29,9 +29,11 @@ type RDBUserSpec struct {
 	// Password is the password associated to the user
 	Password RDBInstancePassword `json:"password"`
 	// Admin represents whether the user is an admin user
-	// +kubebuilder:default:true
+	// Defaults to true
+	// It is serialized even when false, so that an explicit false is not defaulted
+	// +kubebuilder:default=true
 	// +optional
-	Admin bool `json:"admin,omitempty"`
+	Admin bool `json:"admin"`
 	// Privileges represents the privileges given to this user
 	Privileges []RDBPrivilege `json:"privileges,omitempty"`
 	// InstanceRef represents the reference to the instance of the user
@@ -21,44 +21,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	metav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RDBACL) DeepCopyInto(out *RDBACL) {
-	*out = *in
-	if in.Rules != nil {
-		in, out := &in.Rules, &out.Rules
-		*out = make([]RDBACLRule, len(*in))
-		copy(*out, *in)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDBACL.
-func (in *RDBACL) DeepCopy() *RDBACL {
-	if in == nil {
-		return nil
-	}
-	out := new(RDBACL)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RDBACLRule) DeepCopyInto(out *RDBACLRule) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDBACLRule.
-func (in *RDBACLRule) DeepCopy() *RDBACLRule {
-	if in == nil {
-		return nil
-	}
-	out := new(RDBACLRule)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RDBDatabase) DeepCopyInto(out *RDBDatabase) {
 	*out = *in
@@ -325,7 +291,7 @@ func (in *RDBInstanceSpec) DeepCopyInto(out *RDBInstanceSpec) {
 	}
 	if in.ACL != nil {
 		in, out := &in.ACL, &out.ACL
-		*out = new(RDBACL)
+		*out = new(metav1alpha1.ACL)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -0,0 +1,215 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RDBInstanceSpec defines the desired state of RDBInstance
+type RDBInstanceSpec struct {
+	// InstanceID is the ID of the instance
+	// If empty it will create a new instance
+	// If set it will use this ID as the instance ID
+	// This field is immutable after creation
+	// At most one of InstanceID/Region and InstanceFrom have to be specified
+	// on creation.
+	// +optional
+	InstanceID string `json:"instanceID,omitempty"`
+	// Region is the region in which the RDBInstance will run
+	// This field is immutable after creation
+	// Defaults to the controller default region
+	// At most one of InstanceID/Region and InstanceFrom have to be specified
+	// on creation.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// InstanceFrom allows to create an instance from an existing one
+	// At most one of InstanceID/Region and InstanceFrom have to be specified
+	// on creation.
+	// This field is immutable after creation
+	// +optional
+	InstanceFrom *RDBInstanceRef `json:"instanceFrom,omitempty"`
+	// Engine is the database engine of the RDBInstance
+	Engine string `json:"engine"`
+	// NodeType is the type of node to use for the RDBInstance
+	NodeType string `json:"nodeType"`
+	// IsHaCluster represents whether the RDBInstance should be in HA mode
+	// Defaults to false
+	// +kubebuilder:default=false
+	// +optional
+	IsHaCluster bool `json:"isHaCluster,omitempty"`
+	// AutoBackup represents the RDBInstance auto backup policy
+	// +optional
+	AutoBackup *RDBInstanceAutoBackup `json:"autoBackup,omitempty"`
+	// ACL represents the ACL rules of the RDBInstance
+	ACL *RDBACL `json:"acl,omitempty"`
+}
+
+// RDBACL defines the acl of a RDBInstance
+type RDBACL struct {
+	// Rules represents the RDB ACL rules
+	// +optional
+	Rules []scalewaymetav1alpha1.ACLRule `json:"rules,omitempty"`
+
+	// AllowCluster represents wether the nodes in the cluster
+	// should be allowed
+	// +optional
+	AllowCluster bool `json:"allowCluster,omitempty"`
+
+	// Sources represents the sources of IPs resolved dynamically
+	// The ACL rules are updated when the sources change
+	// +optional
+	Sources []RDBACLSource `json:"sources,omitempty"`
+
+	// Ownership represents which rules of the instance ACL are managed
+	// With Full, the ACL is replaced by the wanted rules
+	// With Owned, only the rules created by the operator are managed,
+	// leaving the rules added by other tools untouched
+	// Defaults to Full
+	// +kubebuilder:validation:Enum=Full;Owned
+	// +kubebuilder:default=Full
+	// +optional
+	Ownership RDBACLOwnership `json:"ownership,omitempty"`
+}
+
+// RDBACLOwnership defines which rules of a RDB ACL are managed
+type RDBACLOwnership string
+
+const (
+	// RDBACLOwnershipFull manages all the rules of the ACL
+	RDBACLOwnershipFull RDBACLOwnership = "Full"
+	// RDBACLOwnershipOwned only manages the rules created by the operator
+	RDBACLOwnershipOwned RDBACLOwnership = "Owned"
+)
+
+// RDBACLSource defines a source of IPs allowed by a RDB ACL
+// Exactly one of Service, ConfigMap, RDBInstance, PublicGateway and FlexibleIP must be specified
+type RDBACLSource struct {
+	// Description is the description associated with the ACL rules of this source
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Service allows the load balancer ingress IPs of a Service of type LoadBalancer
+	// +optional
+	Service *RDBACLSourceRef `json:"service,omitempty"`
+	// ConfigMap allows the IPs or CIDRs listed in a ConfigMap key,
+	// separated by commas or whitespaces
+	// +optional
+	ConfigMap *RDBACLSourceConfigMapRef `json:"configMap,omitempty"`
+	// RDBInstance allows the endpoint IP of another RDBInstance
+	// +optional
+	RDBInstance *RDBACLSourceRef `json:"rdbInstance,omitempty"`
+	// PublicGateway allows the public IP of a PublicGateway
+	// +optional
+	PublicGateway *RDBACLSourceRef `json:"publicGateway,omitempty"`
+	// FlexibleIP allows the address of a FlexibleIP
+	// +optional
+	FlexibleIP *RDBACLSourceRef `json:"flexibleIP,omitempty"`
+}
+
+// RDBACLSourceRef defines a reference to an object used as an ACL source
+type RDBACLSourceRef struct {
+	// Name is the name of the object
+	Name string `json:"name"`
+	// Namespace is the namespace of the object
+	// If empty, it will use the namespace of the RDBInstance
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// RDBACLSourceConfigMapRef defines a reference to a ConfigMap key used as an ACL source
+type RDBACLSourceConfigMapRef struct {
+	RDBACLSourceRef `json:",inline"`
+	// Key is the key of the ConfigMap holding the IPs
+	Key string `json:"key"`
+}
+
+// RDBInstanceAutoBackup defines the auto backup state of a RDBInstance
+type RDBInstanceAutoBackup struct {
+	// Disabled represents whether the auto backup should be disabled
+	Disabled bool `json:"disabled,omitempty"`
+	// Frequency represents the frequency, in hour, at which auto backups are made
+	// Default to 24
+	// +kubebuilder:default=24
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Frequency *int32 `json:"frequency,omitempty"`
+	// Retention represents the number of days the autobackup are kept
+	// Default to 7
+	// +kubebuilder:default=7
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retention *int32 `json:"retention,omitempty"`
+}
+
+// RDBInstanceStatus defines the observed state of RDBInstance
+type RDBInstanceStatus struct {
+	// Endpoint is the endpoint of the RDBInstance
+	Endpoint RDBInstanceEndpoint `json:"endpoint,omitempty"`
+	// ACL is the list of the rules of the RDBInstance ACL, including
+	// the ones not managed by the operator
+	ACL []scalewaymetav1alpha1.ACLRule `json:"acl,omitempty"`
+	// Conditions is the current conditions of the RDBInstance
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// RDBInstanceEndpoint defines the endpoint of a RDBInstance
+type RDBInstanceEndpoint struct {
+	// IP is the IP of the RDBInstance
+	IP string `json:"ip,omitempty"`
+	// Port if the port of the RDBInstance
+	Port int32 `json:"port,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=rdbi;rdbinstance
+// +kubebuilder:printcolumn:name="IP",type="string",JSONPath=".status.endpoint.ip"
+// +kubebuilder:printcolumn:name="Port",type="integer",JSONPath=".status.endpoint.port"
+
+// RDBInstance is the Schema for the databaseinstances API
+type RDBInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RDBInstanceSpec   `json:"spec,omitempty"`
+	Status RDBInstanceStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *RDBInstance) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *RDBInstance) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// RDBInstanceList contains a list of RDBInstance
+type RDBInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RDBInstance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RDBInstance{}, &RDBInstanceList{})
+}
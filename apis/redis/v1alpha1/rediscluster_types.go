@@ -0,0 +1,144 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RedisClusterSpec defines the desired state of RedisCluster
+type RedisClusterSpec struct {
+	// ClusterID is the ID of the cluster
+	// If empty it will create a new cluster
+	// If set it will use this ID as the cluster ID
+	// This field is immutable after creation
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+	// Zone is the zone in which the RedisCluster will run
+	// This field is immutable after creation
+	// Defaults to the controller default zone
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// Version is the Redis version of the RedisCluster
+	Version string `json:"version"`
+	// NodeType is the type of node to use for the RedisCluster
+	NodeType string `json:"nodeType"`
+	// ClusterSize is the number of nodes of the RedisCluster
+	// It can only be increased after creation
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ClusterSize *int32 `json:"clusterSize,omitempty"`
+	// TLSEnabled represents whether TLS is enabled on the RedisCluster
+	// This field is immutable after creation
+	// +optional
+	TLSEnabled bool `json:"tlsEnabled,omitempty"`
+	// User is the user created on the RedisCluster
+	User RedisUser `json:"user"`
+	// ACL represents the ACL rules of the RedisCluster
+	// +optional
+	ACL *scalewaymetav1alpha1.ACL `json:"acl,omitempty"`
+}
+
+// RedisUser defines the user of a RedisCluster
+type RedisUser struct {
+	// UserName is the name of the user
+	UserName string `json:"userName"`
+	// Password is the password associated to the user
+	Password RedisPassword `json:"password"`
+}
+
+// RedisPassword defines the password of a RedisCluster user
+// One of Value or ValueFrom must be specified
+type RedisPassword struct {
+	// Value represents a raw value
+	// +optional
+	Value *string `json:"value,omitempty"`
+	// ValueFrom represents a value from a secret
+	// +optional
+	ValueFrom *RedisPasswordValueFrom `json:"valueFrom,omitempty"`
+}
+
+// RedisPasswordValueFrom defines a source to get a password from
+type RedisPasswordValueFrom struct {
+	SecretKeyRef corev1.SecretReference `json:"secretKeyRef"`
+}
+
+// RedisClusterStatus defines the observed state of RedisCluster
+type RedisClusterStatus struct {
+	// Endpoints are the endpoints of the RedisCluster
+	Endpoints []RedisClusterEndpoint `json:"endpoints,omitempty"`
+	// ConnectionSecret is the name of the secret holding
+	// the connection information of the RedisCluster
+	ConnectionSecret string `json:"connectionSecret,omitempty"`
+	// PasswordHash is the hash of the last pushed user password,
+	// salted with the UID of the RedisCluster
+	PasswordHash string `json:"passwordHash,omitempty"`
+	// Conditions is the current conditions of the RedisCluster
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// RedisClusterEndpoint defines an endpoint of a RedisCluster
+type RedisClusterEndpoint struct {
+	// IPs are the IPs of the endpoint
+	IPs []string `json:"ips,omitempty"`
+	// Port is the port of the endpoint
+	Port int32 `json:"port,omitempty"`
+	// PrivateNetworkID is the ID of the private network of the endpoint
+	// Empty for public endpoints
+	PrivateNetworkID string `json:"privateNetworkID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=redis;rediscluster
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="NodeType",type="string",JSONPath=".spec.nodeType"
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".status.connectionSecret"
+
+// RedisCluster is the Schema for the redisclusters API
+type RedisCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedisClusterSpec   `json:"spec,omitempty"`
+	Status RedisClusterStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *RedisCluster) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *RedisCluster) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// RedisClusterList contains a list of RedisCluster
+type RedisClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedisCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedisCluster{}, &RedisClusterList{})
+}
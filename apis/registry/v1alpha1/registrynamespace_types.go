@@ -0,0 +1,148 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PullSecretSourcesAnnotation is the annotation of a Kubernetes namespace listing,
+// comma separated, the namespaces whose RegistryNamespaces may generate pull secrets in it
+const PullSecretSourcesAnnotation = "registry.scaleway.com/pull-secret-sources"
+
+// RegistryNamespaceSpec defines the desired state of RegistryNamespace
+type RegistryNamespaceSpec struct {
+	// NamespaceID is the ID of the registry namespace
+	// If empty it will create a new registry namespace
+	// If set it will use this ID as the registry namespace ID
+	// This field is immutable after creation
+	// +optional
+	NamespaceID string `json:"namespaceID,omitempty"`
+	// Region is the region in which the RegistryNamespace will be created
+	// This field is immutable after creation
+	// Defaults to the controller default region
+	// +optional
+	Region string `json:"region,omitempty"`
+	// OverrideName represents the name given to the registry namespace
+	// Defaults to the name of the RegistryNamespace
+	// This field is immutable after creation
+	// +optional
+	OverrideName string `json:"overrideName,omitempty"`
+	// Description is the description of the registry namespace
+	// +optional
+	Description string `json:"description,omitempty"`
+	// IsPublic represents whether the images of the registry namespace
+	// can be pulled anonymously
+	// Defaults to false
+	// +optional
+	IsPublic bool `json:"isPublic,omitempty"`
+	// PullSecret represents the image pull secret to generate for this
+	// registry namespace
+	// +optional
+	PullSecret *RegistryPullSecret `json:"pullSecret,omitempty"`
+	// PullApplicationID is the ID of the IAM application created to pull the images
+	// when the pull secret has no secretKeyRef
+	// It is set by the operator
+	// +optional
+	PullApplicationID string `json:"pullApplicationID,omitempty"`
+	// PullPolicyID is the ID of the IAM policy allowing the pull application
+	// to read the registry
+	// It is set by the operator
+	// +optional
+	PullPolicyID string `json:"pullPolicyID,omitempty"`
+	// PullAccessKey is the access key of the API key of the pull application
+	// It is set by the operator
+	// +optional
+	PullAccessKey string `json:"pullAccessKey,omitempty"`
+}
+
+// RegistryPullSecret defines the image pull secrets generated for a RegistryNamespace
+type RegistryPullSecret struct {
+	// Name is the name of the generated secrets
+	// Defaults to the name of the RegistryNamespace
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespaces are the Kubernetes namespaces in which the secret is generated
+	// Defaults to the namespace of the RegistryNamespace
+	// Namespaces other than the one of the RegistryNamespace must allow it
+	// with the registry.scaleway.com/pull-secret-sources annotation
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// SecretKeyRef is the reference to the Secret key holding the Scaleway
+	// secret key used to pull the images, such as the SCW_SECRET_KEY key
+	// of the Secret generated by an APIKey
+	// The secret must be in the namespace of the RegistryNamespace
+	// If empty, the operator creates an IAM application allowed to read the registry
+	// of the project, and stores the API key of this application in the
+	// <name>-pull-key Secret, deleted along with the RegistryNamespace
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// RegistryNamespaceStatus defines the observed state of RegistryNamespace
+type RegistryNamespaceStatus struct {
+	// Endpoint is the endpoint of the registry namespace
+	Endpoint string `json:"endpoint,omitempty"`
+	// ImageCount is the number of images in the registry namespace
+	ImageCount int32 `json:"imageCount,omitempty"`
+	// PullSecrets are the generated image pull secrets,
+	// in the namespace/name format
+	PullSecrets []string `json:"pullSecrets,omitempty"`
+	// Conditions is the current conditions of the RegistryNamespace
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=rgn;registrynamespace
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.endpoint"
+// +kubebuilder:printcolumn:name="Public",type="boolean",JSONPath=".spec.isPublic"
+// +kubebuilder:printcolumn:name="Images",type="integer",JSONPath=".status.imageCount"
+
+// RegistryNamespace is the Schema for the registrynamespaces API
+type RegistryNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RegistryNamespaceSpec   `json:"spec,omitempty"`
+	Status RegistryNamespaceStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *RegistryNamespace) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *RegistryNamespace) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// RegistryNamespaceList contains a list of RegistryNamespace
+type RegistryNamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RegistryNamespace `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RegistryNamespace{}, &RegistryNamespaceList{})
+}
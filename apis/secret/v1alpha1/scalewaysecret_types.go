@@ -0,0 +1,176 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScalewaySecretSpec defines the desired state of ScalewaySecret
+type ScalewaySecretSpec struct {
+	// SecretID is the ID of the secret in Secret Manager
+	// If empty it will look for the secret by name in Pull mode,
+	// and create a new secret in Push mode
+	// If set it will use this ID as the secret ID
+	// This field is immutable after creation
+	// +optional
+	SecretID string `json:"secretID,omitempty"`
+	// Region is the region of the secret
+	// This field is immutable after creation
+	// Defaults to the controller default region
+	// +optional
+	Region string `json:"region,omitempty"`
+	// SecretName is the name of the secret in Secret Manager
+	// Defaults to the name of the ScalewaySecret
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// Path is the path of the secret in Secret Manager
+	// Defaults to /
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Direction is the direction of the synchronization
+	// Pull materializes the Secret Manager secret into a Kubernetes Secret
+	// Push writes a Kubernetes Secret into a Secret Manager secret
+	// This field is immutable after creation
+	// +kubebuilder:validation:Enum=Pull;Push
+	// +optional
+	Direction ScalewaySecretDirection `json:"direction,omitempty"`
+	// Revision is the revision of the secret to pull, such as 1, latest or latest_enabled
+	// Only used in Pull mode
+	// Defaults to latest
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// RefreshInterval is the interval at which the secret is pulled again
+	// Only used in Pull mode
+	// The secret is only pulled on changes if not set
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// Target is the Kubernetes Secret the secret is pulled into
+	// Only used in Pull mode
+	// +optional
+	Target *ScalewaySecretTarget `json:"target,omitempty"`
+	// Source is the Kubernetes Secret the secret is pushed from
+	// Required in Push mode
+	// +optional
+	Source *ScalewaySecretSource `json:"source,omitempty"`
+}
+
+// ScalewaySecretDirection defines the direction of the synchronization
+type ScalewaySecretDirection string
+
+const (
+	// SecretDirectionPull pulls the secret from Secret Manager
+	SecretDirectionPull ScalewaySecretDirection = "Pull"
+	// SecretDirectionPush pushes the secret to Secret Manager
+	SecretDirectionPush ScalewaySecretDirection = "Push"
+)
+
+// ScalewaySecretTarget defines the Kubernetes Secret a secret is pulled into
+type ScalewaySecretTarget struct {
+	// Name is the name of the Kubernetes Secret
+	// Defaults to the name of the ScalewaySecret
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Type is the type of the Kubernetes Secret
+	// Defaults to Opaque
+	// +optional
+	Type corev1.SecretType `json:"type,omitempty"`
+	// Keys maps the secret data to the Kubernetes Secret keys
+	// By default, structured secrets (key_value, basic_credentials and database_credentials)
+	// have one key per property, and other secrets are written to the value key
+	// +optional
+	Keys []ScalewaySecretKeyMapping `json:"keys,omitempty"`
+}
+
+// ScalewaySecretKeyMapping defines the mapping of a secret value to a Kubernetes Secret key
+type ScalewaySecretKeyMapping struct {
+	// Key is the key in the Kubernetes Secret
+	Key string `json:"key"`
+	// Property is the property of the JSON secret data to use
+	// The whole secret data is used if empty
+	// +optional
+	Property string `json:"property,omitempty"`
+}
+
+// ScalewaySecretSource defines the Kubernetes Secret a secret is pushed from
+type ScalewaySecretSource struct {
+	// Name is the name of the Kubernetes Secret
+	Name string `json:"name"`
+	// Key is the key of the Kubernetes Secret to push
+	// All the keys are pushed as a key_value secret if empty
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ScalewaySecretStatus defines the observed state of ScalewaySecret
+type ScalewaySecretStatus struct {
+	// Revision is the revision of the last synchronized secret version
+	Revision int64 `json:"revision,omitempty"`
+	// Created represents whether the secret was created by the operator
+	// Only created secrets are deleted with the ScalewaySecret
+	Created bool `json:"created,omitempty"`
+	// DataHash is the hash of the last synchronized data,
+	// salted with the UID of the ScalewaySecret
+	DataHash string `json:"dataHash,omitempty"`
+	// TargetSecret is the name of the Kubernetes Secret holding the pulled secret
+	TargetSecret string `json:"targetSecret,omitempty"`
+	// LastSyncTime is the last time the secret was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Conditions is the current conditions of the ScalewaySecret
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=scwsecret
+// +kubebuilder:printcolumn:name="Direction",type="string",JSONPath=".spec.direction"
+// +kubebuilder:printcolumn:name="Revision",type="integer",JSONPath=".status.revision"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime"
+
+// ScalewaySecret is the Schema for the scalewaysecrets API
+type ScalewaySecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScalewaySecretSpec   `json:"spec,omitempty"`
+	Status ScalewaySecretStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *ScalewaySecret) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *ScalewaySecret) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// ScalewaySecretList contains a list of ScalewaySecret
+type ScalewaySecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScalewaySecret `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScalewaySecret{}, &ScalewaySecretList{})
+}
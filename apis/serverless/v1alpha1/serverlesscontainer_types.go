@@ -0,0 +1,163 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerlessContainerSpec defines the desired state of ServerlessContainer
+type ServerlessContainerSpec struct {
+	// ContainerID is the ID of the container
+	// If empty it will create a new container
+	// If set it will use this ID as the container ID
+	// This field is immutable after creation
+	// +optional
+	ContainerID string `json:"containerID,omitempty"`
+	// Region is the region of the container
+	// It is filled with the region of the namespace on creation
+	// This field is immutable after creation
+	// +optional
+	Region string `json:"region,omitempty"`
+	// NamespaceRef represents the reference to the namespace of the container
+	// This field is immutable after creation
+	NamespaceRef ServerlessNamespaceRef `json:"namespaceRef"`
+	// RegistryImage is the image of the container, such as rg.fr-par.scw.cloud/namespace/image:tag
+	RegistryImage string `json:"registryImage"`
+	// Port is the port the container listens on
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// MinScale is the minimum number of instances of the container
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinScale *int32 `json:"minScale,omitempty"`
+	// MaxScale is the maximum number of instances of the container
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxScale *int32 `json:"maxScale,omitempty"`
+	// MemoryLimit is the memory of an instance of the container, in MB
+	// +kubebuilder:validation:Minimum=128
+	// +optional
+	MemoryLimit *int32 `json:"memoryLimit,omitempty"`
+	// CPULimit is the CPU of an instance of the container, in mvCPU
+	// +kubebuilder:validation:Minimum=70
+	// +optional
+	CPULimit *int32 `json:"cpuLimit,omitempty"`
+	// Timeout is the maximum duration of a request
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Privacy represents whether the container is publicly reachable
+	// Defaults to public
+	// +optional
+	Privacy ServerlessPrivacy `json:"privacy,omitempty"`
+	// Description is the description of the container
+	// +optional
+	Description string `json:"description,omitempty"`
+	// EnvironmentVariables are the environment variables of the container
+	// +optional
+	EnvironmentVariables map[string]string `json:"environmentVariables,omitempty"`
+	// SecretEnvironmentVariables are the secret environment variables of the container
+	// +optional
+	SecretEnvironmentVariables []ServerlessSecretEnvVar `json:"secretEnvironmentVariables,omitempty"`
+	// Domains are the custom hostnames of the container
+	// A CNAME record to the container endpoint must exist for each of them
+	// +optional
+	Domains []string `json:"domains,omitempty"`
+}
+
+// ServerlessNamespaceRef defines a reference to a serverless namespace
+// Only one of ExternalID/Region or Name/Namespace must be specified
+type ServerlessNamespaceRef struct {
+	// ExternalID is the ID of the namespace
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+	// Region is the region of the namespace
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Name is the name of the ServerlessNamespace object
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the ServerlessNamespace object
+	// If empty, it will use the namespace of the referencing object
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ServerlessPrivacy defines the privacy of a serverless workload
+// +kubebuilder:validation:Enum=public;private
+type ServerlessPrivacy string
+
+const (
+	// PrivacyPublic makes the workload publicly reachable
+	PrivacyPublic ServerlessPrivacy = "public"
+	// PrivacyPrivate requires a token to reach the workload
+	PrivacyPrivate ServerlessPrivacy = "private"
+)
+
+// ServerlessContainerStatus defines the observed state of ServerlessContainer
+type ServerlessContainerStatus struct {
+	// Endpoint is the public endpoint of the container
+	Endpoint string `json:"endpoint,omitempty"`
+	// Domains are the URLs of the ready custom domains of the container
+	Domains []string `json:"domains,omitempty"`
+	// SecretsHash is the hash of the last pushed secret environment variables,
+	// salted with the UID of the object
+	SecretsHash string `json:"secretsHash,omitempty"`
+	// Conditions is the current conditions of the ServerlessContainer
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=slc;serverlesscontainer
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.registryImage"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.endpoint"
+
+// ServerlessContainer is the Schema for the serverlesscontainers API
+type ServerlessContainer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerlessContainerSpec   `json:"spec,omitempty"`
+	Status ServerlessContainerStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *ServerlessContainer) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *ServerlessContainer) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// ServerlessContainerList contains a list of ServerlessContainer
+type ServerlessContainerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerlessContainer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerlessContainer{}, &ServerlessContainerList{})
+}
@@ -0,0 +1,142 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerlessFunctionSpec defines the desired state of ServerlessFunction
+type ServerlessFunctionSpec struct {
+	// FunctionID is the ID of the function
+	// If empty it will create a new function
+	// If set it will use this ID as the function ID
+	// This field is immutable after creation
+	// +optional
+	FunctionID string `json:"functionID,omitempty"`
+	// Region is the region of the function
+	// It is filled with the region of the namespace on creation
+	// This field is immutable after creation
+	// +optional
+	Region string `json:"region,omitempty"`
+	// NamespaceRef represents the reference to the namespace of the function
+	// This field is immutable after creation
+	NamespaceRef ServerlessNamespaceRef `json:"namespaceRef"`
+	// Runtime is the runtime of the function, such as go121 or python311
+	Runtime string `json:"runtime"`
+	// Handler is the entrypoint of the function, such as handler.Handle
+	// +optional
+	Handler string `json:"handler,omitempty"`
+	// Code represents the source of the zip archive of the function code
+	// If empty, the code has to be deployed outside of the operator
+	// +optional
+	Code *ServerlessFunctionCode `json:"code,omitempty"`
+	// MinScale is the minimum number of instances of the function
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinScale *int32 `json:"minScale,omitempty"`
+	// MaxScale is the maximum number of instances of the function
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxScale *int32 `json:"maxScale,omitempty"`
+	// MemoryLimit is the memory of an instance of the function, in MB
+	// +kubebuilder:validation:Minimum=128
+	// +optional
+	MemoryLimit *int32 `json:"memoryLimit,omitempty"`
+	// Timeout is the maximum duration of a request
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Privacy represents whether the function is publicly reachable
+	// Defaults to public
+	// +optional
+	Privacy ServerlessPrivacy `json:"privacy,omitempty"`
+	// Description is the description of the function
+	// +optional
+	Description string `json:"description,omitempty"`
+	// EnvironmentVariables are the environment variables of the function
+	// +optional
+	EnvironmentVariables map[string]string `json:"environmentVariables,omitempty"`
+	// SecretEnvironmentVariables are the secret environment variables of the function
+	// +optional
+	SecretEnvironmentVariables []ServerlessSecretEnvVar `json:"secretEnvironmentVariables,omitempty"`
+	// Domains are the custom hostnames of the function
+	// A CNAME record to the function endpoint must exist for each of them
+	// +optional
+	Domains []string `json:"domains,omitempty"`
+}
+
+// ServerlessFunctionCode defines the source of a function code
+type ServerlessFunctionCode struct {
+	// ConfigMapKeyRef selects the key of a config map holding the zip archive
+	// The config map must be in the same namespace as the function
+	ConfigMapKeyRef corev1.ConfigMapKeySelector `json:"configMapKeyRef"`
+}
+
+// ServerlessFunctionStatus defines the observed state of ServerlessFunction
+type ServerlessFunctionStatus struct {
+	// Endpoint is the public endpoint of the function
+	Endpoint string `json:"endpoint,omitempty"`
+	// Domains are the URLs of the ready custom domains of the function
+	Domains []string `json:"domains,omitempty"`
+	// SecretsHash is the hash of the last pushed secret environment variables,
+	// salted with the UID of the object
+	SecretsHash string `json:"secretsHash,omitempty"`
+	// CodeHash is the hash of the last deployed code archive
+	CodeHash string `json:"codeHash,omitempty"`
+	// Conditions is the current conditions of the ServerlessFunction
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=slf;serverlessfunction
+// +kubebuilder:printcolumn:name="Runtime",type="string",JSONPath=".spec.runtime"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.endpoint"
+
+// ServerlessFunction is the Schema for the serverlessfunctions API
+type ServerlessFunction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerlessFunctionSpec   `json:"spec,omitempty"`
+	Status ServerlessFunctionStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *ServerlessFunction) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *ServerlessFunction) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// ServerlessFunctionList contains a list of ServerlessFunction
+type ServerlessFunctionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerlessFunction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerlessFunction{}, &ServerlessFunctionList{})
+}
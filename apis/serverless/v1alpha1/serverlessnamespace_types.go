@@ -0,0 +1,122 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerlessNamespaceSpec defines the desired state of ServerlessNamespace
+type ServerlessNamespaceSpec struct {
+	// NamespaceID is the ID of the namespace
+	// If empty it will create a new namespace
+	// If set it will use this ID as the namespace ID
+	// This field is immutable after creation
+	// +optional
+	NamespaceID string `json:"namespaceID,omitempty"`
+	// Region is the region in which the ServerlessNamespace will be created
+	// This field is immutable after creation
+	// Defaults to the controller default region
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Type is the type of workloads running in the ServerlessNamespace
+	// This field is immutable after creation
+	Type ServerlessNamespaceType `json:"type"`
+	// Description is the description of the ServerlessNamespace
+	// +optional
+	Description string `json:"description,omitempty"`
+	// EnvironmentVariables are the environment variables shared by the workloads of the ServerlessNamespace
+	// +optional
+	EnvironmentVariables map[string]string `json:"environmentVariables,omitempty"`
+	// SecretEnvironmentVariables are the secret environment variables shared by the workloads of the ServerlessNamespace
+	// +optional
+	SecretEnvironmentVariables []ServerlessSecretEnvVar `json:"secretEnvironmentVariables,omitempty"`
+}
+
+// ServerlessNamespaceType defines the type of a ServerlessNamespace
+// +kubebuilder:validation:Enum=Container;Function
+type ServerlessNamespaceType string
+
+const (
+	// NamespaceTypeContainer is the type of namespaces running containers
+	NamespaceTypeContainer ServerlessNamespaceType = "Container"
+	// NamespaceTypeFunction is the type of namespaces running functions
+	NamespaceTypeFunction ServerlessNamespaceType = "Function"
+)
+
+// ServerlessSecretEnvVar defines a secret environment variable
+type ServerlessSecretEnvVar struct {
+	// Name is the name of the environment variable
+	Name string `json:"name"`
+	// SecretKeyRef selects the key of a secret holding the value
+	// The secret must be in the same namespace as the object
+	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// ServerlessNamespaceStatus defines the observed state of ServerlessNamespace
+type ServerlessNamespaceStatus struct {
+	// RegistryEndpoint is the endpoint of the registry namespace holding the images
+	RegistryEndpoint string `json:"registryEndpoint,omitempty"`
+	// RegistryNamespaceID is the ID of the registry namespace holding the images
+	RegistryNamespaceID string `json:"registryNamespaceID,omitempty"`
+	// SecretsHash is the hash of the last pushed secret environment variables,
+	// salted with the UID of the object
+	SecretsHash string `json:"secretsHash,omitempty"`
+	// Conditions is the current conditions of the ServerlessNamespace
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=sln;serverlessnamespace
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.region"
+// +kubebuilder:printcolumn:name="Registry",type="string",JSONPath=".status.registryEndpoint"
+
+// ServerlessNamespace is the Schema for the serverlessnamespaces API
+type ServerlessNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerlessNamespaceSpec   `json:"spec,omitempty"`
+	Status ServerlessNamespaceStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *ServerlessNamespace) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *ServerlessNamespace) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// ServerlessNamespaceList contains a list of ServerlessNamespace
+type ServerlessNamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerlessNamespace `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerlessNamespace{}, &ServerlessNamespaceList{})
+}
@@ -0,0 +1,104 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PrivateNetworkSpec defines the desired state of PrivateNetwork
+type PrivateNetworkSpec struct {
+	// PrivateNetworkID is the ID of the private network
+	// If empty it will create a new private network
+	// If set it will use this ID as the private network ID
+	// This field is immutable after creation
+	// +optional
+	PrivateNetworkID string `json:"privateNetworkID,omitempty"`
+	// Region is the region in which the PrivateNetwork will be created
+	// This field is immutable after creation
+	// Defaults to the controller default region
+	// +optional
+	Region string `json:"region,omitempty"`
+	// VPCID is the ID of the VPC of the PrivateNetwork
+	// Defaults to the default VPC of the region
+	// This field is immutable after creation
+	// +optional
+	VPCID string `json:"vpcID,omitempty"`
+	// Subnets are the CIDRs of the PrivateNetwork, managed by IPAM
+	// If empty, a subnet will be allocated by IPAM
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+	// EnableDHCP represents whether the managed DHCP is enabled on the PrivateNetwork
+	// Defaults to true, as the DHCP is always enabled on VPC v2 private networks
+	// It can not be disabled once enabled, a warning event being emitted if it is
+	// enabled while this field is false
+	// +kubebuilder:default=true
+	// +optional
+	EnableDHCP *bool `json:"enableDHCP,omitempty"`
+}
+
+// PrivateNetworkStatus defines the observed state of PrivateNetwork
+type PrivateNetworkStatus struct {
+	// VPCID is the ID of the VPC of the PrivateNetwork
+	VPCID string `json:"vpcID,omitempty"`
+	// Subnets are the CIDRs allocated to the PrivateNetwork
+	Subnets []string `json:"subnets,omitempty"`
+	// DHCPEnabled represents whether the managed DHCP is enabled
+	DHCPEnabled bool `json:"dhcpEnabled,omitempty"`
+	// Conditions is the current conditions of the PrivateNetwork
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pn;privatenetwork
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".spec.privateNetworkID"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.region"
+// +kubebuilder:printcolumn:name="Subnets",type="string",JSONPath=".status.subnets"
+
+// PrivateNetwork is the Schema for the privatenetworks API
+type PrivateNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PrivateNetworkSpec   `json:"spec,omitempty"`
+	Status PrivateNetworkStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *PrivateNetwork) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *PrivateNetwork) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// PrivateNetworkList contains a list of PrivateNetwork
+type PrivateNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PrivateNetwork `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PrivateNetwork{}, &PrivateNetworkList{})
+}
@@ -0,0 +1,222 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PublicGatewaySpec defines the desired state of PublicGateway
+type PublicGatewaySpec struct {
+	// GatewayID is the ID of the gateway
+	// If empty it will create a new gateway
+	// If set it will use this ID as the gateway ID
+	// This field is immutable after creation
+	// +optional
+	GatewayID string `json:"gatewayID,omitempty"`
+	// Zone is the zone in which the PublicGateway will run
+	// This field is immutable after creation
+	// Defaults to the controller default zone
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// Type is the type of the gateway, such as VPC-GW-S
+	Type string `json:"type"`
+	// IPID is the ID of an existing flexible IP to attach to the gateway
+	// If empty, a new IP will be reserved and released along with the gateway
+	// This field is immutable after creation
+	// +optional
+	IPID string `json:"ipID,omitempty"`
+	// UpstreamDNSServers overrides the DNS servers used by the gateway
+	// +optional
+	UpstreamDNSServers []string `json:"upstreamDNSServers,omitempty"`
+	// Bastion represents the SSH bastion configuration of the gateway
+	// If empty, the bastion is disabled
+	// +optional
+	Bastion *PublicGatewayBastion `json:"bastion,omitempty"`
+	// PrivateNetworks are the private networks attached to the gateway
+	// +optional
+	PrivateNetworks []PublicGatewayNetwork `json:"privateNetworks,omitempty"`
+	// NATRules are the port address translation rules of the gateway
+	// +optional
+	NATRules []PublicGatewayNATRule `json:"natRules,omitempty"`
+}
+
+// PublicGatewayBastion defines the SSH bastion of a PublicGateway
+type PublicGatewayBastion struct {
+	// Port is the port on which the bastion listens
+	// Defaults to 61000
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=59999
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// PublicGatewayNetwork defines a private network attached to a PublicGateway
+type PublicGatewayNetwork struct {
+	// PrivateNetworkRef represents the reference to the private network
+	PrivateNetworkRef PrivateNetworkRef `json:"privateNetworkRef"`
+	// EnableMasquerade represents whether the gateway masquerades traffic from the private network
+	// +optional
+	EnableMasquerade bool `json:"enableMasquerade,omitempty"`
+	// DHCP represents the DHCP configuration of the gateway on the private network
+	// Only one of DHCP or IPAM must be specified
+	// +optional
+	DHCP *PublicGatewayDHCP `json:"dhcp,omitempty"`
+	// IPAM represents the IPAM configuration of the gateway on the private network
+	// Only one of DHCP or IPAM must be specified
+	// Defaults to IPAM when DHCP is not specified
+	// +optional
+	IPAM *PublicGatewayIPAM `json:"ipam,omitempty"`
+}
+
+// PrivateNetworkRef defines a reference to a private network
+// Only one of ExternalID or Name/Namespace must be specified
+type PrivateNetworkRef struct {
+	// ExternalID is the ID of the private network
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+	// Name is the name of the PrivateNetwork object
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the PrivateNetwork object
+	// If empty, it will use the namespace of the gateway
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// PublicGatewayDHCP defines the DHCP server of a PublicGateway on a private network
+type PublicGatewayDHCP struct {
+	// Subnet is the CIDR served by the DHCP server
+	// This field is immutable after creation
+	Subnet string `json:"subnet"`
+	// Address is the IP of the gateway on the subnet
+	// Defaults to the first address of the subnet
+	// +optional
+	Address string `json:"address,omitempty"`
+	// PoolLow is the first IP of the dynamic pool
+	// +optional
+	PoolLow string `json:"poolLow,omitempty"`
+	// PoolHigh is the last IP of the dynamic pool
+	// +optional
+	PoolHigh string `json:"poolHigh,omitempty"`
+	// PushDefaultRoute represents whether the gateway is advertised as the default route
+	// +optional
+	PushDefaultRoute bool `json:"pushDefaultRoute,omitempty"`
+	// PushDNSServer represents whether the gateway is advertised as the DNS server
+	// +optional
+	PushDNSServer bool `json:"pushDNSServer,omitempty"`
+}
+
+// PublicGatewayIPAM defines the IPAM configuration of a PublicGateway on a private network
+type PublicGatewayIPAM struct {
+	// PushDefaultRoute represents whether the gateway is advertised as the default route
+	// +optional
+	PushDefaultRoute bool `json:"pushDefaultRoute,omitempty"`
+}
+
+// PublicGatewayNATRule defines a port address translation rule of a PublicGateway
+type PublicGatewayNATRule struct {
+	// PublicPort is the port on the gateway IP
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	PublicPort int32 `json:"publicPort"`
+	// PrivateIP is the IP of the target on the private network
+	PrivateIP string `json:"privateIP"`
+	// PrivatePort is the port on the target
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	PrivatePort int32 `json:"privatePort"`
+	// Protocol is the protocol of the rule
+	// Defaults to both
+	// +optional
+	Protocol NATRuleProtocol `json:"protocol,omitempty"`
+}
+
+// NATRuleProtocol defines the protocol of a NAT rule
+// +kubebuilder:validation:Enum=tcp;udp;both
+type NATRuleProtocol string
+
+const (
+	// NATRuleProtocolTCP is the TCP protocol
+	NATRuleProtocolTCP NATRuleProtocol = "tcp"
+	// NATRuleProtocolUDP is the UDP protocol
+	NATRuleProtocolUDP NATRuleProtocol = "udp"
+	// NATRuleProtocolBoth is both TCP and UDP protocols
+	NATRuleProtocolBoth NATRuleProtocol = "both"
+)
+
+// PublicGatewayStatus defines the observed state of PublicGateway
+type PublicGatewayStatus struct {
+	// IP is the public IP of the gateway
+	IP string `json:"ip,omitempty"`
+	// IPID is the ID of the public IP of the gateway
+	IPID string `json:"ipID,omitempty"`
+	// GatewayNetworks are the attachments of the gateway to private networks
+	GatewayNetworks []PublicGatewayNetworkStatus `json:"gatewayNetworks,omitempty"`
+	// Conditions is the current conditions of the PublicGateway
+	scalewaymetav1alpha1.Status `json:",inline"`
+}
+
+// PublicGatewayNetworkStatus defines the observed state of a gateway attachment
+type PublicGatewayNetworkStatus struct {
+	// PrivateNetworkID is the ID of the private network
+	PrivateNetworkID string `json:"privateNetworkID"`
+	// GatewayNetworkID is the ID of the attachment
+	GatewayNetworkID string `json:"gatewayNetworkID"`
+	// DHCPID is the ID of the DHCP configuration, if any
+	DHCPID string `json:"dhcpID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pgw;publicgateway
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".spec.gatewayID"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="IP",type="string",JSONPath=".status.ip"
+
+// PublicGateway is the Schema for the publicgateways API
+type PublicGateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PublicGatewaySpec   `json:"spec,omitempty"`
+	Status PublicGatewayStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the scaleway meta status
+func (r *PublicGateway) GetStatus() scalewaymetav1alpha1.Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the scaleway meta status
+func (r *PublicGateway) SetStatus(status scalewaymetav1alpha1.Status) {
+	r.Status.Status = status
+}
+
+// +kubebuilder:object:root=true
+
+// PublicGatewayList contains a list of PublicGateway
+type PublicGatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PublicGateway `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PublicGateway{}, &PublicGatewayList{})
+}
@@ -0,0 +1,47 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	blockv1alpha1 "github.com/scaleway/scaleway-operator/apis/block/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// BlockVolumeSnapshotReconciler reconciles a BlockVolumeSnapshot object
+type BlockVolumeSnapshotReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=block.scaleway.com,resources=blockvolumesnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=block.scaleway.com,resources=blockvolumesnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=block.scaleway.com,resources=blockvolumes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile reconciles the Block Volume Snapshot
+func (r *BlockVolumeSnapshotReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &blockv1alpha1.BlockVolumeSnapshot{})
+}
+
+// SetupWithManager registers the Block Volume Snapshot controller
+func (r *BlockVolumeSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&blockv1alpha1.BlockVolumeSnapshot{}).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
@@ -0,0 +1,85 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	blockv1alpha1 "github.com/scaleway/scaleway-operator/apis/block/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	blockmanager "github.com/scaleway/scaleway-operator/pkg/manager/block"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/webhooks"
+	blockwebhook "github.com/scaleway/scaleway-operator/webhooks/block"
+)
+
+func init() {
+	scaleway.RegisterProduct(scaleway.Product{
+		Name:        "block",
+		AddToScheme: blockv1alpha1.AddToScheme,
+		Kinds: []scaleway.Kind{
+			{
+				Name: "BlockVolume",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &blockmanager.VolumeManager{
+						Client:      c,
+						API:         block.NewAPI(scwClient),
+						InstanceAPI: instance.NewAPI(scwClient),
+						Log:         log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&BlockVolumeReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "BlockVolume", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&blockwebhook.BlockVolumeValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "BlockVolumeSnapshot",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &blockmanager.SnapshotManager{
+						Client: c,
+						API:    block.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&BlockVolumeSnapshotReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "BlockVolumeSnapshot", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&blockwebhook.BlockVolumeSnapshotValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+		},
+	})
+}
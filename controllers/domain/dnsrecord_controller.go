@@ -0,0 +1,97 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	domainv1alpha1 "github.com/scaleway/scaleway-operator/apis/domain/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+	redisv1alpha1 "github.com/scaleway/scaleway-operator/apis/redis/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// DNSRecordReconciler reconciles a DNSRecord object
+type DNSRecordReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=domain.scaleway.com,resources=dnsrecords,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=domain.scaleway.com,resources=dnsrecords/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rdb.scaleway.com,resources=rdbinstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=redis.scaleway.com,resources=redisclusters,verbs=get;list;watch
+
+// Reconcile reconciles the DNS Record
+func (r *DNSRecordReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &domainv1alpha1.DNSRecord{})
+}
+
+// SetupWithManager registers the DNS Record controller
+func (r *DNSRecordReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &domainv1alpha1.DNSRecord{}, valueFromField, func(obj runtime.Object) []string {
+		record := obj.(*domainv1alpha1.DNSRecord)
+		return indexValueFrom(record.Spec.ValueFrom, record.Namespace)
+	})
+	if err != nil {
+		return err
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&domainv1alpha1.DNSRecord{}).
+		WithOptions(r.ScalewayReconciler.Options)
+
+	// watch the objects records can get their value from
+	sources := []runtime.Object{
+		&corev1.Service{},
+	}
+	// the sources of the other products are only watched when these products are enabled
+	if r.ScalewayReconciler.ProductEnabled("rdb") {
+		sources = append(sources, &rdbv1beta1.RDBInstance{})
+	}
+	if r.ScalewayReconciler.ProductEnabled("redis") {
+		sources = append(sources, &redisv1alpha1.RedisCluster{})
+	}
+	for _, sourceObj := range sources {
+		gvk, err := apiutil.GVKForObject(sourceObj, mgr.GetScheme())
+		if err != nil {
+			return err
+		}
+		builder = builder.Watches(&source.Kind{Type: sourceObj}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: r.sourceToRequests(gvk.GroupKind()),
+		})
+	}
+
+	return builder.Complete(r)
+}
+
+// sourceToRequests enqueues the DNSRecords getting their value from the given object
+func (r *DNSRecordReconciler) sourceToRequests(groupKind schema.GroupKind) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		return r.ScalewayReconciler.RequestsForIndex(&domainv1alpha1.DNSRecordList{}, valueFromField, valueFromKey(groupKind, controllers.RefKey(obj.Meta.GetNamespace(), obj.Meta.GetName(), "")))
+	}
+}
@@ -0,0 +1,48 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	domainv1alpha1 "github.com/scaleway/scaleway-operator/apis/domain/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+const (
+	// valueFromField is the field index of the objects records get their value from
+	valueFromField = "spec.valueFrom.objectRef"
+)
+
+// indexValueFrom returns the indexed values of the object a record gets its value from
+// References with an invalid API version are not indexed since they don't match any object
+func indexValueFrom(valueFrom *domainv1alpha1.DNSRecordValueFrom, namespace string) []string {
+	if valueFrom == nil {
+		return nil
+	}
+	gv, err := schema.ParseGroupVersion(valueFrom.ObjectRef.APIVersion)
+	if err != nil {
+		return nil
+	}
+	groupKind := gv.WithKind(valueFrom.ObjectRef.Kind).GroupKind()
+	return []string{valueFromKey(groupKind, controllers.RefKey(namespace, valueFrom.ObjectRef.Name, ""))}
+}
+
+// valueFromKey returns the indexed value of an object of the given kind records get their value from
+func valueFromKey(groupKind schema.GroupKind, refKey string) string {
+	return groupKind.String() + "/" + refKey
+}
@@ -0,0 +1,104 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iamv1alpha1 "github.com/scaleway/scaleway-operator/apis/iam/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	iammanager "github.com/scaleway/scaleway-operator/pkg/manager/iam"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/webhooks"
+	iamwebhook "github.com/scaleway/scaleway-operator/webhooks/iam"
+)
+
+func init() {
+	scaleway.RegisterProduct(scaleway.Product{
+		Name:        "iam",
+		AddToScheme: iamv1alpha1.AddToScheme,
+		Kinds: []scaleway.Kind{
+			{
+				Name: "IAMApplication",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &iammanager.ApplicationManager{
+						Client: c,
+						API:    iam.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&IAMApplicationReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "IAMApplication", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&iamwebhook.IAMApplicationValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "IAMPolicy",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &iammanager.PolicyManager{
+						Client: c,
+						API:    iam.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&IAMPolicyReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "IAMPolicy", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&iamwebhook.IAMPolicyValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "IAMAPIKey",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &iammanager.APIKeyManager{
+						Client: c,
+						API:    iam.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&IAMAPIKeyReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "IAMAPIKey", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&iamwebhook.IAMAPIKeyValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+		},
+	})
+}
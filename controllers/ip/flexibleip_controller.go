@@ -0,0 +1,46 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ipv1alpha1 "github.com/scaleway/scaleway-operator/apis/ip/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// FlexibleIPReconciler reconciles a FlexibleIP object
+type FlexibleIPReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=ip.scaleway.com,resources=flexibleips,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ip.scaleway.com,resources=flexibleips/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile reconciles the Flexible IP
+func (r *FlexibleIPReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &ipv1alpha1.FlexibleIP{})
+}
+
+// SetupWithManager registers the Flexible IP controller
+func (r *FlexibleIPReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ipv1alpha1.FlexibleIP{}).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
@@ -0,0 +1,64 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ipv1alpha1 "github.com/scaleway/scaleway-operator/apis/ip/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	ipmanager "github.com/scaleway/scaleway-operator/pkg/manager/ip"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/webhooks"
+	ipwebhook "github.com/scaleway/scaleway-operator/webhooks/ip"
+)
+
+func init() {
+	scaleway.RegisterProduct(scaleway.Product{
+		Name:        "ip",
+		AddToScheme: ipv1alpha1.AddToScheme,
+		Kinds: []scaleway.Kind{
+			{
+				Name: "FlexibleIP",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &ipmanager.FlexibleIPManager{
+						Client:      c,
+						InstanceAPI: instance.NewAPI(scwClient),
+						LBAPI:       lb.NewZonedAPI(scwClient),
+						Log:         log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&FlexibleIPReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "FlexibleIP", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&ipwebhook.FlexibleIPValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+		},
+	})
+}
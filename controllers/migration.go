@@ -0,0 +1,153 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=get;update;patch
+
+const migrationPageSize = 100
+
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// StorageVersionMigrator rewrites the stored objects of the kinds served in several versions
+// in their storage version, which is their conversion hub, then removes the other versions
+// from the stored versions of their CRD, so these versions can later stop being served
+type StorageVersionMigrator struct {
+	Client     client.Client
+	Scheme     *runtime.Scheme
+	RESTMapper meta.RESTMapper
+	Log        logr.Logger
+	// Kinds are the names of the kinds to migrate
+	// Kinds with a single version are skipped
+	Kinds []string
+}
+
+// Start migrates the kinds, logging the failures without stopping the manager
+func (m *StorageVersionMigrator) Start(stop <-chan struct{}) error {
+	ctx := context.Background()
+
+	for _, kind := range m.Kinds {
+		hubGVK, ok := m.getHubGVK(kind)
+		if !ok {
+			continue
+		}
+
+		log := m.Log.WithValues("kind", hubGVK.String())
+		if err := m.migrate(ctx, log, hubGVK); err != nil {
+			log.Error(err, "failed to migrate storage version")
+		}
+	}
+
+	return nil
+}
+
+// getHubGVK returns the version of the given kind implementing the conversion hub
+func (m *StorageVersionMigrator) getHubGVK(kind string) (schema.GroupVersionKind, bool) {
+	for gvk := range m.Scheme.AllKnownTypes() {
+		if gvk.Kind != kind {
+			continue
+		}
+		obj, err := m.Scheme.New(gvk)
+		if err != nil {
+			continue
+		}
+		if _, ok := obj.(conversion.Hub); ok {
+			return gvk, true
+		}
+	}
+	return schema.GroupVersionKind{}, false
+}
+
+func (m *StorageVersionMigrator) migrate(ctx context.Context, log logr.Logger, hubGVK schema.GroupVersionKind) error {
+	mapping, err := m.RESTMapper.RESTMapping(hubGVK.GroupKind(), hubGVK.Version)
+	if err != nil {
+		return err
+	}
+
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	err = m.Client.Get(ctx, client.ObjectKey{Name: mapping.Resource.Resource + "." + hubGVK.Group}, crd)
+	if err != nil {
+		return err
+	}
+
+	storedVersions, _, err := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+	if err != nil {
+		return err
+	}
+	if len(storedVersions) == 1 && storedVersions[0] == hubGVK.Version {
+		return nil
+	}
+
+	log.Info("migrating storage version", "storedVersions", storedVersions)
+
+	listGVK := hubGVK
+	listGVK.Kind += "List"
+	continueToken := ""
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(listGVK)
+		err := m.Client.List(ctx, list, client.Limit(migrationPageSize), client.Continue(continueToken))
+		if err != nil {
+			return err
+		}
+
+		for i := range list.Items {
+			// an update without changes rewrites the object in the storage version
+			err := m.Client.Update(ctx, &list.Items[i])
+			// a deleted or concurrently updated object does not need to be rewritten
+			if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+				return err
+			}
+		}
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			break
+		}
+	}
+
+	err = unstructured.SetNestedStringSlice(crd.Object, []string{hubGVK.Version}, "status", "storedVersions")
+	if err != nil {
+		return err
+	}
+	err = m.Client.Status().Update(ctx, crd)
+	if err != nil {
+		return err
+	}
+
+	log.Info("storage version migrated")
+
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	mnqv1alpha1 "github.com/scaleway/scaleway-operator/apis/mnq/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// MNQTopicReconciler reconciles a MNQTopic object
+type MNQTopicReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=mnq.scaleway.com,resources=mnqtopics,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=mnq.scaleway.com,resources=mnqtopics/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile reconciles the SNS Topic
+func (r *MNQTopicReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &mnqv1alpha1.MNQTopic{})
+}
+
+// SetupWithManager registers the SNS Topic controller
+func (r *MNQTopicReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mnqv1alpha1.MNQTopic{}).
+		Owns(&corev1.Secret{}).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
@@ -0,0 +1,104 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mnqv1alpha1 "github.com/scaleway/scaleway-operator/apis/mnq/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	mnqmanager "github.com/scaleway/scaleway-operator/pkg/manager/mnq"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/webhooks"
+	mnqwebhook "github.com/scaleway/scaleway-operator/webhooks/mnq"
+)
+
+func init() {
+	scaleway.RegisterProduct(scaleway.Product{
+		Name:        "mnq",
+		AddToScheme: mnqv1alpha1.AddToScheme,
+		Kinds: []scaleway.Kind{
+			{
+				Name: "MNQNatsAccount",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &mnqmanager.NatsAccountManager{
+						Client: c,
+						API:    mnq.NewNatsAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&MNQNatsAccountReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "MNQNatsAccount", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&mnqwebhook.MNQNatsAccountValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "MNQQueue",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &mnqmanager.QueueManager{
+						Client: c,
+						API:    mnq.NewSqsAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&MNQQueueReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "MNQQueue", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&mnqwebhook.MNQQueueValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "MNQTopic",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &mnqmanager.TopicManager{
+						Client: c,
+						API:    mnq.NewSnsAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&MNQTopicReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "MNQTopic", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&mnqwebhook.MNQTopicValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+		},
+	})
+}
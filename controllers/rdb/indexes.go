@@ -0,0 +1,62 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	rdbmanager "github.com/scaleway/scaleway-operator/pkg/manager/rdb"
+)
+
+const (
+	// instanceRefField is the field index of the RDBInstance referenced by databases and users
+	instanceRefField = "spec.instanceRef"
+	// passwordSecretField is the field index of the secret holding the password of users
+	passwordSecretField = "spec.password.valueFrom.secretKeyRef"
+	// aclSourceField is the field index of the objects used as ACL sources by instances
+	aclSourceField = "spec.acl.sources"
+)
+
+// indexInstanceRef returns the indexed values of a reference to a RDBInstance
+// References to external instances are not indexed since they don't match any object
+func indexInstanceRef(ref rdbv1beta1.RDBInstanceRef, namespace string) []string {
+	if ref.Object == nil {
+		return nil
+	}
+	return []string{controllers.RefKey(ref.Object.Namespace, ref.Object.Name, namespace)}
+}
+
+// indexACLSources returns the indexed values of the objects used as ACL sources by an instance
+func indexACLSources(acl *rdbv1beta1.RDBACL, namespace string) []string {
+	if acl == nil {
+		return nil
+	}
+	values := []string{}
+	for _, source := range acl.Sources {
+		kind, ref := rdbmanager.GetACLSourceKindAndRef(source)
+		if ref == nil {
+			continue
+		}
+		values = append(values, aclSourceKey(kind, controllers.RefKey(ref.Namespace, ref.Name, namespace)))
+	}
+	return values
+}
+
+// aclSourceKey returns the indexed value of an ACL source of the given kind
+func aclSourceKey(kind string, refKey string) string {
+	return kind + "/" + refKey
+}
@@ -0,0 +1,139 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	rdbmanager "github.com/scaleway/scaleway-operator/pkg/manager/rdb"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/webhooks"
+	rdbwebhook "github.com/scaleway/scaleway-operator/webhooks/rdb"
+)
+
+// addToScheme adds all the served versions of the RDB API to the scheme
+func addToScheme(s *runtime.Scheme) error {
+	if err := rdbv1alpha1.AddToScheme(s); err != nil {
+		return err
+	}
+	return rdbv1beta1.AddToScheme(s)
+}
+
+func init() {
+	scaleway.RegisterProduct(scaleway.Product{
+		Name:        "rdb",
+		AddToScheme: addToScheme,
+		Kinds: []scaleway.Kind{
+			{
+				Name: "RDBInstance",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					region, _ := scwClient.GetDefaultRegion()
+					return &rdbmanager.InstanceManager{
+						Client:        c,
+						API:           rdb.NewAPI(scwClient),
+						Log:           log,
+						DefaultRegion: region,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&RDBInstanceReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "RDBInstance", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					err := (&rdbwebhook.RDBInstanceDefaulter{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+					if err != nil {
+						return err
+					}
+					return (&rdbwebhook.RDBInstanceValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "RDBDatabase",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					region, _ := scwClient.GetDefaultRegion()
+					return &rdbmanager.DatabaseManager{
+						Client:        c,
+						API:           rdb.NewAPI(scwClient),
+						DefaultRegion: region,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&RDBDatabaseReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "RDBDatabase", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					err := (&rdbwebhook.RDBDatabaseDefaulter{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+					if err != nil {
+						return err
+					}
+					return (&rdbwebhook.RDBDatabaseValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "RDBUser",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					region, _ := scwClient.GetDefaultRegion()
+					return &rdbmanager.UserManager{
+						Client:        c,
+						API:           rdb.NewAPI(scwClient),
+						DefaultRegion: region,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&RDBUserReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "RDBUser", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					err := (&rdbwebhook.RDBUserDefaulter{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+					if err != nil {
+						return err
+					}
+					return (&rdbwebhook.RDBUserValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+		},
+	})
+}
@@ -17,9 +17,15 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
 	"github.com/scaleway/scaleway-operator/controllers"
 )
 
@@ -31,15 +37,33 @@ type RDBDatabaseReconciler struct {
 // +kubebuilder:rbac:groups=rdb.scaleway.com,resources=rdbdatabases,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rdb.scaleway.com,resources=rdbdatabases/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=rdb.scaleway.com,resources=rdbinstances,verbs=get;list;watch
 
 // Reconcile reconsiles the RDB Database
 func (r *RDBDatabaseReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return r.ScalewayReconciler.Reconcile(req, &rdbv1alpha1.RDBDatabase{})
+	return r.ScalewayReconciler.Reconcile(req, &rdbv1beta1.RDBDatabase{})
 }
 
 // SetupWithManager registers the RDB Database controller
 func (r *RDBDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &rdbv1beta1.RDBDatabase{}, instanceRefField, func(obj runtime.Object) []string {
+		database := obj.(*rdbv1beta1.RDBDatabase)
+		return indexInstanceRef(database.Spec.InstanceRef, database.Namespace)
+	})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&rdbv1alpha1.RDBDatabase{}).
+		For(&rdbv1beta1.RDBDatabase{}).
+		Watches(&source.Kind{Type: &rdbv1beta1.RDBInstance{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.instanceToRequests),
+		}).
+		WithOptions(r.ScalewayReconciler.Options).
 		Complete(r)
 }
+
+// instanceToRequests enqueues the RDBDatabases of the given instance
+func (r *RDBDatabaseReconciler) instanceToRequests(obj handler.MapObject) []reconcile.Request {
+	return r.ScalewayReconciler.RequestsForIndex(&rdbv1beta1.RDBDatabaseList{}, instanceRefField, controllers.RefKey(obj.Meta.GetNamespace(), obj.Meta.GetName(), ""))
+}
@@ -0,0 +1,143 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+)
+
+func remoteDatabaseExists(instanceID string, name string) (bool, error) {
+	scwClient, err := fakeServer.Client()
+	if err != nil {
+		return false, err
+	}
+	databasesResp, err := rdb.NewAPI(scwClient).ListDatabases(&rdb.ListDatabasesRequest{
+		Region:     scw.RegionFrPar,
+		InstanceID: instanceID,
+	}, scw.WithAllPages())
+	if err != nil {
+		return false, err
+	}
+	for _, database := range databasesResp.Databases {
+		if database.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func Test_RDBDatabase_Lifecycle(t *testing.T) {
+	skipWithoutEnv(t)
+	ctx := context.Background()
+
+	createNamespace(t, "database-instance")
+	createNamespace(t, "database-other")
+
+	instance := newTestInstance("database-instance", "instance")
+	if err := k8sClient.Create(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	instanceKey := client.ObjectKey{Namespace: instance.Namespace, Name: instance.Name}
+	instance = waitForInstance(t, instanceKey)
+	instanceID := instance.Spec.InstanceID
+
+	cases := []struct {
+		database   *rdbv1beta1.RDBDatabase
+		remoteName string
+		owned      bool
+	}{
+		{
+			&rdbv1beta1.RDBDatabase{
+				ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "database-instance"},
+				Spec: rdbv1beta1.RDBDatabaseSpec{
+					InstanceRef: rdbv1beta1.RDBInstanceRef{Object: &rdbv1beta1.RDBInstanceObjectRef{Name: "instance"}},
+				},
+			},
+			"database",
+			true,
+		},
+		{
+			// owner references can't cross namespaces, the database must still be created
+			&rdbv1beta1.RDBDatabase{
+				ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "database-other"},
+				Spec: rdbv1beta1.RDBDatabaseSpec{
+					InstanceRef:  rdbv1beta1.RDBInstanceRef{Object: &rdbv1beta1.RDBInstanceObjectRef{Name: "instance", Namespace: "database-instance"}},
+					OverrideName: "other",
+				},
+			},
+			"other",
+			false,
+		},
+	}
+
+	for i, c := range cases {
+		if err := k8sClient.Create(ctx, c.database); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		key := client.ObjectKey{Namespace: c.database.Namespace, Name: c.database.Name}
+
+		database := &rdbv1beta1.RDBDatabase{}
+		eventually(t, func() (bool, error) {
+			if err := k8sClient.Get(ctx, key, database); err != nil {
+				return false, err
+			}
+			return isReconciled(database.GetStatus()), nil
+		}, "database "+key.String()+" reconciled")
+
+		exists, err := remoteDatabaseExists(instanceID, c.remoteName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Errorf("case %d: database %s not created on the API", i, c.remoteName)
+		}
+
+		owned := metav1.IsControlledBy(database, instance)
+		if owned != c.owned {
+			t.Errorf("case %d: got owned %t instead of %t", i, owned, c.owned)
+		}
+
+		if err := k8sClient.Delete(ctx, database); err != nil {
+			t.Fatal(err)
+		}
+		waitForDeletion(t, key, &rdbv1beta1.RDBDatabase{})
+
+		exists, err = remoteDatabaseExists(instanceID, c.remoteName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists {
+			t.Errorf("case %d: database %s not deleted on the API", i, c.remoteName)
+		}
+	}
+
+	if err := k8sClient.Get(ctx, instanceKey, instance); err != nil {
+		t.Fatal(err)
+	}
+	if err := k8sClient.Delete(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	waitForDeletion(t, instanceKey, &rdbv1beta1.RDBInstance{})
+}
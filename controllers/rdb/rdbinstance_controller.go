@@ -17,10 +17,21 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	ipv1alpha1 "github.com/scaleway/scaleway-operator/apis/ip/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
 	"github.com/scaleway/scaleway-operator/controllers"
+	rdbmanager "github.com/scaleway/scaleway-operator/pkg/manager/rdb"
 )
 
 // RDBInstanceReconciler reconciles a RDBInstance object
@@ -32,15 +43,77 @@ type RDBInstanceReconciler struct {
 // +kubebuilder:rbac:groups=rdb.scaleway.com,resources=rdbinstances/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vpc.scaleway.com,resources=publicgateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ip.scaleway.com,resources=flexibleips,verbs=get;list;watch
 
 // Reconcile reconciles the RDB Instance
 func (r *RDBInstanceReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return r.ScalewayReconciler.Reconcile(req, &rdbv1alpha1.RDBInstance{})
+	return r.ScalewayReconciler.Reconcile(req, &rdbv1beta1.RDBInstance{})
 }
 
 // SetupWithManager registers the RDB Instance Controller
 func (r *RDBInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&rdbv1alpha1.RDBInstance{}).
-		Complete(r)
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &rdbv1beta1.RDBInstance{}, controllers.AllowClusterField, func(obj runtime.Object) []string {
+		instance := obj.(*rdbv1beta1.RDBInstance)
+		if instance.Spec.ACL == nil || !instance.Spec.ACL.AllowCluster {
+			return nil
+		}
+		return []string{controllers.AllowClusterValue}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &rdbv1beta1.RDBInstance{}, aclSourceField, func(obj runtime.Object) []string {
+		instance := obj.(*rdbv1beta1.RDBInstance)
+		return indexACLSources(instance.Spec.ACL, instance.Namespace)
+	})
+	if err != nil {
+		return err
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&rdbv1beta1.RDBInstance{}).
+		Watches(&source.Kind{Type: &corev1.Node{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.nodeToRequests),
+		}, builder.WithPredicates(controllers.NodeAddressesChanged)).
+		WithOptions(r.ScalewayReconciler.Options)
+
+	// watch the objects instances can get the IPs of their ACLs from
+	sources := map[string]runtime.Object{
+		rdbmanager.ACLSourceService:       &corev1.Service{},
+		rdbmanager.ACLSourceConfigMap:     &corev1.ConfigMap{},
+		rdbmanager.ACLSourceRDBInstance:   &rdbv1beta1.RDBInstance{},
+		rdbmanager.ACLSourcePublicGateway: &vpcv1alpha1.PublicGateway{},
+		rdbmanager.ACLSourceFlexibleIP:    &ipv1alpha1.FlexibleIP{},
+	}
+	// the sources of the other products are only watched when these products are enabled
+	sourceProducts := map[string]string{
+		rdbmanager.ACLSourcePublicGateway: "vpc",
+		rdbmanager.ACLSourceFlexibleIP:    "ip",
+	}
+	for kind, sourceObj := range sources {
+		if product, ok := sourceProducts[kind]; ok && !r.ScalewayReconciler.ProductEnabled(product) {
+			continue
+		}
+		controllerBuilder = controllerBuilder.Watches(&source.Kind{Type: sourceObj}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: r.aclSourceToRequests(kind),
+		})
+	}
+
+	return controllerBuilder.Complete(r)
+}
+
+// nodeToRequests enqueues the RDBInstances allowing the cluster nodes in their ACLs
+func (r *RDBInstanceReconciler) nodeToRequests(obj handler.MapObject) []reconcile.Request {
+	return r.ScalewayReconciler.RequestsForIndex(&rdbv1beta1.RDBInstanceList{}, controllers.AllowClusterField, controllers.AllowClusterValue)
+}
+
+// aclSourceToRequests enqueues the RDBInstances using the given object of the given kind as an ACL source
+func (r *RDBInstanceReconciler) aclSourceToRequests(kind string) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		return r.ScalewayReconciler.RequestsForIndex(&rdbv1beta1.RDBInstanceList{}, aclSourceField, aclSourceKey(kind, controllers.RefKey(obj.Meta.GetNamespace(), obj.Meta.GetName(), "")))
+	}
 }
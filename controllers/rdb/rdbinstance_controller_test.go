@@ -0,0 +1,244 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+)
+
+const finalizerName = "scaleway.com/finalizer"
+
+func newTestInstance(namespace string, name string) *rdbv1beta1.RDBInstance {
+	return &rdbv1beta1.RDBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: rdbv1beta1.RDBInstanceSpec{
+			Region:   "fr-par",
+			Engine:   "PostgreSQL-12",
+			NodeType: "db-dev-s",
+		},
+	}
+}
+
+func isReconciled(status scalewaymetav1alpha1.Status) bool {
+	for _, condition := range status.Conditions {
+		if condition.Type == scalewaymetav1alpha1.Reconciled {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// waitForInstance waits for the instance to be reconciled and returns it
+func waitForInstance(t *testing.T, key client.ObjectKey) *rdbv1beta1.RDBInstance {
+	t.Helper()
+	instance := &rdbv1beta1.RDBInstance{}
+	eventually(t, func() (bool, error) {
+		if err := k8sClient.Get(context.Background(), key, instance); err != nil {
+			return false, err
+		}
+		return instance.Spec.InstanceID != "" && isReconciled(instance.GetStatus()), nil
+	}, "instance "+key.String()+" reconciled")
+	return instance
+}
+
+// waitForDeletion waits for the object to be removed from the API server
+func waitForDeletion(t *testing.T, key client.ObjectKey, obj runtime.Object) {
+	t.Helper()
+	eventually(t, func() (bool, error) {
+		err := k8sClient.Get(context.Background(), key, obj)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}, key.String()+" deleted")
+}
+
+func Test_RDBInstance_Lifecycle(t *testing.T) {
+	skipWithoutEnv(t)
+	ctx := context.Background()
+
+	createNamespace(t, "instance-lifecycle")
+	instance := newTestInstance("instance-lifecycle", "instance")
+	if err := k8sClient.Create(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	key := client.ObjectKey{Namespace: instance.Namespace, Name: instance.Name}
+
+	instance = waitForInstance(t, key)
+	if !controllerutil.ContainsFinalizer(instance, finalizerName) {
+		t.Errorf("finalizer %s not added", finalizerName)
+	}
+	instanceID := instance.Spec.InstanceID
+	if _, ok := fakeServer.Instance(instanceID); !ok {
+		t.Fatalf("instance %s not created on the API", instanceID)
+	}
+
+	err := updateInstance(ctx, key, func(instance *rdbv1beta1.RDBInstance) {
+		instance.Spec.NodeType = "db-dev-m"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() (bool, error) {
+		rdbInstance, ok := fakeServer.Instance(instanceID)
+		return ok && rdbInstance.NodeType == "db-dev-m" && rdbInstance.Status == rdb.InstanceStatusReady, nil
+	}, "instance upgraded")
+
+	if err := k8sClient.Delete(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	waitForDeletion(t, key, &rdbv1beta1.RDBInstance{})
+	if _, ok := fakeServer.Instance(instanceID); ok {
+		t.Errorf("instance %s not deleted on the API", instanceID)
+	}
+}
+
+func Test_RDBInstance_Ignore(t *testing.T) {
+	skipWithoutEnv(t)
+	ctx := context.Background()
+
+	createNamespace(t, "instance-ignore")
+	instance := newTestInstance("instance-ignore", "instance")
+	instance.Annotations = map[string]string{"scaleway.com/ignore": "true"}
+	instancesBefore := len(fakeServer.InstanceIDs())
+	if err := k8sClient.Create(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	key := client.ObjectKey{Namespace: instance.Namespace, Name: instance.Name}
+
+	eventually(t, func() (bool, error) {
+		return hasEvent(ctx, instance.Namespace, instance.Name, "Ignoring")
+	}, "instance ignored")
+
+	if err := k8sClient.Get(ctx, key, instance); err != nil {
+		t.Fatal(err)
+	}
+	if controllerutil.ContainsFinalizer(instance, finalizerName) {
+		t.Errorf("finalizer added on an ignored instance")
+	}
+	if instance.Spec.InstanceID != "" {
+		t.Errorf("got instance ID %s on an ignored instance", instance.Spec.InstanceID)
+	}
+	if instancesAfter := len(fakeServer.InstanceIDs()); instancesAfter != instancesBefore {
+		t.Errorf("got %d instances instead of %d on the API", instancesAfter, instancesBefore)
+	}
+
+	if err := k8sClient.Delete(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	waitForDeletion(t, key, &rdbv1beta1.RDBInstance{})
+}
+
+func Test_RDBInstance_Webhook(t *testing.T) {
+	skipWithoutEnv(t)
+	ctx := context.Background()
+
+	createNamespace(t, "instance-webhook")
+
+	cases := []struct {
+		mutate func(*rdbv1beta1.RDBInstance)
+		field  string
+	}{
+		{
+			func(instance *rdbv1beta1.RDBInstance) { instance.Spec.Region = "mars-1" },
+			"spec.region",
+		},
+		{
+			func(instance *rdbv1beta1.RDBInstance) { instance.Spec.Engine = "PostgreSQL-1" },
+			"spec.engine",
+		},
+		{
+			func(instance *rdbv1beta1.RDBInstance) { instance.Spec.NodeType = "db-huge" },
+			"spec.nodeType",
+		},
+	}
+
+	for i, c := range cases {
+		instance := newTestInstance("instance-webhook", "instance")
+		c.mutate(instance)
+		err := k8sClient.Create(ctx, instance)
+		if err == nil {
+			t.Errorf("case %d: creation not denied", i)
+			continue
+		}
+		if !strings.Contains(err.Error(), c.field) {
+			t.Errorf("case %d: got %v instead of an error on %s", i, err, c.field)
+		}
+	}
+
+	instance := newTestInstance("instance-webhook", "instance")
+	if err := k8sClient.Create(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	key := client.ObjectKey{Namespace: instance.Namespace, Name: instance.Name}
+	instance = waitForInstance(t, key)
+
+	err := updateInstance(ctx, key, func(instance *rdbv1beta1.RDBInstance) {
+		instance.Spec.Engine = "PostgreSQL-11"
+	})
+	if err == nil || !strings.Contains(err.Error(), "spec.engine") {
+		t.Errorf("got %v instead of an error on spec.engine", err)
+	}
+
+	if err := k8sClient.Get(ctx, key, instance); err != nil {
+		t.Fatal(err)
+	}
+	if err := k8sClient.Delete(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	waitForDeletion(t, key, &rdbv1beta1.RDBInstance{})
+}
+
+// updateInstance applies mutate on the latest version of the instance, retrying on conflicts
+// since the controller updates the instance concurrently
+func updateInstance(ctx context.Context, key client.ObjectKey, mutate func(*rdbv1beta1.RDBInstance)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance := &rdbv1beta1.RDBInstance{}
+		if err := k8sClient.Get(ctx, key, instance); err != nil {
+			return err
+		}
+		mutate(instance)
+		return k8sClient.Update(ctx, instance)
+	})
+}
+
+func hasEvent(ctx context.Context, namespace string, name string, reason string) (bool, error) {
+	events := &corev1.EventList{}
+	if err := k8sClient.List(ctx, events, client.InNamespace(namespace)); err != nil {
+		return false, err
+	}
+	for _, event := range events.Items {
+		if event.InvolvedObject.Name == name && event.Reason == reason {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -17,10 +17,18 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
 	"github.com/scaleway/scaleway-operator/controllers"
+	rdbmanager "github.com/scaleway/scaleway-operator/pkg/manager/rdb"
 )
 
 // RDBUserReconciler reconciles a RDBUser object
@@ -31,15 +39,54 @@ type RDBUserReconciler struct {
 // +kubebuilder:rbac:groups=rdb.scaleway.com,resources=rdbusers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rdb.scaleway.com,resources=rdbusers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=rdb.scaleway.com,resources=rdbinstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 
 // Reconcile reconciles a RDB User
 func (r *RDBUserReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return r.ScalewayReconciler.Reconcile(req, &rdbv1alpha1.RDBUser{})
+	return r.ScalewayReconciler.Reconcile(req, &rdbv1beta1.RDBUser{})
 }
 
 // SetupWithManager registers the RDB User controller
 func (r *RDBUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &rdbv1beta1.RDBUser{}, instanceRefField, func(obj runtime.Object) []string {
+		user := obj.(*rdbv1beta1.RDBUser)
+		return indexInstanceRef(user.Spec.InstanceRef, user.Namespace)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &rdbv1beta1.RDBUser{}, passwordSecretField, func(obj runtime.Object) []string {
+		user := obj.(*rdbv1beta1.RDBUser)
+		if user.Spec.Password.ValueFrom == nil || user.Spec.Password.ValueFrom.SecretKeyRef == nil {
+			return nil
+		}
+		secretRef := user.Spec.Password.ValueFrom.SecretKeyRef
+		return []string{controllers.RefKey(rdbmanager.GetPasswordSecretNamespace(user), secretRef.Name, user.Namespace)}
+	})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&rdbv1alpha1.RDBUser{}).
+		For(&rdbv1beta1.RDBUser{}).
+		Watches(&source.Kind{Type: &rdbv1beta1.RDBInstance{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.instanceToRequests),
+		}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.secretToRequests),
+		}).
+		WithOptions(r.ScalewayReconciler.Options).
 		Complete(r)
 }
+
+// instanceToRequests enqueues the RDBUsers of the given instance
+func (r *RDBUserReconciler) instanceToRequests(obj handler.MapObject) []reconcile.Request {
+	return r.ScalewayReconciler.RequestsForIndex(&rdbv1beta1.RDBUserList{}, instanceRefField, controllers.RefKey(obj.Meta.GetNamespace(), obj.Meta.GetName(), ""))
+}
+
+// secretToRequests enqueues the RDBUsers getting their password from the given secret
+func (r *RDBUserReconciler) secretToRequests(obj handler.MapObject) []reconcile.Request {
+	return r.ScalewayReconciler.RequestsForIndex(&rdbv1beta1.RDBUserList{}, passwordSecretField, controllers.RefKey(obj.Meta.GetNamespace(), obj.Meta.GetName(), ""))
+}
@@ -0,0 +1,242 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/scaleway/scaleway-operator/controllers"
+	// the ip and vpc products are registered for their kinds to be added to the scheme
+	_ "github.com/scaleway/scaleway-operator/controllers/ip"
+	_ "github.com/scaleway/scaleway-operator/controllers/vpc"
+	"github.com/scaleway/scaleway-operator/internal/testhelpers/fakerdb"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+)
+
+const (
+	defaultAssetsPath = "/usr/local/kubebuilder/bin"
+
+	pollInterval = 100 * time.Millisecond
+	pollTimeout  = 30 * time.Second
+)
+
+var (
+	testEnv    *envtest.Environment
+	k8sClient  client.Client
+	fakeServer *fakerdb.Server
+)
+
+// TestMain boots an API server with the CRDs and webhooks from config/ and runs
+// the RDB controllers against a fake RDB API
+// The suite is skipped when the envtest binaries (etcd and kube-apiserver) are not found,
+// see KUBEBUILDER_ASSETS
+func TestMain(m *testing.M) {
+	code, err := runSuite(m)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+func runSuite(m *testing.M) (int, error) {
+	if !assetsAvailable() {
+		fmt.Println("envtest binaries not found, skipping the integration suite")
+		return m.Run(), nil
+	}
+
+	logf.SetLogger(zap.New(zap.UseDevMode(true), zap.WriteTo(os.Stderr)))
+
+	controllers.RequeueDuration = pollInterval
+
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			DirectoryPaths: []string{filepath.Join("..", "..", "config", "webhook")},
+		},
+	}
+
+	cfg, err := testEnv.Start()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := testEnv.Stop(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
+
+	fakeServer = fakerdb.NewServer()
+	fakeServer.TransitionSteps = 1
+	defer fakeServer.Close()
+
+	scwClient, err := fakeServer.Client()
+	if err != nil {
+		return 0, err
+	}
+
+	// all the registered products are added to the scheme, as in the operator, since the
+	// RDB controllers watch the PublicGateway and FlexibleIP sources of the vpc and ip products
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return 0, err
+	}
+	for _, product := range scaleway.GetProducts() {
+		if err := product.AddToScheme(scheme); err != nil {
+			return 0, err
+		}
+	}
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             scheme,
+		MetricsBindAddress: "0",
+		Host:               testEnv.WebhookInstallOptions.LocalServingHost,
+		Port:               testEnv.WebhookInstallOptions.LocalServingPort,
+		CertDir:            testEnv.WebhookInstallOptions.LocalServingCertDir,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	products, err := scaleway.SelectProducts([]string{"rdb"}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, product := range products {
+		for _, kind := range product.Kinds {
+			manager := kind.NewManager(mgr.GetClient(), scwClient, ctrl.Log.WithName("manager").WithName(kind.Name))
+			// the CRDs of all the products are installed, so the sources of the other products
+			// are watched even though only the RDB controllers run
+			config := scaleway.ControllerConfig{
+				Options:         kind.ControllerOptions,
+				EnabledProducts: scaleway.ProductNames(scaleway.GetProducts()),
+			}
+			if err := kind.SetupController(mgr, manager, config); err != nil {
+				return 0, err
+			}
+			if kind.SetupWebhook != nil {
+				if err := kind.SetupWebhook(mgr, manager, ctrl.Log.WithName("webhooks").WithName(kind.Name)); err != nil {
+					return 0, err
+				}
+			}
+		}
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		if err := mgr.Start(stop); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
+
+	// the tests only start once the watches and the webhook server are ready,
+	// as the API server rejects the objects while the webhooks can't be called
+	if !mgr.GetCache().WaitForCacheSync(stop) {
+		return 0, fmt.Errorf("failed to sync the cache")
+	}
+	if err := waitForWebhookServer(testEnv.WebhookInstallOptions); err != nil {
+		return 0, err
+	}
+
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return 0, err
+	}
+
+	return m.Run(), nil
+}
+
+// waitForWebhookServer waits until the webhook server accepts TLS connections
+func waitForWebhookServer(options envtest.WebhookInstallOptions) error {
+	address := net.JoinHostPort(options.LocalServingHost, strconv.Itoa(options.LocalServingPort))
+	deadline := time.Now().Add(pollTimeout)
+	for {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: pollInterval}, "tcp", address, &tls.Config{InsecureSkipVerify: true})
+		if err == nil {
+			return conn.Close()
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("webhook server not ready: %w", err)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func assetsAvailable() bool {
+	path := os.Getenv("KUBEBUILDER_ASSETS")
+	if path == "" {
+		path = defaultAssetsPath
+	}
+	for _, binary := range []string{"etcd", "kube-apiserver"} {
+		if _, err := os.Stat(filepath.Join(path, binary)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func skipWithoutEnv(t *testing.T) {
+	if testEnv == nil {
+		t.Skip("envtest binaries not found")
+	}
+}
+
+// eventually calls condition until it returns true, failing the test after pollTimeout
+func eventually(t *testing.T, condition func() (bool, error), msg string) {
+	t.Helper()
+	deadline := time.Now().Add(pollTimeout)
+	var lastErr error
+	for time.Now().Before(deadline) {
+		ok, err := condition()
+		if err == nil && ok {
+			return
+		}
+		lastErr = err
+		time.Sleep(pollInterval)
+	}
+	t.Fatalf("timed out waiting: %s (last error: %v)", msg, lastErr)
+}
+
+func createNamespace(t *testing.T, name string) {
+	t.Helper()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := k8sClient.Create(context.Background(), ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		t.Fatal(err)
+	}
+}
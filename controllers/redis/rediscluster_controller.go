@@ -0,0 +1,76 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	redisv1alpha1 "github.com/scaleway/scaleway-operator/apis/redis/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// RedisClusterReconciler reconciles a RedisCluster object
+type RedisClusterReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=redis.scaleway.com,resources=redisclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=redis.scaleway.com,resources=redisclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+
+// Reconcile reconciles the Redis Cluster
+func (r *RedisClusterReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &redisv1alpha1.RedisCluster{})
+}
+
+// SetupWithManager registers the Redis Cluster Controller
+func (r *RedisClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &redisv1alpha1.RedisCluster{}, controllers.AllowClusterField, func(obj runtime.Object) []string {
+		cluster := obj.(*redisv1alpha1.RedisCluster)
+		if cluster.Spec.ACL == nil || !cluster.Spec.ACL.AllowCluster {
+			return nil
+		}
+		return []string{controllers.AllowClusterValue}
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&redisv1alpha1.RedisCluster{}).
+		Owns(&corev1.Secret{}).
+		Watches(&source.Kind{Type: &corev1.Node{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.nodeToRequests),
+		}, builder.WithPredicates(controllers.NodeAddressesChanged)).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
+
+// nodeToRequests enqueues the RedisClusters allowing the cluster nodes in their ACLs
+func (r *RedisClusterReconciler) nodeToRequests(obj handler.MapObject) []reconcile.Request {
+	return r.ScalewayReconciler.RequestsForIndex(&redisv1alpha1.RedisClusterList{}, controllers.AllowClusterField, controllers.AllowClusterValue)
+}
@@ -0,0 +1,64 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	registryv1alpha1 "github.com/scaleway/scaleway-operator/apis/registry/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	registrymanager "github.com/scaleway/scaleway-operator/pkg/manager/registry"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/webhooks"
+	registrywebhook "github.com/scaleway/scaleway-operator/webhooks/registry"
+)
+
+func init() {
+	scaleway.RegisterProduct(scaleway.Product{
+		Name:        "registry",
+		AddToScheme: registryv1alpha1.AddToScheme,
+		Kinds: []scaleway.Kind{
+			{
+				Name: "RegistryNamespace",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &registrymanager.NamespaceManager{
+						Client: c,
+						API:    registry.NewAPI(scwClient),
+						IAMAPI: iam.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&RegistryNamespaceReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "RegistryNamespace", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&registrywebhook.RegistryNamespaceValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,92 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	registryv1alpha1 "github.com/scaleway/scaleway-operator/apis/registry/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	registrymanager "github.com/scaleway/scaleway-operator/pkg/manager/registry"
+)
+
+// secretKeyRefField is the field index of the secret holding the API key of the pull secrets
+const secretKeyRefField = "spec.pullSecret.secretKeyRef"
+
+// RegistryNamespaceReconciler reconciles a RegistryNamespace object
+type RegistryNamespaceReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=registry.scaleway.com,resources=registrynamespaces,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=registry.scaleway.com,resources=registrynamespaces/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Reconcile reconciles the Registry Namespace
+func (r *RegistryNamespaceReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &registryv1alpha1.RegistryNamespace{})
+}
+
+// SetupWithManager registers the Registry Namespace controller
+func (r *RegistryNamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &registryv1alpha1.RegistryNamespace{}, secretKeyRefField, func(obj runtime.Object) []string {
+		namespace := obj.(*registryv1alpha1.RegistryNamespace)
+		if namespace.Spec.PullSecret == nil || namespace.Spec.PullSecret.SecretKeyRef == nil {
+			return nil
+		}
+		return []string{controllers.RefKey("", namespace.Spec.PullSecret.SecretKeyRef.Name, namespace.Namespace)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&registryv1alpha1.RegistryNamespace{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.secretToRequests),
+		}).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
+
+// secretToRequests enqueues the RegistryNamespaces owning a generated secret
+// or using the given secret as API key, so pull secrets are kept refreshed
+func (r *RegistryNamespaceReconciler) secretToRequests(obj handler.MapObject) []reconcile.Request {
+	labels := obj.Meta.GetLabels()
+	if name, ok := labels[registrymanager.PullSecretOwnerNameLabel]; ok {
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      name,
+					Namespace: labels[registrymanager.PullSecretOwnerNamespaceLabel],
+				},
+			},
+		}
+	}
+
+	return r.ScalewayReconciler.RequestsForIndex(&registryv1alpha1.RegistryNamespaceList{}, secretKeyRefField, controllers.RefKey(obj.Meta.GetNamespace(), obj.Meta.GetName(), ""))
+}
@@ -2,37 +2,50 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
 	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/metrics"
+	"github.com/scaleway/scaleway-operator/pkg/tracing"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
 	finalizerName    = "scaleway.com/finalizer"
 	ignoreAnnotation = "scaleway.com/ignore"
+	pausedAnnotation = "scaleway.com/paused"
+	dryRunAnnotation = "scaleway.com/dry-run"
+
+	driftPolicyAnnotation = "scaleway.com/drift-policy"
+	driftPolicyReportOnly = "ReportOnly"
 
 	messageStillReconciling = "Still reconciling"
+	messagePaused           = "Reconciliation paused based on annotation"
+	messageResumed          = "Reconciliation resumed"
 
 	reasonReconciling       = "Reconciling"
-	reasonTransientState    = "TransientState"
-	reasonResourceNotFound  = "ResourceNotFound"
-	reasonPermissionsDenied = "PermissionsDenied"
-	reasonOutOfStock        = "OutOfStock"
-	reasonQuotasExceeded    = "QuotasExceeded"
-	reasonResourceLocked    = "ResourceLocked"
-	reasonInvalidArguments  = "InvalidArguments"
+	reasonDriftDetected     = "DriftDetected"
+	reasonDriftCorrected    = "DriftCorrected"
+	reasonPaused            = "Paused"
+	reasonResumed           = "Resumed"
+	reasonPlannedAction     = "PlannedAction"
+	reasonDryRunUnsupported = "DryRunUnsupported"
 )
 
 var (
@@ -44,21 +57,68 @@ var (
 // ScalewayReconciler is the base reconciler for Scaleway products
 type ScalewayReconciler struct {
 	client.Client
+	// Kind is the kind of the reconciled resources
+	Kind            string
 	ScalewayManager scaleway.Manager
 	Recorder        record.EventRecorder
 	Log             logr.Logger
 	Scheme          *runtime.Scheme
+	Options         controller.Options
+	// ResyncPeriod is the duration after which reconciled resources are reconciled again
+	ResyncPeriod time.Duration
+	// DryRun only plans the actions needed to reconcile the resources, without executing them
+	DryRun bool
+	// EnabledProducts is the set of the names of the enabled products
+	EnabledProducts map[string]bool
+	// Resources exports the state of the conditions of the reconciled resources
+	Resources *metrics.ResourceTracker
+}
+
+// NewScalewayReconciler returns a base reconciler for the given kind
+func NewScalewayReconciler(mgr ctrl.Manager, kind string, manager scaleway.Manager, config scaleway.ControllerConfig) *ScalewayReconciler {
+	return &ScalewayReconciler{
+		Client:          mgr.GetClient(),
+		Kind:            kind,
+		ScalewayManager: manager,
+		Recorder:        mgr.GetEventRecorderFor(kind),
+		Log:             ctrl.Log.WithName("controllers").WithName(kind),
+		Scheme:          mgr.GetScheme(),
+		Options:         config.Options,
+		ResyncPeriod:    config.ResyncPeriod,
+		DryRun:          config.DryRun,
+		EnabledProducts: config.EnabledProducts,
+		Resources:       metrics.NewResourceTracker(kind),
+	}
+}
+
+// ProductEnabled returns whether the given product is enabled, so that its kinds can be watched
+func (r *ScalewayReconciler) ProductEnabled(name string) bool {
+	return r.EnabledProducts[name]
 }
 
 // Reconcile is the global reconcile loop
 func (r *ScalewayReconciler) Reconcile(req ctrl.Request, obj runtime.Object) (ctrl.Result, error) {
-	ctx := context.Background()
+	ctx, span := tracing.StartSpan(context.Background(), "Reconcile "+r.Kind,
+		attribute.String("k8s.kind", r.Kind),
+		attribute.String("k8s.namespace", req.Namespace),
+		attribute.String("k8s.name", req.Name),
+	)
+	res, err := r.reconcile(ctx, req, obj)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (r *ScalewayReconciler) reconcile(ctx context.Context, req ctrl.Request, obj runtime.Object) (ctrl.Result, error) {
 	log := r.Log.WithValues("object", req.String())
 
 	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
 		log.Error(err, "could not find object")
+		if apierrors.IsNotFound(err) && r.Resources != nil {
+			r.Resources.Delete(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	defer r.trackConditions(req.NamespacedName, obj)
 
 	objMeta, err := meta.Accessor(obj)
 	if err != nil {
@@ -76,6 +136,44 @@ func (r *ScalewayReconciler) Reconcile(req ctrl.Request, obj runtime.Object) (ct
 		}
 	}
 
+	// a paused object keeps its finalizer, so it can't be deleted
+	// without its Scaleway resource once the reconciliation is resumed
+	if paused, ok := objMeta.GetAnnotations()[pausedAnnotation]; ok && strings.ToLower(paused) == "true" {
+		scalewayStatus := obj.(scalewaymetav1alpha1.TypeMeta).GetStatus()
+		if isConditionTrue(&scalewayStatus, scalewaymetav1alpha1.Paused) {
+			log.Info("reconciliation paused")
+			return ctrl.Result{}, nil
+		}
+		r.Recorder.Event(obj, corev1.EventTypeNormal, reasonPaused, messagePaused)
+		updateCondition(&scalewayStatus, scalewaymetav1alpha1.Condition{
+			Message: messagePaused,
+			Reason:  reasonPaused,
+			Status:  corev1.ConditionTrue,
+			Type:    scalewaymetav1alpha1.Paused,
+		}, metav1.NewTime(time.Now()))
+		obj.(scalewaymetav1alpha1.TypeMeta).SetStatus(scalewayStatus)
+		return ctrl.Result{}, r.Status().Update(ctx, obj)
+	}
+
+	if scalewayStatus := obj.(scalewaymetav1alpha1.TypeMeta).GetStatus(); isConditionTrue(&scalewayStatus, scalewaymetav1alpha1.Paused) {
+		r.Recorder.Event(obj, corev1.EventTypeNormal, reasonResumed, messageResumed)
+		updateCondition(&scalewayStatus, scalewaymetav1alpha1.Condition{
+			Message: messageResumed,
+			Reason:  reasonResumed,
+			Status:  corev1.ConditionFalse,
+			Type:    scalewaymetav1alpha1.Paused,
+		}, metav1.NewTime(time.Now()))
+		obj.(scalewaymetav1alpha1.TypeMeta).SetStatus(scalewayStatus)
+		if err := r.Status().Update(ctx, obj); err != nil {
+			log.Error(err, "failed to update status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if dryRun, ok := objMeta.GetAnnotations()[dryRunAnnotation]; r.DryRun || (ok && strings.ToLower(dryRun) == "true") {
+		return r.plan(ctx, log, obj, objMeta)
+	}
+
 	if objMeta.GetDeletionTimestamp().IsZero() {
 		if !controllerutil.ContainsFinalizer(obj.(controllerutil.Object), finalizerName) {
 			controllerutil.AddFinalizer(obj.(controllerutil.Object), finalizerName)
@@ -87,9 +185,14 @@ func (r *ScalewayReconciler) Reconcile(req ctrl.Request, obj runtime.Object) (ct
 	} else {
 		// deletion
 		if controllerutil.ContainsFinalizer(obj.(controllerutil.Object), finalizerName) {
-			deleted, err := r.ScalewayManager.Delete(ctx, obj)
+			deleteCtx, span := tracing.StartSpan(ctx, "Delete")
+			deleted, err := r.ScalewayManager.Delete(scaleway.WithEventRecorder(deleteCtx, r.Recorder), obj)
+			tracing.EndSpan(span, err)
 			if err != nil {
 				log.Error(err, "failed to delete")
+				if reason, ok := scaleway.GetErrorReason(err); ok {
+					r.Recorder.Event(obj, corev1.EventTypeWarning, reason, err.Error())
+				}
 				return ctrl.Result{}, err
 			}
 			if deleted {
@@ -108,15 +211,48 @@ func (r *ScalewayReconciler) Reconcile(req ctrl.Request, obj runtime.Object) (ct
 		return ctrl.Result{}, err
 	}
 
+	// the remote state fetched to detect the drift is reused by Ensure
+	ctx = scaleway.WithReconcileCache(ctx)
+	drift := r.detectDrift(ctx, log, obj, objMeta)
+	if len(drift) > 0 && strings.ToLower(objMeta.GetAnnotations()[driftPolicyAnnotation]) == strings.ToLower(driftPolicyReportOnly) {
+		log.Info("drift detected, not correcting it based on annotation", "fields", drift)
+		scalewayStatus := obj.(scalewaymetav1alpha1.TypeMeta).GetStatus()
+		updateSyncedCondition(&scalewayStatus, metav1.NewTime(time.Now()), drift, false)
+		obj.(scalewaymetav1alpha1.TypeMeta).SetStatus(scalewayStatus)
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, r.Status().Update(ctx, obj)
+	}
+
 	log.Info("reconciling object")
 
-	ensured, err := r.ScalewayManager.Ensure(ctx, obj)
+	ensureCtx, span := tracing.StartSpan(ctx, "Ensure")
+	ensured, err := r.ScalewayManager.Ensure(scaleway.WithEventRecorder(ensureCtx, r.Recorder), obj)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		log.Error(err, "error ensuring object")
+		if reason, ok := scaleway.GetErrorReason(err); ok {
+			r.Recorder.Event(obj, corev1.EventTypeWarning, reason, err.Error())
+		}
 	}
 
+	now := metav1.NewTime(time.Now())
 	scalewayStatus := obj.(scalewaymetav1alpha1.TypeMeta).GetStatus()
-	requeueAfter, updateErr := updateStatus(&scalewayStatus, metav1.NewTime(time.Now()), err, ensured)
+	requeueAfter, updateErr := updateStatus(&scalewayStatus, now, err, ensured)
+	if ensured && err == nil {
+		if refresher, ok := r.ScalewayManager.(scaleway.Refresher); ok {
+			requeueAfter = refresher.GetRefreshInterval(obj)
+		}
+		if r.ResyncPeriod > 0 && (requeueAfter == 0 || requeueAfter > r.ResyncPeriod) {
+			requeueAfter = r.ResyncPeriod
+		}
+		if len(drift) > 0 {
+			r.Recorder.Event(obj, corev1.EventTypeNormal, reasonDriftCorrected, "Successfully corrected drift")
+		}
+		updateSyncedCondition(&scalewayStatus, now, nil, true)
+		scalewayStatus.ObservedGeneration = objMeta.GetGeneration()
+		scalewayStatus.PlannedActions = nil
+	} else if len(drift) > 0 {
+		updateSyncedCondition(&scalewayStatus, now, drift, false)
+	}
 	obj.(scalewaymetav1alpha1.TypeMeta).SetStatus(scalewayStatus)
 	err = r.Status().Update(ctx, obj)
 	if err != nil {
@@ -127,8 +263,72 @@ func (r *ScalewayReconciler) Reconcile(req ctrl.Request, obj runtime.Object) (ct
 	return ctrl.Result{RequeueAfter: requeueAfter}, updateErr
 }
 
+// trackConditions exports the state of the conditions of the object
+func (r *ScalewayReconciler) trackConditions(key types.NamespacedName, obj runtime.Object) {
+	if r.Resources == nil {
+		return
+	}
+	typeMeta, ok := obj.(scalewaymetav1alpha1.TypeMeta)
+	if !ok {
+		return
+	}
+	states := []metrics.ConditionState{}
+	for _, condition := range typeMeta.GetStatus().Conditions {
+		states = append(states, metrics.ConditionState{
+			Condition: string(condition.Type),
+			Status:    string(condition.Status),
+			Reason:    condition.Reason,
+		})
+	}
+	r.Resources.Set(key, states)
+}
+
+// plan records the actions needed to reconcile the object, without executing them
+// Finalizers are neither added nor removed in dry-run mode
+func (r *ScalewayReconciler) plan(ctx context.Context, log logr.Logger, obj runtime.Object, objMeta metav1.Object) (ctrl.Result, error) {
+	if !scaleway.SupportsDryRun(r.ScalewayManager) {
+		r.Recorder.Event(obj, corev1.EventTypeWarning, reasonDryRunUnsupported, "Dry-run is not supported for this kind, skipping reconciliation")
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("planning object")
+
+	planner := &scaleway.Planner{}
+	planCtx := scaleway.WithPlanner(ctx, planner)
+
+	var err error
+	if objMeta.GetDeletionTimestamp().IsZero() {
+		ensureCtx, span := tracing.StartSpan(planCtx, "Ensure", attribute.Bool("scaleway.dry_run", true))
+		_, err = r.ScalewayManager.Ensure(ensureCtx, obj)
+		tracing.EndSpan(span, err)
+	} else if controllerutil.ContainsFinalizer(obj.(controllerutil.Object), finalizerName) {
+		deleteCtx, span := tracing.StartSpan(planCtx, "Delete", attribute.Bool("scaleway.dry_run", true))
+		_, err = r.ScalewayManager.Delete(deleteCtx, obj)
+		tracing.EndSpan(span, err)
+	}
+	if err != nil {
+		log.Error(err, "error planning object")
+	}
+
+	for _, action := range planner.Actions() {
+		r.Recorder.Event(obj, corev1.EventTypeNormal, reasonPlannedAction, action)
+	}
+
+	scalewayStatus := obj.(scalewaymetav1alpha1.TypeMeta).GetStatus()
+	scalewayStatus.PlannedActions = planner.Actions()
+	obj.(scalewaymetav1alpha1.TypeMeta).SetStatus(scalewayStatus)
+	if updateErr := r.Status().Update(ctx, obj); updateErr != nil {
+		log.Error(updateErr, "failed to update status")
+		return ctrl.Result{}, updateErr
+	}
+
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, err
+}
+
 func (r *ScalewayReconciler) setOwners(ctx context.Context, log logr.Logger, obj runtime.Object, objMeta metav1.Object) error {
-	owners, err := r.ScalewayManager.GetOwners(ctx, obj)
+	ownersCtx, span := tracing.StartSpan(ctx, "GetOwners")
+	owners, err := r.ScalewayManager.GetOwners(ownersCtx, obj)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		log.Error(err, "failed to get owners")
 		return err
@@ -156,6 +356,54 @@ func (r *ScalewayReconciler) setOwners(ctx context.Context, log logr.Logger, obj
 	return nil
 }
 
+// detectDrift returns the fields of the resource whose remote state differs from the spec
+// The drift is only detected on resources whose current spec was successfully reconciled
+func (r *ScalewayReconciler) detectDrift(ctx context.Context, log logr.Logger, obj runtime.Object, objMeta metav1.Object) []string {
+	detector, ok := r.ScalewayManager.(scaleway.DriftDetector)
+	if !ok {
+		return nil
+	}
+
+	status := obj.(scalewaymetav1alpha1.TypeMeta).GetStatus()
+	if status.ObservedGeneration == 0 || status.ObservedGeneration != objMeta.GetGeneration() {
+		return nil
+	}
+	if !isConditionTrue(&status, scalewaymetav1alpha1.Reconciled) {
+		return nil
+	}
+
+	driftCtx, span := tracing.StartSpan(ctx, "GetDrift")
+	drift, err := detector.GetDrift(driftCtx, obj)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		log.Error(err, "failed to detect drift")
+		return nil
+	}
+
+	if len(drift) > 0 {
+		r.Recorder.Event(obj, corev1.EventTypeWarning, reasonDriftDetected, getDriftMessage(drift))
+	}
+
+	return drift
+}
+
+func getDriftMessage(drift []string) string {
+	return fmt.Sprintf("Remote state differs from spec on fields: %s", strings.Join(drift, ", "))
+}
+
+func updateSyncedCondition(status *scalewaymetav1alpha1.Status, now metav1.Time, drift []string, synced bool) {
+	condition := scalewaymetav1alpha1.Condition{
+		Status: corev1.ConditionTrue,
+		Type:   scalewaymetav1alpha1.Synced,
+	}
+	if !synced {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = reasonDriftDetected
+		condition.Message = getDriftMessage(drift)
+	}
+	updateCondition(status, condition, now)
+}
+
 func updateStatus(status *scalewaymetav1alpha1.Status, now metav1.Time, ensureErr error, reconciled bool) (time.Duration, error) {
 	reconcileStatus := corev1.ConditionTrue
 	var message string
@@ -169,29 +417,29 @@ func updateStatus(status *scalewaymetav1alpha1.Status, now metav1.Time, ensureEr
 
 		switch ensureErr.(type) {
 		case *scw.ResourceNotFoundError:
-			reason = reasonResourceNotFound
+			reason = scaleway.ReasonResourceNotFound
 			ensureErr = nil
 		case *scw.InvalidArgumentsError:
-			reason = reasonInvalidArguments
+			reason = scaleway.ReasonInvalidArguments
 			ensureErr = nil
 		case *scw.PermissionsDeniedError:
-			reason = reasonPermissionsDenied
+			reason = scaleway.ReasonPermissionsDenied
 			requeueAfter = RequeueDuration * 10
 			ensureErr = nil
 		case *scw.OutOfStockError:
-			reason = reasonOutOfStock
+			reason = scaleway.ReasonOutOfStock
 			requeueAfter = RequeueDuration * 4
 			ensureErr = nil
 		case *scw.QuotasExceededError:
-			reason = reasonQuotasExceeded
+			reason = scaleway.ReasonQuotasExceeded
 			requeueAfter = RequeueDuration * 2
 			ensureErr = nil
 		case *scw.ResourceLockedError:
-			reason = reasonResourceLocked
+			reason = scaleway.ReasonResourceLocked
 			requeueAfter = RequeueDuration * 10
 			ensureErr = nil
 		case *scw.TransientStateError:
-			reason = reasonTransientState
+			reason = scaleway.ReasonTransientState
 			requeueAfter = RequeueDuration
 			ensureErr = nil
 		}
@@ -238,3 +486,12 @@ func updateCondition(status *scalewaymetav1alpha1.Status, condition scalewaymeta
 		Status:             condition.Status,
 	})
 }
+
+func isConditionTrue(status *scalewaymetav1alpha1.Status, conditionType scalewaymetav1alpha1.ConditionType) bool {
+	for _, c := range status.Conditions {
+		if c.Type == conditionType {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
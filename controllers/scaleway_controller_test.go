@@ -5,6 +5,7 @@ import (
 	"time"
 
 	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -199,7 +200,7 @@ func Test_updateStatus(t *testing.T) {
 						LastProbeTime:      now,
 						LastTransitionTime: before,
 						Message:            notFoundErr.Error(),
-						Reason:             reasonResourceNotFound,
+						Reason:             scaleway.ReasonResourceNotFound,
 						Status:             corev1.ConditionFalse,
 					},
 				},
@@ -228,7 +229,7 @@ func Test_updateStatus(t *testing.T) {
 						LastProbeTime:      now,
 						LastTransitionTime: before,
 						Message:            tStateErr.Error(),
-						Reason:             reasonTransientState,
+						Reason:             scaleway.ReasonTransientState,
 						Status:             corev1.ConditionFalse,
 					},
 				},
@@ -257,7 +258,7 @@ func Test_updateStatus(t *testing.T) {
 						LastProbeTime:      now,
 						LastTransitionTime: before,
 						Message:            permDeniedErr.Error(),
-						Reason:             reasonPermissionsDenied,
+						Reason:             scaleway.ReasonPermissionsDenied,
 						Status:             corev1.ConditionFalse,
 					},
 				},
@@ -286,7 +287,7 @@ func Test_updateStatus(t *testing.T) {
 						LastProbeTime:      now,
 						LastTransitionTime: before,
 						Message:            oosErr.Error(),
-						Reason:             reasonOutOfStock,
+						Reason:             scaleway.ReasonOutOfStock,
 						Status:             corev1.ConditionFalse,
 					},
 				},
@@ -315,7 +316,7 @@ func Test_updateStatus(t *testing.T) {
 						LastProbeTime:      now,
 						LastTransitionTime: before,
 						Message:            quotasExceededError.Error(),
-						Reason:             reasonQuotasExceeded,
+						Reason:             scaleway.ReasonQuotasExceeded,
 						Status:             corev1.ConditionFalse,
 					},
 				},
@@ -344,7 +345,7 @@ func Test_updateStatus(t *testing.T) {
 						LastProbeTime:      now,
 						LastTransitionTime: before,
 						Message:            resLockedErr.Error(),
-						Reason:             reasonResourceLocked,
+						Reason:             scaleway.ReasonResourceLocked,
 						Status:             corev1.ConditionFalse,
 					},
 				},
@@ -373,7 +374,7 @@ func Test_updateStatus(t *testing.T) {
 						LastProbeTime:      now,
 						LastTransitionTime: before,
 						Message:            invalidArgErr.Error(),
-						Reason:             reasonInvalidArguments,
+						Reason:             scaleway.ReasonInvalidArguments,
 						Status:             corev1.ConditionFalse,
 					},
 				},
@@ -496,3 +497,64 @@ func Test_updateCondition(t *testing.T) {
 		}
 	}
 }
+
+func Test_updateSyncedCondition(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	before := metav1.NewTime(time.Now().Add(-5 * time.Second))
+
+	cases := []struct {
+		status         scalewaymetav1alpha1.Status
+		drift          []string
+		synced         bool
+		expectedStatus scalewaymetav1alpha1.Status
+	}{
+		{
+			status: scalewaymetav1alpha1.Status{},
+			drift:  nil,
+			synced: true,
+			expectedStatus: scalewaymetav1alpha1.Status{
+				Conditions: []scalewaymetav1alpha1.Condition{
+					{
+						Type:               scalewaymetav1alpha1.Synced,
+						Status:             corev1.ConditionTrue,
+						LastProbeTime:      now,
+						LastTransitionTime: now,
+					},
+				},
+			},
+		},
+		{
+			status: scalewaymetav1alpha1.Status{
+				Conditions: []scalewaymetav1alpha1.Condition{
+					{
+						Type:               scalewaymetav1alpha1.Synced,
+						Status:             corev1.ConditionTrue,
+						LastProbeTime:      before,
+						LastTransitionTime: before,
+					},
+				},
+			},
+			drift:  []string{"spec.nodeType", "metadata.labels"},
+			synced: false,
+			expectedStatus: scalewaymetav1alpha1.Status{
+				Conditions: []scalewaymetav1alpha1.Condition{
+					{
+						Type:               scalewaymetav1alpha1.Synced,
+						Status:             corev1.ConditionFalse,
+						LastProbeTime:      now,
+						LastTransitionTime: now,
+						Reason:             reasonDriftDetected,
+						Message:            "Remote state differs from spec on fields: spec.nodeType, metadata.labels",
+					},
+				},
+			},
+		},
+	}
+
+	for i, c := range cases {
+		updateSyncedCondition(&c.status, now, c.drift, c.synced)
+		if !compareStatus(&c.status, &c.expectedStatus) {
+			t.Errorf("case %d: got %v instead of %v", i, c.status, c.expectedStatus)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/api/secret/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretv1alpha1 "github.com/scaleway/scaleway-operator/apis/secret/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	secretmanager "github.com/scaleway/scaleway-operator/pkg/manager/secret"
+	"github.com/scaleway/scaleway-operator/webhooks"
+	secretwebhook "github.com/scaleway/scaleway-operator/webhooks/secret"
+)
+
+func init() {
+	scaleway.RegisterProduct(scaleway.Product{
+		Name:        "secret",
+		AddToScheme: secretv1alpha1.AddToScheme,
+		Kinds: []scaleway.Kind{
+			{
+				Name: "ScalewaySecret",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &secretmanager.SecretManager{
+						Client: c,
+						API:    secret.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&ScalewaySecretReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "ScalewaySecret", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&secretwebhook.ScalewaySecretValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,83 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	secretv1alpha1 "github.com/scaleway/scaleway-operator/apis/secret/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// ScalewaySecretReconciler reconciles a ScalewaySecret object
+type ScalewaySecretReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=secret.scaleway.com,resources=scalewaysecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secret.scaleway.com,resources=scalewaysecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+
+// Reconcile reconciles the Scaleway Secret
+func (r *ScalewaySecretReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &secretv1alpha1.ScalewaySecret{})
+}
+
+// SetupWithManager registers the Scaleway Secret controller
+func (r *ScalewaySecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&secretv1alpha1.ScalewaySecret{}).
+		Owns(&corev1.Secret{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.secretToRequests),
+		}).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
+
+// secretToRequests enqueues the ScalewaySecrets pushing the given secret
+func (r *ScalewaySecretReconciler) secretToRequests(obj handler.MapObject) []reconcile.Request {
+	scwSecrets := secretv1alpha1.ScalewaySecretList{}
+	err := r.ScalewayReconciler.List(context.Background(), &scwSecrets, client.InNamespace(obj.Meta.GetNamespace()))
+	if err != nil {
+		r.ScalewayReconciler.Log.Error(err, "failed to list scaleway secrets")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, scwSecret := range scwSecrets.Items {
+		if scwSecret.Spec.Source != nil && scwSecret.Spec.Source.Name == obj.Meta.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      scwSecret.Name,
+					Namespace: scwSecret.Namespace,
+				},
+			})
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,106 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/api/container/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	serverlessv1alpha1 "github.com/scaleway/scaleway-operator/apis/serverless/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	serverlessmanager "github.com/scaleway/scaleway-operator/pkg/manager/serverless"
+	"github.com/scaleway/scaleway-operator/webhooks"
+	serverlesswebhook "github.com/scaleway/scaleway-operator/webhooks/serverless"
+)
+
+func init() {
+	scaleway.RegisterProduct(scaleway.Product{
+		Name:        "serverless",
+		AddToScheme: serverlessv1alpha1.AddToScheme,
+		Kinds: []scaleway.Kind{
+			{
+				Name: "ServerlessNamespace",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &serverlessmanager.NamespaceManager{
+						Client:       c,
+						ContainerAPI: container.NewAPI(scwClient),
+						FunctionAPI:  function.NewAPI(scwClient),
+						Log:          log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&ServerlessNamespaceReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "ServerlessNamespace", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&serverlesswebhook.ServerlessNamespaceValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "ServerlessContainer",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &serverlessmanager.ContainerManager{
+						Client: c,
+						API:    container.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&ServerlessContainerReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "ServerlessContainer", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&serverlesswebhook.ServerlessContainerValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "ServerlessFunction",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &serverlessmanager.FunctionManager{
+						Client: c,
+						API:    function.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&ServerlessFunctionReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "ServerlessFunction", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&serverlesswebhook.ServerlessFunctionValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,100 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	serverlessv1alpha1 "github.com/scaleway/scaleway-operator/apis/serverless/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// ServerlessFunctionReconciler reconciles a ServerlessFunction object
+type ServerlessFunctionReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=serverless.scaleway.com,resources=serverlessfunctions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=serverless.scaleway.com,resources=serverlessfunctions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serverless.scaleway.com,resources=serverlessnamespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+
+// Reconcile reconciles the Serverless Function
+func (r *ServerlessFunctionReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &serverlessv1alpha1.ServerlessFunction{})
+}
+
+// SetupWithManager registers the Serverless Function controller
+func (r *ServerlessFunctionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&serverlessv1alpha1.ServerlessFunction{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.secretToRequests),
+		}).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.configMapToRequests),
+		}).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
+
+// secretToRequests enqueues the ServerlessFunctions using the given secret
+func (r *ServerlessFunctionReconciler) secretToRequests(obj handler.MapObject) []reconcile.Request {
+	return r.functionsToRequests(obj, func(serverlessFunction serverlessv1alpha1.ServerlessFunction) bool {
+		return usesSecret(serverlessFunction.Spec.SecretEnvironmentVariables, obj.Meta.GetName())
+	})
+}
+
+// configMapToRequests enqueues the ServerlessFunctions getting their code from the given config map
+func (r *ServerlessFunctionReconciler) configMapToRequests(obj handler.MapObject) []reconcile.Request {
+	return r.functionsToRequests(obj, func(serverlessFunction serverlessv1alpha1.ServerlessFunction) bool {
+		return serverlessFunction.Spec.Code != nil && serverlessFunction.Spec.Code.ConfigMapKeyRef.Name == obj.Meta.GetName()
+	})
+}
+
+func (r *ServerlessFunctionReconciler) functionsToRequests(obj handler.MapObject, matches func(serverlessv1alpha1.ServerlessFunction) bool) []reconcile.Request {
+	serverlessFunctions := serverlessv1alpha1.ServerlessFunctionList{}
+	err := r.ScalewayReconciler.List(context.Background(), &serverlessFunctions, client.InNamespace(obj.Meta.GetNamespace()))
+	if err != nil {
+		r.ScalewayReconciler.Log.Error(err, "failed to list serverless functions")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, serverlessFunction := range serverlessFunctions.Items {
+		if matches(serverlessFunction) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      serverlessFunction.Name,
+					Namespace: serverlessFunction.Namespace,
+				},
+			})
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,91 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	serverlessv1alpha1 "github.com/scaleway/scaleway-operator/apis/serverless/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// ServerlessNamespaceReconciler reconciles a ServerlessNamespace object
+type ServerlessNamespaceReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=serverless.scaleway.com,resources=serverlessnamespaces,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=serverless.scaleway.com,resources=serverlessnamespaces/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// Reconcile reconciles the Serverless Namespace
+func (r *ServerlessNamespaceReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &serverlessv1alpha1.ServerlessNamespace{})
+}
+
+// SetupWithManager registers the Serverless Namespace controller
+func (r *ServerlessNamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&serverlessv1alpha1.ServerlessNamespace{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.secretToRequests),
+		}).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
+
+// secretToRequests enqueues the ServerlessNamespaces using the given secret
+func (r *ServerlessNamespaceReconciler) secretToRequests(obj handler.MapObject) []reconcile.Request {
+	namespaces := serverlessv1alpha1.ServerlessNamespaceList{}
+	err := r.ScalewayReconciler.List(context.Background(), &namespaces, client.InNamespace(obj.Meta.GetNamespace()))
+	if err != nil {
+		r.ScalewayReconciler.Log.Error(err, "failed to list serverless namespaces")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, namespace := range namespaces.Items {
+		if usesSecret(namespace.Spec.SecretEnvironmentVariables, obj.Meta.GetName()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      namespace.Name,
+					Namespace: namespace.Namespace,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+func usesSecret(secretEnvVars []serverlessv1alpha1.ServerlessSecretEnvVar, secretName string) bool {
+	for _, secretEnvVar := range secretEnvVars {
+		if secretEnvVar.SecretKeyRef.Name == secretName {
+			return true
+		}
+	}
+	return false
+}
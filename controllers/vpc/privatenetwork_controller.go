@@ -0,0 +1,46 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// PrivateNetworkReconciler reconciles a PrivateNetwork object
+type PrivateNetworkReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=vpc.scaleway.com,resources=privatenetworks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vpc.scaleway.com,resources=privatenetworks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile reconciles the Private Network
+func (r *PrivateNetworkReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &vpcv1alpha1.PrivateNetwork{})
+}
+
+// SetupWithManager registers the Private Network controller
+func (r *PrivateNetworkReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vpcv1alpha1.PrivateNetwork{}).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
@@ -0,0 +1,84 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	vpcmanager "github.com/scaleway/scaleway-operator/pkg/manager/vpc"
+	"github.com/scaleway/scaleway-operator/webhooks"
+	vpcwebhook "github.com/scaleway/scaleway-operator/webhooks/vpc"
+)
+
+func init() {
+	scaleway.RegisterProduct(scaleway.Product{
+		Name:        "vpc",
+		AddToScheme: vpcv1alpha1.AddToScheme,
+		Kinds: []scaleway.Kind{
+			{
+				Name: "PrivateNetwork",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &vpcmanager.PrivateNetworkManager{
+						Client: c,
+						API:    vpc.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&PrivateNetworkReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "PrivateNetwork", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&vpcwebhook.PrivateNetworkValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+			{
+				Name: "PublicGateway",
+				NewManager: func(c client.Client, scwClient *scw.Client, log logr.Logger) scaleway.Manager {
+					return &vpcmanager.GatewayManager{
+						Client: c,
+						API:    vpcgw.NewAPI(scwClient),
+						Log:    log,
+					}
+				},
+				SetupController: func(mgr ctrl.Manager, manager scaleway.Manager, config scaleway.ControllerConfig) error {
+					return (&PublicGatewayReconciler{
+						ScalewayReconciler: controllers.NewScalewayReconciler(mgr, "PublicGateway", manager, config),
+					}).SetupWithManager(mgr)
+				},
+				SetupWebhook: func(mgr ctrl.Manager, manager scaleway.Manager, log logr.Logger) error {
+					return (&vpcwebhook.PublicGatewayValidator{
+						Log:             log,
+						ScalewayWebhook: &webhooks.ScalewayWebhook{ScalewayManager: manager},
+					}).SetupWebhookWithManager(mgr)
+				},
+			},
+		},
+	})
+}
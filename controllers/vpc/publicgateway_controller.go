@@ -0,0 +1,87 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+	"github.com/scaleway/scaleway-operator/controllers"
+)
+
+// PublicGatewayReconciler reconciles a PublicGateway object
+type PublicGatewayReconciler struct {
+	ScalewayReconciler *controllers.ScalewayReconciler
+}
+
+// +kubebuilder:rbac:groups=vpc.scaleway.com,resources=publicgateways,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vpc.scaleway.com,resources=publicgateways/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vpc.scaleway.com,resources=privatenetworks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile reconciles the Public Gateway
+func (r *PublicGatewayReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	return r.ScalewayReconciler.Reconcile(req, &vpcv1alpha1.PublicGateway{})
+}
+
+// SetupWithManager registers the Public Gateway controller
+func (r *PublicGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vpcv1alpha1.PublicGateway{}).
+		Watches(&source.Kind{Type: &vpcv1alpha1.PrivateNetwork{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.privateNetworkToRequests),
+		}).
+		WithOptions(r.ScalewayReconciler.Options).
+		Complete(r)
+}
+
+// privateNetworkToRequests enqueues the PublicGateways attached to the given private network
+func (r *PublicGatewayReconciler) privateNetworkToRequests(obj handler.MapObject) []reconcile.Request {
+	gateways := vpcv1alpha1.PublicGatewayList{}
+	err := r.ScalewayReconciler.List(context.Background(), &gateways)
+	if err != nil {
+		r.ScalewayReconciler.Log.Error(err, "failed to list public gateways")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, gateway := range gateways.Items {
+		for _, network := range gateway.Spec.PrivateNetworks {
+			networkNamespace := network.PrivateNetworkRef.Namespace
+			if networkNamespace == "" {
+				networkNamespace = gateway.Namespace
+			}
+			if network.PrivateNetworkRef.Name == obj.Meta.GetName() && networkNamespace == obj.Meta.GetNamespace() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      gateway.Name,
+						Namespace: gateway.Namespace,
+					},
+				})
+				break
+			}
+		}
+	}
+
+	return requests
+}
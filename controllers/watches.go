@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// AllowClusterField is the field index of the objects allowing the cluster nodes in their ACLs
+	AllowClusterField = "spec.acl.allowCluster"
+	// AllowClusterValue is the indexed value of the objects allowing the cluster nodes in their ACLs
+	AllowClusterValue = "true"
+)
+
+// NodeAddressesChanged filters the node events to the ones changing the node addresses
+// Nodes are updated very often, and only their addresses are used by the ACLs
+var NodeAddressesChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, ok := e.ObjectOld.(*corev1.Node)
+		if !ok {
+			return false
+		}
+		node, ok := e.ObjectNew.(*corev1.Node)
+		if !ok {
+			return false
+		}
+		return !reflect.DeepEqual(oldNode.Status.Addresses, node.Status.Addresses)
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
+// RefKey returns the value used to index a reference to the given object
+// The namespace of the reference defaults to the one of the referencing object
+func RefKey(namespace string, name string, defaultNamespace string) string {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+// RequestsForIndex lists the objects of the given list type matching the field index
+// and returns the requests to reconcile them
+func (r *ScalewayReconciler) RequestsForIndex(list runtime.Object, field string, value string) []reconcile.Request {
+	err := r.List(context.Background(), list, client.MatchingFields{field: value})
+	if err != nil {
+		r.Log.Error(err, "failed to list objects", "field", field, "value", value)
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		r.Log.Error(err, "failed to extract list")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, item := range items {
+		itemMeta, err := meta.Accessor(item)
+		if err != nil {
+			r.Log.Error(err, "failed to get object meta")
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      itemMeta.GetName(),
+				Namespace: itemMeta.GetNamespace(),
+			},
+		})
+	}
+
+	return requests
+}
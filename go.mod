@@ -3,9 +3,15 @@ module github.com/scaleway/scaleway-operator
 go 1.15
 
 require (
-	github.com/dnaeon/go-vcr v1.0.1
+	github.com/dnaeon/go-vcr v1.2.0
 	github.com/go-logr/logr v0.1.0
-	github.com/scaleway/scaleway-sdk-go v1.0.0-beta.7
+	github.com/prometheus/client_golang v1.0.0
+	github.com/scaleway/scaleway-sdk-go v1.0.0-beta.30
+	go.opentelemetry.io/otel v1.2.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.2.0
+	go.opentelemetry.io/otel/sdk v1.2.0
+	go.opentelemetry.io/otel/trace v1.2.0
+	golang.org/x/time v0.0.0-20190308202827-9d24e82272b4
 	k8s.io/api v0.18.6
 	k8s.io/apimachinery v0.18.6
 	k8s.io/client-go v0.18.6
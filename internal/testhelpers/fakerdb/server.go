@@ -0,0 +1,880 @@
+package fakerdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// DefaultTransitionSteps is the default number of reads an instance stays in a transient state
+	DefaultTransitionSteps = 2
+
+	// ErrorTypeQuotasExceeded is the type of the quotas exceeded error
+	ErrorTypeQuotasExceeded = "quotas_exceeded"
+	// ErrorTypeResourceLocked is the type of the resource locked error
+	ErrorTypeResourceLocked = "locked"
+	// ErrorTypeTransientState is the type of the transient state error
+	ErrorTypeTransientState = "transient_state"
+	// ErrorTypeNotFound is the type of the not found error
+	ErrorTypeNotFound = "not_found"
+	// ErrorTypeOutOfStock is the type of the out of stock error
+	ErrorTypeOutOfStock = "out_of_stock"
+	// ErrorTypePermissionsDenied is the type of the permissions denied error
+	ErrorTypePermissionsDenied = "permissions_denied"
+)
+
+const (
+	fakeAccessKey = "SCWXXXXXXXXXXXXXXXXX"
+	fakeSecretKey = "11111111-1111-1111-1111-111111111111"
+)
+
+// Server is a stateful fake of the Scaleway RDB API, served with httptest
+type Server struct {
+	*httptest.Server
+
+	// TransitionSteps is the number of reads an instance stays in a transient state,
+	// such as provisioning after a creation or configuring after an upgrade
+	TransitionSteps int
+	// InstanceQuota is the maximum number of instances, zero meaning no quota
+	InstanceQuota int
+
+	mu          sync.Mutex
+	nextID      int
+	instances   map[string]*instanceState
+	backups     map[string]*rdb.DatabaseBackup
+	engines     []*rdb.DatabaseEngine
+	nodeTypes   []*rdb.NodeType
+	failures    []failure
+	requestLogs []string
+}
+
+type instanceState struct {
+	instance     *rdb.Instance
+	acls         []*rdb.ACLRule
+	users        map[string]*rdb.User
+	databases    map[string]*rdb.Database
+	pendingSteps int
+	nextStatus   rdb.InstanceStatus
+}
+
+type failure struct {
+	method    string
+	errorType string
+}
+
+// NewServer starts a fake RDB API with default engines and node types
+// It must be closed by the caller
+func NewServer() *Server {
+	s := &Server{
+		TransitionSteps: DefaultTransitionSteps,
+		instances:       make(map[string]*instanceState),
+		backups:         make(map[string]*rdb.DatabaseBackup),
+		engines: []*rdb.DatabaseEngine{
+			{
+				Name:   "PostgreSQL",
+				Region: scw.RegionFrPar,
+				Versions: []*rdb.EngineVersion{
+					{Name: "PostgreSQL-12", Version: "12"},
+					{Name: "PostgreSQL-11", Version: "11"},
+				},
+			},
+			{
+				Name:   "MySQL",
+				Region: scw.RegionFrPar,
+				Versions: []*rdb.EngineVersion{
+					{Name: "MySQL-8", Version: "8"},
+				},
+			},
+		},
+		nodeTypes: []*rdb.NodeType{
+			{Name: "db-dev-s", StockStatus: rdb.NodeTypeStockAvailable, Region: scw.RegionFrPar},
+			{Name: "db-dev-m", StockStatus: rdb.NodeTypeStockAvailable, Region: scw.RegionFrPar},
+			{Name: "db-gp-xs", StockStatus: rdb.NodeTypeStockAvailable, Region: scw.RegionFrPar},
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Client returns a Scaleway client targeting the fake API
+func (s *Server) Client() (*scw.Client, error) {
+	return scw.NewClient(
+		scw.WithAPIURL(s.URL),
+		scw.WithHTTPClient(s.Server.Client()),
+		scw.WithAuth(fakeAccessKey, fakeSecretKey),
+		scw.WithDefaultRegion(scw.RegionFrPar),
+		scw.WithDefaultZone(scw.ZoneFrPar1),
+	)
+}
+
+// FailNext makes the next request with the given HTTP method fail with the given error type
+func (s *Server) FailNext(method string, errorType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{method: method, errorType: errorType})
+}
+
+// LockInstance locks the given instance, making every mutating call on it fail
+func (s *Server) LockInstance(instanceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state, ok := s.instances[instanceID]; ok {
+		state.instance.Status = rdb.InstanceStatusLocked
+		state.pendingSteps = 0
+	}
+}
+
+// Instance returns a copy of the given instance, without advancing its transient state
+func (s *Server) Instance(instanceID string) (rdb.Instance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.instances[instanceID]
+	if !ok {
+		return rdb.Instance{}, false
+	}
+	return *state.instance, true
+}
+
+// ACLRules returns a copy of the ACL rules of the given instance
+func (s *Server) ACLRules(instanceID string) []rdb.ACLRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.instances[instanceID]
+	if !ok {
+		return nil
+	}
+	rules := []rdb.ACLRule{}
+	for _, rule := range state.acls {
+		rules = append(rules, *rule)
+	}
+	return rules
+}
+
+// InstanceIDs returns the IDs of the existing instances
+func (s *Server) InstanceIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedInstanceIDs()
+}
+
+// Requests returns the requests received by the fake API, as "METHOD path"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requestLogs...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requestLogs = append(s.requestLogs, r.Method+" "+r.URL.Path)
+
+	for i, f := range s.failures {
+		if f.method == r.Method {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			writeError(w, f.errorType, "", "", "")
+			return
+		}
+	}
+
+	// paths are /rdb/v1/regions/{region}/{resource}/...
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 5 || parts[0] != "rdb" || parts[1] != "v1" || parts[2] != "regions" {
+		writeError(w, ErrorTypeNotFound, "path", r.URL.Path, "")
+		return
+	}
+	region := scw.Region(parts[3])
+	parts = parts[4:]
+
+	switch parts[0] {
+	case "database-engines":
+		s.listEngines(w, r)
+	case "node-types":
+		s.listNodeTypes(w, r)
+	case "instances":
+		s.handleInstances(w, r, region, parts[1:])
+	case "backups":
+		s.handleBackups(w, r, region, parts[1:])
+	default:
+		writeError(w, ErrorTypeNotFound, "path", r.URL.Path, "")
+	}
+}
+
+func (s *Server) handleInstances(w http.ResponseWriter, r *http.Request, region scw.Region, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.listInstances(w, r)
+		case http.MethodPost:
+			s.createInstance(w, r, region)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	state, ok := s.instances[parts[0]]
+	if !ok {
+		writeError(w, ErrorTypeNotFound, "instance", parts[0], "")
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			if !s.advance(state) {
+				writeError(w, ErrorTypeNotFound, "instance", parts[0], "")
+				return
+			}
+			writeJSON(w, state.instance)
+		case http.MethodPatch:
+			s.updateInstance(w, r, state)
+		case http.MethodDelete:
+			s.deleteInstance(w, state)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	switch parts[1] {
+	case "upgrade":
+		s.upgradeInstance(w, r, state)
+	case "clone":
+		s.cloneInstance(w, r, region, state)
+	case "acls":
+		s.handleACLs(w, r, state)
+	case "users":
+		s.handleUsers(w, r, state, parts[2:])
+	case "databases":
+		s.handleDatabases(w, r, state, parts[2:])
+	default:
+		writeError(w, ErrorTypeNotFound, "path", r.URL.Path, "")
+	}
+}
+
+func (s *Server) listInstances(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	instances := []*rdb.Instance{}
+	for _, id := range s.sortedInstanceIDs() {
+		instance := s.instances[id].instance
+		if name == "" || strings.Contains(instance.Name, name) {
+			instances = append(instances, instance)
+		}
+	}
+	start, end := pageBounds(r, len(instances))
+	writeJSON(w, &rdb.ListInstancesResponse{
+		Instances:  instances[start:end],
+		TotalCount: uint32(len(instances)),
+	})
+}
+
+func (s *Server) createInstance(w http.ResponseWriter, r *http.Request, region scw.Region) {
+	req := &rdb.CreateInstanceRequest{}
+	if !readJSON(w, r, req) {
+		return
+	}
+	if s.InstanceQuota > 0 && len(s.instances) >= s.InstanceQuota {
+		writeQuotasExceeded(w, len(s.instances))
+		return
+	}
+	if !s.nodeTypeExists(req.NodeType) {
+		writeInvalidArgument(w, "node_type")
+		return
+	}
+
+	state := s.newInstance(region, req.Name, req.Engine, req.NodeType)
+	state.instance.IsHaCluster = req.IsHaCluster
+	state.instance.Tags = append([]string{}, req.Tags...)
+	state.instance.BackupSchedule.Disabled = req.DisableBackup
+	writeJSON(w, state.instance)
+}
+
+func (s *Server) cloneInstance(w http.ResponseWriter, r *http.Request, region scw.Region, source *instanceState) {
+	req := &rdb.CloneInstanceRequest{}
+	if !readJSON(w, r, req) {
+		return
+	}
+	if !s.checkReady(w, source) {
+		return
+	}
+	if s.InstanceQuota > 0 && len(s.instances) >= s.InstanceQuota {
+		writeQuotasExceeded(w, len(s.instances))
+		return
+	}
+
+	nodeType := source.instance.NodeType
+	if req.NodeType != nil {
+		nodeType = *req.NodeType
+	}
+	state := s.newInstance(region, req.Name, source.instance.Engine, nodeType)
+	state.instance.IsHaCluster = source.instance.IsHaCluster
+	state.instance.Tags = append([]string{}, source.instance.Tags...)
+	for name, user := range source.users {
+		state.users[name] = &rdb.User{Name: user.Name, IsAdmin: user.IsAdmin}
+	}
+	for name, database := range source.databases {
+		state.databases[name] = &rdb.Database{Name: database.Name, Owner: database.Owner, Size: database.Size}
+	}
+	writeJSON(w, state.instance)
+}
+
+func (s *Server) updateInstance(w http.ResponseWriter, r *http.Request, state *instanceState) {
+	req := &rdb.UpdateInstanceRequest{}
+	if !readJSON(w, r, req) {
+		return
+	}
+	if !s.checkReady(w, state) {
+		return
+	}
+
+	if req.Name != nil {
+		state.instance.Name = *req.Name
+	}
+	if req.Tags != nil {
+		state.instance.Tags = append([]string{}, *req.Tags...)
+	}
+	if req.IsBackupScheduleDisabled != nil {
+		state.instance.BackupSchedule.Disabled = *req.IsBackupScheduleDisabled
+	}
+	if req.BackupScheduleFrequency != nil {
+		state.instance.BackupSchedule.Frequency = *req.BackupScheduleFrequency
+	}
+	if req.BackupScheduleRetention != nil {
+		state.instance.BackupSchedule.Retention = *req.BackupScheduleRetention
+	}
+	writeJSON(w, state.instance)
+}
+
+func (s *Server) upgradeInstance(w http.ResponseWriter, r *http.Request, state *instanceState) {
+	req := &rdb.UpgradeInstanceRequest{}
+	if !readJSON(w, r, req) {
+		return
+	}
+	if !s.checkReady(w, state) {
+		return
+	}
+
+	if req.NodeType != nil {
+		if !s.nodeTypeExists(*req.NodeType) {
+			writeInvalidArgument(w, "node_type")
+			return
+		}
+		state.instance.NodeType = *req.NodeType
+	}
+	if req.EnableHa != nil {
+		state.instance.IsHaCluster = *req.EnableHa
+	}
+	s.transition(state, rdb.InstanceStatusConfiguring, rdb.InstanceStatusReady)
+	writeJSON(w, state.instance)
+}
+
+func (s *Server) deleteInstance(w http.ResponseWriter, state *instanceState) {
+	if !s.checkReady(w, state) {
+		return
+	}
+	s.transition(state, rdb.InstanceStatusDeleting, rdb.InstanceStatusUnknown)
+	writeJSON(w, state.instance)
+	if state.pendingSteps == 0 {
+		delete(s.instances, state.instance.ID)
+	}
+}
+
+func (s *Server) handleACLs(w http.ResponseWriter, r *http.Request, state *instanceState) {
+	switch r.Method {
+	case http.MethodGet:
+		start, end := pageBounds(r, len(state.acls))
+		writeJSON(w, &rdb.ListInstanceACLRulesResponse{
+			Rules:      state.acls[start:end],
+			TotalCount: uint32(len(state.acls)),
+		})
+	case http.MethodPut:
+		req := &rdb.SetInstanceACLRulesRequest{}
+		if !readJSON(w, r, req) {
+			return
+		}
+		if !s.checkReady(w, state) {
+			return
+		}
+		state.acls = []*rdb.ACLRule{}
+		for _, rule := range req.Rules {
+			state.acls = append(state.acls, newACLRule(rule))
+		}
+		writeJSON(w, &rdb.SetInstanceACLRulesResponse{Rules: state.acls})
+	case http.MethodPost:
+		req := &rdb.AddInstanceACLRulesRequest{}
+		if !readJSON(w, r, req) {
+			return
+		}
+		if !s.checkReady(w, state) {
+			return
+		}
+		added := []*rdb.ACLRule{}
+		for _, rule := range req.Rules {
+			for _, existing := range state.acls {
+				if existing.IP.String() == rule.IP.String() {
+					writeInvalidArgument(w, "rules")
+					return
+				}
+			}
+			added = append(added, newACLRule(rule))
+		}
+		state.acls = append(state.acls, added...)
+		writeJSON(w, &rdb.AddInstanceACLRulesResponse{Rules: added})
+	case http.MethodDelete:
+		req := &rdb.DeleteInstanceACLRulesRequest{}
+		if !readJSON(w, r, req) {
+			return
+		}
+		if !s.checkReady(w, state) {
+			return
+		}
+		deletedIPs := map[string]bool{}
+		for _, ip := range req.ACLRuleIPs {
+			deletedIPs[ip] = true
+		}
+		remaining := []*rdb.ACLRule{}
+		deleted := []*rdb.ACLRule{}
+		for _, rule := range state.acls {
+			if deletedIPs[rule.IP.String()] {
+				deleted = append(deleted, rule)
+			} else {
+				remaining = append(remaining, rule)
+			}
+		}
+		state.acls = remaining
+		writeJSON(w, &rdb.DeleteInstanceACLRulesResponse{Rules: deleted})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleUsers(w http.ResponseWriter, r *http.Request, state *instanceState, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			name := r.URL.Query().Get("name")
+			users := []*rdb.User{}
+			names := make([]string, 0, len(state.users))
+			for userName := range state.users {
+				names = append(names, userName)
+			}
+			sort.Strings(names)
+			for _, userName := range names {
+				if name == "" || strings.Contains(userName, name) {
+					users = append(users, state.users[userName])
+				}
+			}
+			start, end := pageBounds(r, len(users))
+			writeJSON(w, &rdb.ListUsersResponse{
+				Users:      users[start:end],
+				TotalCount: uint32(len(users)),
+			})
+		case http.MethodPost:
+			req := &rdb.CreateUserRequest{}
+			if !readJSON(w, r, req) {
+				return
+			}
+			if !s.checkReady(w, state) {
+				return
+			}
+			if _, ok := state.users[req.Name]; ok {
+				writeInvalidArgument(w, "name")
+				return
+			}
+			user := &rdb.User{Name: req.Name, IsAdmin: req.IsAdmin}
+			state.users[req.Name] = user
+			writeJSON(w, user)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	user, ok := state.users[parts[0]]
+	if !ok {
+		writeError(w, ErrorTypeNotFound, "user", parts[0], "")
+		return
+	}
+	if !s.checkReady(w, state) {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		req := &rdb.UpdateUserRequest{}
+		if !readJSON(w, r, req) {
+			return
+		}
+		if req.IsAdmin != nil {
+			user.IsAdmin = *req.IsAdmin
+		}
+		writeJSON(w, user)
+	case http.MethodDelete:
+		delete(state.users, user.Name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleDatabases(w http.ResponseWriter, r *http.Request, state *instanceState, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			name := r.URL.Query().Get("name")
+			databases := []*rdb.Database{}
+			names := make([]string, 0, len(state.databases))
+			for databaseName := range state.databases {
+				names = append(names, databaseName)
+			}
+			sort.Strings(names)
+			for _, databaseName := range names {
+				if name == "" || strings.Contains(databaseName, name) {
+					databases = append(databases, state.databases[databaseName])
+				}
+			}
+			start, end := pageBounds(r, len(databases))
+			writeJSON(w, &rdb.ListDatabasesResponse{
+				Databases:  databases[start:end],
+				TotalCount: uint32(len(databases)),
+			})
+		case http.MethodPost:
+			req := &rdb.CreateDatabaseRequest{}
+			if !readJSON(w, r, req) {
+				return
+			}
+			if !s.checkReady(w, state) {
+				return
+			}
+			if _, ok := state.databases[req.Name]; ok {
+				writeInvalidArgument(w, "name")
+				return
+			}
+			database := &rdb.Database{Name: req.Name, Managed: true, Owner: "_rdb_superadmin"}
+			state.databases[req.Name] = database
+			writeJSON(w, database)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	database, ok := state.databases[parts[0]]
+	if !ok {
+		writeError(w, ErrorTypeNotFound, "database", parts[0], "")
+		return
+	}
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.checkReady(w, state) {
+		return
+	}
+	delete(state.databases, database.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleBackups(w http.ResponseWriter, r *http.Request, region scw.Region, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			instanceID := r.URL.Query().Get("instance_id")
+			ids := make([]string, 0, len(s.backups))
+			for id := range s.backups {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			backups := []*rdb.DatabaseBackup{}
+			for _, id := range ids {
+				if instanceID == "" || s.backups[id].InstanceID == instanceID {
+					backups = append(backups, s.backups[id])
+				}
+			}
+			start, end := pageBounds(r, len(backups))
+			writeJSON(w, &rdb.ListDatabaseBackupsResponse{
+				DatabaseBackups: backups[start:end],
+				TotalCount:      uint32(len(backups)),
+			})
+		case http.MethodPost:
+			req := &rdb.CreateDatabaseBackupRequest{}
+			if !readJSON(w, r, req) {
+				return
+			}
+			state, ok := s.instances[req.InstanceID]
+			if !ok {
+				writeError(w, ErrorTypeNotFound, "instance", req.InstanceID, "")
+				return
+			}
+			if _, ok := state.databases[req.DatabaseName]; !ok {
+				writeError(w, ErrorTypeNotFound, "database", req.DatabaseName, "")
+				return
+			}
+			if !s.checkReady(w, state) {
+				return
+			}
+			now := time.Now()
+			backup := &rdb.DatabaseBackup{
+				ID:           s.newID(),
+				InstanceID:   req.InstanceID,
+				InstanceName: state.instance.Name,
+				DatabaseName: req.DatabaseName,
+				Name:         req.Name,
+				Status:       rdb.DatabaseBackupStatusReady,
+				ExpiresAt:    req.ExpiresAt,
+				CreatedAt:    &now,
+				UpdatedAt:    &now,
+				Region:       region,
+			}
+			s.backups[backup.ID] = backup
+			writeJSON(w, backup)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	backup, ok := s.backups[parts[0]]
+	if !ok {
+		writeError(w, ErrorTypeNotFound, "database_backup", parts[0], "")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, backup)
+	case http.MethodDelete:
+		delete(s.backups, backup.ID)
+		writeJSON(w, backup)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) listEngines(w http.ResponseWriter, r *http.Request) {
+	start, end := pageBounds(r, len(s.engines))
+	writeJSON(w, &rdb.ListDatabaseEnginesResponse{
+		Engines:    s.engines[start:end],
+		TotalCount: uint32(len(s.engines)),
+	})
+}
+
+func (s *Server) listNodeTypes(w http.ResponseWriter, r *http.Request) {
+	start, end := pageBounds(r, len(s.nodeTypes))
+	writeJSON(w, &rdb.ListNodeTypesResponse{
+		NodeTypes:  s.nodeTypes[start:end],
+		TotalCount: uint32(len(s.nodeTypes)),
+	})
+}
+
+func newACLRule(rule *rdb.ACLRuleRequest) *rdb.ACLRule {
+	return &rdb.ACLRule{
+		IP:          rule.IP,
+		Port:        scw.Uint32Ptr(5432),
+		Protocol:    rdb.ACLRuleProtocolTCP,
+		Direction:   rdb.ACLRuleDirectionInbound,
+		Action:      rdb.ACLRuleActionAllow,
+		Description: rule.Description,
+	}
+}
+
+func (s *Server) newInstance(region scw.Region, name string, engine string, nodeType string) *instanceState {
+	now := time.Now()
+	ip := net.IPv4(51, 15, 0, byte(len(s.instances)+1))
+	state := &instanceState{
+		instance: &rdb.Instance{
+			ID:        s.newID(),
+			Name:      name,
+			Region:    region,
+			Engine:    engine,
+			NodeType:  nodeType,
+			Tags:      []string{},
+			CreatedAt: &now,
+			BackupSchedule: &rdb.BackupSchedule{
+				Frequency: 24,
+				Retention: 7,
+			},
+			Endpoint: &rdb.Endpoint{
+				IP:   &ip,
+				Port: 5432,
+			},
+		},
+		acls:      []*rdb.ACLRule{},
+		users:     make(map[string]*rdb.User),
+		databases: make(map[string]*rdb.Database),
+	}
+	s.transition(state, rdb.InstanceStatusProvisioning, rdb.InstanceStatusReady)
+	s.instances[state.instance.ID] = state
+	return state
+}
+
+// transition puts the instance in the given transient state for TransitionSteps reads
+// An unknown next status deletes the instance at the end of the transition
+func (s *Server) transition(state *instanceState, status rdb.InstanceStatus, nextStatus rdb.InstanceStatus) {
+	state.instance.Status = status
+	state.nextStatus = nextStatus
+	state.pendingSteps = s.TransitionSteps
+	if state.pendingSteps <= 0 {
+		state.pendingSteps = 0
+		state.instance.Status = nextStatus
+	}
+}
+
+// advance moves the instance toward the end of its transition
+// It returns false when the instance was deleted at the end of its transition
+func (s *Server) advance(state *instanceState) bool {
+	if state.pendingSteps == 0 {
+		return true
+	}
+	state.pendingSteps--
+	if state.pendingSteps == 0 {
+		state.instance.Status = state.nextStatus
+	}
+	if state.instance.Status == rdb.InstanceStatusUnknown {
+		delete(s.instances, state.instance.ID)
+		return false
+	}
+	return true
+}
+
+func (s *Server) checkReady(w http.ResponseWriter, state *instanceState) bool {
+	switch state.instance.Status {
+	case rdb.InstanceStatusReady:
+		return true
+	case rdb.InstanceStatusLocked:
+		writeError(w, ErrorTypeResourceLocked, "instance", state.instance.ID, "")
+	default:
+		writeError(w, ErrorTypeTransientState, "instance", state.instance.ID, state.instance.Status.String())
+	}
+	return false
+}
+
+func (s *Server) nodeTypeExists(name string) bool {
+	for _, nodeType := range s.nodeTypes {
+		if nodeType.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", s.nextID)
+}
+
+func (s *Server) sortedInstanceIDs() []string {
+	ids := make([]string, 0, len(s.instances))
+	for id := range s.instances {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// pageBounds returns the bounds of the requested page in a list of the given size
+func pageBounds(r *http.Request, size int) (int, int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 20
+	}
+
+	start := (page - 1) * pageSize
+	if start > size {
+		start = size
+	}
+	end := start + pageSize
+	if end > size {
+		end = size
+	}
+	return start, end
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Body == nil {
+		return true
+	}
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil && err != io.EOF {
+		writeInvalidArgument(w, "body")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, errorType string, resource string, resourceID string, currentState string) {
+	body := map[string]interface{}{
+		"type":    errorType,
+		"message": strings.Replace(errorType, "_", " ", -1),
+	}
+	if resource != "" {
+		body["resource"] = resource
+		body["resource_id"] = resourceID
+	}
+	if currentState != "" {
+		body["current_state"] = currentState
+	}
+
+	status := http.StatusBadRequest
+	switch errorType {
+	case ErrorTypeNotFound:
+		status = http.StatusNotFound
+	case ErrorTypeTransientState, ErrorTypeResourceLocked:
+		status = http.StatusConflict
+	case ErrorTypeQuotasExceeded, ErrorTypePermissionsDenied:
+		status = http.StatusForbidden
+	case ErrorTypeOutOfStock:
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeQuotasExceeded(w http.ResponseWriter, current int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":    ErrorTypeQuotasExceeded,
+		"message": "quota(s) exceeded for this resource",
+		"details": []map[string]interface{}{
+			{"resource": "instances", "quota": current, "current": current},
+		},
+	})
+}
+
+func writeInvalidArgument(w http.ResponseWriter, argument string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":    "invalid_arguments",
+		"message": "invalid argument(s)",
+		"details": []map[string]interface{}{
+			{"argument_name": argument, "reason": "constraint"},
+		},
+	})
+}
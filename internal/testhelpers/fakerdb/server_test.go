@@ -0,0 +1,85 @@
+package fakerdb
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func Test_Server(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := rdb.NewAPI(client)
+
+	instance, err := api.CreateInstance(&rdb.CreateInstanceRequest{
+		Name:     "test",
+		Engine:   "PostgreSQL-12",
+		NodeType: "db-dev-s",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if instance.Status != rdb.InstanceStatusProvisioning {
+		t.Errorf("got status %s instead of %s", instance.Status, rdb.InstanceStatusProvisioning)
+	}
+
+	_, err = api.CreateUser(&rdb.CreateUserRequest{InstanceID: instance.ID, Name: "user"})
+	if _, ok := err.(*scw.TransientStateError); !ok {
+		t.Errorf("got error %v instead of a transient state error", err)
+	}
+
+	for i := 0; i < server.TransitionSteps; i++ {
+		instance, err = api.GetInstance(&rdb.GetInstanceRequest{InstanceID: instance.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if instance.Status != rdb.InstanceStatusReady {
+		t.Errorf("got status %s instead of %s", instance.Status, rdb.InstanceStatusReady)
+	}
+
+	for i := 0; i < 25; i++ {
+		_, err = api.CreateUser(&rdb.CreateUserRequest{InstanceID: instance.ID, Name: fmt.Sprintf("user-%02d", i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	usersResp, err := api.ListUsers(&rdb.ListUsersRequest{InstanceID: instance.ID}, scw.WithAllPages())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usersResp.Users) != 25 {
+		t.Errorf("got %d users instead of 25", len(usersResp.Users))
+	}
+
+	server.InstanceQuota = 1
+	_, err = api.CreateInstance(&rdb.CreateInstanceRequest{Name: "other", Engine: "PostgreSQL-12", NodeType: "db-dev-s"})
+	if _, ok := err.(*scw.QuotasExceededError); !ok {
+		t.Errorf("got error %v instead of a quotas exceeded error", err)
+	}
+
+	server.LockInstance(instance.ID)
+	_, err = api.UpgradeInstance(&rdb.UpgradeInstanceRequest{InstanceID: instance.ID, NodeType: scw.StringPtr("db-dev-m")})
+	if _, ok := err.(*scw.ResourceLockedError); !ok {
+		t.Errorf("got error %v instead of a resource locked error", err)
+	}
+
+	server.FailNext(http.MethodGet, ErrorTypeOutOfStock)
+	_, err = api.GetInstance(&rdb.GetInstanceRequest{InstanceID: instance.ID})
+	if _, ok := err.(*scw.OutOfStockError); !ok {
+		t.Errorf("got error %v instead of an out of stock error", err)
+	}
+
+	_, err = api.GetInstance(&rdb.GetInstanceRequest{InstanceID: "unknown"})
+	if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+		t.Errorf("got error %v instead of a not found error", err)
+	}
+}
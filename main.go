@@ -17,24 +17,37 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
-	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
-	"github.com/scaleway/scaleway-sdk-go/scw"
-
-	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
 	"github.com/scaleway/scaleway-operator/controllers"
-	rdbcontroller "github.com/scaleway/scaleway-operator/controllers/rdb"
-	rdbmanager "github.com/scaleway/scaleway-operator/pkg/manager/rdb"
-	"github.com/scaleway/scaleway-operator/webhooks"
-	rdbwebhook "github.com/scaleway/scaleway-operator/webhooks/rdb"
+	_ "github.com/scaleway/scaleway-operator/controllers/block"
+	_ "github.com/scaleway/scaleway-operator/controllers/domain"
+	_ "github.com/scaleway/scaleway-operator/controllers/iam"
+	_ "github.com/scaleway/scaleway-operator/controllers/ip"
+	_ "github.com/scaleway/scaleway-operator/controllers/mnq"
+	_ "github.com/scaleway/scaleway-operator/controllers/rdb"
+	_ "github.com/scaleway/scaleway-operator/controllers/redis"
+	_ "github.com/scaleway/scaleway-operator/controllers/registry"
+	_ "github.com/scaleway/scaleway-operator/controllers/secret"
+	_ "github.com/scaleway/scaleway-operator/controllers/serverless"
+	_ "github.com/scaleway/scaleway-operator/controllers/vpc"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/metrics"
+	"github.com/scaleway/scaleway-operator/pkg/ratelimit"
+	"github.com/scaleway/scaleway-operator/pkg/tracing"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -46,21 +59,86 @@ var (
 func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 
-	_ = rdbv1alpha1.AddToScheme(scheme)
+	// all the products are added to the scheme, even disabled ones,
+	// since some controllers watch objects of other products
+	for _, product := range scaleway.GetProducts() {
+		_ = product.AddToScheme(scheme)
+	}
 	// +kubebuilder:scaffold:scheme
 }
 
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var enabledProducts string
+	var disabledProducts string
+	var resyncPeriod time.Duration
+	var resyncPeriods string
+	var dryRun bool
+	var migrateStorageVersions bool
+	var tracingConfig tracing.Config
+	var rateLimitConfig ratelimit.Config
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&enabledProducts, "enable-products", "",
+		"Comma-separated list of products to run the controllers and webhooks of, such as rdb,redis. "+
+			"All the products are enabled when empty.")
+	flag.StringVar(&disabledProducts, "disable-products", "",
+		"Comma-separated list of products to not run the controllers and webhooks of. "+
+			"The webhooks of disabled products are not served, so their webhook configurations must be removed. "+
+			"The kinds of disabled products are not watched by the other controllers, so resources referencing them, "+
+			"such as an RDBInstance ACL sourced from a FlexibleIP, are not reconciled when they change.")
+	flag.DurationVar(&resyncPeriod, "resync-period", 10*time.Minute,
+		"The duration after which reconciled resources are reconciled again to detect their drift. "+
+			"A zero duration disables the resync.")
+	flag.StringVar(&resyncPeriods, "resync-periods", "",
+		"Comma-separated list of kind=duration overriding the resync period of the given kinds, such as RDBInstance=5m,RedisCluster=0.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Only plan the actions needed to reconcile the resources, without executing them. "+
+			"The planned actions are reported in the status and as events. "+
+			"The operator refuses to start if an enabled kind does not support it, "+
+			"so only the products supporting it, such as rdb, must be enabled.")
+	flag.BoolVar(&migrateStorageVersions, "migrate-storage-versions", true,
+		"Rewrite the resources served in several versions in their storage version on startup. "+
+			"It requires the conversion webhook, so it is skipped when the webhooks are disabled.")
+	flag.StringVar(&tracingConfig.Endpoint, "tracing-endpoint", "",
+		"The host:port of the OTLP HTTP collector to export the traces to, such as localhost:4318. "+
+			"Tracing is disabled when empty, unless the OTEL_EXPORTER_OTLP_ENDPOINT environment variable is set.")
+	flag.BoolVar(&tracingConfig.Insecure, "tracing-insecure", false,
+		"Export the traces without TLS, such as to a local collector.")
+	flag.Float64Var(&tracingConfig.SampleRatio, "tracing-sample-ratio", 1,
+		"The ratio of the reconciliations to trace, between 0 and 1.")
+	flag.Float64Var(&rateLimitConfig.Rate, "api-rate-limit", 20,
+		"The number of requests per second allowed to the Scaleway API, per product and region. "+
+			"A zero rate disables the rate limiting.")
+	flag.IntVar(&rateLimitConfig.Burst, "api-burst", 40,
+		"The number of requests allowed at once to the Scaleway API, per product and region.")
+	flag.IntVar(&rateLimitConfig.MaxRetries, "api-max-retries", 5,
+		"The number of times a throttled (429) or failed (5xx) request to the Scaleway API is retried. "+
+			"Failed requests are only retried for idempotent methods.")
+	flag.DurationVar(&rateLimitConfig.MinBackoff, "api-min-backoff", 500*time.Millisecond,
+		"The duration waited before retrying a request to the Scaleway API, doubled on each retry, unless the response has a Retry-After header.")
+	flag.DurationVar(&rateLimitConfig.MaxBackoff, "api-max-backoff", 10*time.Second,
+		"The maximum duration waited before retrying a request to the Scaleway API. "+
+			"Requests with a longer Retry-After are not retried, and their resources are reconciled again later.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
+	products, err := scaleway.SelectProducts(splitList(enabledProducts), splitList(disabledProducts))
+	if err != nil {
+		setupLog.Error(err, "unable to select products")
+		os.Exit(1)
+	}
+
+	kindResyncPeriods, err := parseDurations(resyncPeriods)
+	if err != nil {
+		setupLog.Error(err, "unable to parse resync periods")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
@@ -73,93 +151,129 @@ func main() {
 		os.Exit(1)
 	}
 
-	scwClient, err := scw.NewClient(scw.WithEnv())
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingConfig)
 	if err != nil {
-		setupLog.Error(err, "unable to create scw client")
+		setupLog.Error(err, "unable to setup tracing")
 		os.Exit(1)
 	}
 
-	if err = (&rdbcontroller.RDBInstanceReconciler{
-		ScalewayReconciler: &controllers.ScalewayReconciler{
-			Client:   mgr.GetClient(),
-			Log:      ctrl.Log.WithName("controllers").WithName("RDBInstance"),
-			Recorder: mgr.GetEventRecorderFor("RDBInstance"),
-			Scheme:   mgr.GetScheme(),
-			ScalewayManager: &rdbmanager.InstanceManager{
-				API:    rdb.NewAPI(scwClient),
-				Client: mgr.GetClient(),
-				Log:    ctrl.Log.WithName("manager").WithName("RDBInstance"),
-			},
-		},
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "RDBInstance")
+	// the requests are traced once, while the metrics are recorded for each of their attempts
+	httpClient := tracing.WrapClient(ratelimit.WrapClient(metrics.NewHTTPClient(), rateLimitConfig))
+	scwClient, err := scw.NewClient(scw.WithEnv(), scw.WithHTTPClient(httpClient))
+	if err != nil {
+		setupLog.Error(err, "unable to create scw client")
 		os.Exit(1)
 	}
 
-	if err = (&rdbcontroller.RDBDatabaseReconciler{
-		ScalewayReconciler: &controllers.ScalewayReconciler{
-			Client:   mgr.GetClient(),
-			Log:      ctrl.Log.WithName("controllers").WithName("RDBDatabase"),
-			Recorder: mgr.GetEventRecorderFor("RDBDatabase"),
-			Scheme:   mgr.GetScheme(),
-			ScalewayManager: &rdbmanager.DatabaseManager{
-				API:    rdb.NewAPI(scwClient),
-				Client: mgr.GetClient(),
-			},
-		},
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "RDBDatabase")
-		os.Exit(1)
-	}
+	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") != "false"
+	enabledProductNames := scaleway.ProductNames(products)
 
-	if err = (&rdbcontroller.RDBUserReconciler{
-		ScalewayReconciler: &controllers.ScalewayReconciler{
-			Client:   mgr.GetClient(),
-			Log:      ctrl.Log.WithName("controllers").WithName("RDBUser"),
-			Recorder: mgr.GetEventRecorderFor("RDBDatabase"),
-			Scheme:   mgr.GetScheme(),
-			ScalewayManager: &rdbmanager.DatabaseManager{
-				API:    rdb.NewAPI(scwClient),
-				Client: mgr.GetClient(),
-			},
-		},
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "RDBUser")
-		os.Exit(1)
-	}
-	// +kubebuilder:scaffold:builder
+	for _, product := range products {
+		setupLog.Info("enabling product", "product", product.Name)
 
-	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err = (&rdbwebhook.RDBInstanceValidator{
-			Log: ctrl.Log.WithName("webhooks").WithName("RDBInstance"),
-			ScalewayWebhook: &webhooks.ScalewayWebhook{
-				ScalewayManager: &rdbmanager.InstanceManager{
-					API:    rdb.NewAPI(scwClient),
-					Client: mgr.GetClient(),
-				},
-			},
-		}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "RDBInstance")
-			os.Exit(1)
+		for _, kind := range product.Kinds {
+			manager := kind.NewManager(mgr.GetClient(), scwClient, ctrl.Log.WithName("manager").WithName(kind.Name))
+
+			// the kinds not supporting the dry-run mode would silently never be planned
+			if dryRun && !scaleway.SupportsDryRun(manager) {
+				setupLog.Error(fmt.Errorf("dry-run is not supported by kind %s", kind.Name),
+					"unable to run in dry-run mode, disable the product with --disable-products", "product", product.Name)
+				os.Exit(1)
+			}
+
+			config := scaleway.ControllerConfig{
+				Options:         kind.ControllerOptions,
+				ResyncPeriod:    resyncPeriod,
+				DryRun:          dryRun,
+				EnabledProducts: enabledProductNames,
+			}
+			if kind.ResyncPeriod != 0 {
+				config.ResyncPeriod = kind.ResyncPeriod
+			}
+			if period, ok := kindResyncPeriods[kind.Name]; ok {
+				config.ResyncPeriod = period
+			}
+
+			if err = kind.SetupController(mgr, manager, config); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", kind.Name)
+				os.Exit(1)
+			}
+
+			if enableWebhooks && kind.SetupWebhook != nil {
+				if err = kind.SetupWebhook(mgr, manager, ctrl.Log.WithName("webhooks").WithName(kind.Name)); err != nil {
+					setupLog.Error(err, "unable to create webhook", "webhook", kind.Name)
+					os.Exit(1)
+				}
+			}
 		}
+	}
 
-		if err = (&rdbwebhook.RDBDatabaseValidator{
-			Log: ctrl.Log.WithName("webhooks").WithName("RDBDatabase"),
-			ScalewayWebhook: &webhooks.ScalewayWebhook{
-				ScalewayManager: &rdbmanager.DatabaseManager{
-					API:    rdb.NewAPI(scwClient),
-					Client: mgr.GetClient(),
-				},
-			},
-		}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "RDBDatabase")
-			os.Exit(1)
+	if enableWebhooks {
+		mgr.GetWebhookServer().Register("/convert", &conversion.Webhook{})
+
+		if migrateStorageVersions {
+			kinds := []string{}
+			for _, product := range products {
+				for _, kind := range product.Kinds {
+					kinds = append(kinds, kind.Name)
+				}
+			}
+			err = mgr.Add(&controllers.StorageVersionMigrator{
+				Client:     mgr.GetClient(),
+				Scheme:     mgr.GetScheme(),
+				RESTMapper: mgr.GetRESTMapper(),
+				Log:        ctrl.Log.WithName("migration"),
+				Kinds:      kinds,
+			})
+			if err != nil {
+				setupLog.Error(err, "unable to add storage version migrator")
+				os.Exit(1)
+			}
 		}
 	}
+	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	// flush the remaining traces before exiting
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
+
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// splitList splits a comma-separated list, ignoring empty items
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseDurations parses a comma-separated list of key=duration
+func parseDurations(list string) (map[string]time.Duration, error) {
+	durations := make(map[string]time.Duration)
+	for _, item := range splitList(list) {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid item %s, expected key=duration", item)
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", parts[0], err)
+		}
+		durations[strings.TrimSpace(parts[0])] = duration
+	}
+	return durations, nil
+}
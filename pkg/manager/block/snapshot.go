@@ -0,0 +1,159 @@
+package block
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	blockv1alpha1 "github.com/scaleway/scaleway-operator/apis/block/v1alpha1"
+)
+
+// SnapshotManager manages the block volume snapshots
+type SnapshotManager struct {
+	client.Client
+	API *block.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the block volume snapshot resource
+func (m *SnapshotManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	snapshot, err := convertSnapshot(obj)
+	if err != nil {
+		return false, err
+	}
+
+	// if snapshotID is empty, we need to create the snapshot
+	if snapshot.Spec.SnapshotID == "" {
+		return false, m.createSnapshot(ctx, snapshot)
+	}
+
+	snapshotResp, err := m.API.GetSnapshot(&block.GetSnapshotRequest{
+		Zone:       scw.Zone(snapshot.Spec.Zone),
+		SnapshotID: snapshot.Spec.SnapshotID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	snapshot.Status.Size = resource.NewQuantity(int64(snapshotResp.Size), resource.DecimalSI)
+	snapshot.Status.State = snapshotResp.Status.String()
+	if snapshotResp.ParentVolume != nil {
+		snapshot.Status.VolumeID = snapshotResp.ParentVolume.ID
+	}
+
+	switch snapshotResp.Status {
+	case block.SnapshotStatusError:
+		return false, fmt.Errorf("snapshot is in error")
+	case block.SnapshotStatusCreating:
+		return false, nil
+	}
+
+	if !utils.CompareTagsLabels(snapshotResp.Tags, snapshot.Labels) {
+		_, err = m.API.UpdateSnapshot(&block.UpdateSnapshotRequest{
+			Zone:       snapshotResp.Zone,
+			SnapshotID: snapshotResp.ID,
+			Tags:       scw.StringsPtr(utils.LabelsToTags(snapshot.Labels)),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Delete deletes the block volume snapshot resource
+func (m *SnapshotManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	snapshot, err := convertSnapshot(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := snapshot.Spec.SnapshotID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	err = m.API.DeleteSnapshot(&block.DeleteSnapshotRequest{
+		Zone:       scw.Zone(snapshot.Spec.Zone),
+		SnapshotID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the block volume snapshot resource
+// Snapshots are backups, so they are not owned by their volume and outlive it
+func (m *SnapshotManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+func (m *SnapshotManager) createSnapshot(ctx context.Context, snapshot *blockv1alpha1.BlockVolumeSnapshot) error {
+	volumeID, zone, err := getVolumeRef(ctx, m.Client, snapshot.Namespace, snapshot.Spec.VolumeRef)
+	if err != nil {
+		return err
+	}
+	if volumeID == "" {
+		// the volume is not created yet
+		return nil
+	}
+
+	snapshotResp, err := m.API.CreateSnapshot(&block.CreateSnapshotRequest{
+		Zone:     scw.Zone(zone),
+		VolumeID: volumeID,
+		Name:     snapshot.Name,
+		Tags:     utils.LabelsToTags(snapshot.Labels),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	snapshot.Spec.SnapshotID = snapshotResp.ID
+	snapshot.Spec.Zone = snapshotResp.Zone.String()
+
+	return m.Client.Update(ctx, snapshot)
+}
+
+// getVolumeRef returns the ID and zone of the referenced volume
+// The ID is empty when the volume is not created yet
+func getVolumeRef(ctx context.Context, c client.Client, objNamespace string, ref blockv1alpha1.BlockVolumeRef) (string, string, error) {
+	if ref.Name == "" {
+		return ref.ExternalID, ref.Zone, nil
+	}
+
+	volumeNamespace := ref.Namespace
+	if volumeNamespace == "" {
+		volumeNamespace = objNamespace
+	}
+
+	volume := &blockv1alpha1.BlockVolume{}
+	err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: volumeNamespace}, volume)
+	if err != nil {
+		return "", "", err
+	}
+
+	return volume.Spec.VolumeID, volume.Spec.Zone, nil
+}
+
+func convertSnapshot(obj runtime.Object) (*blockv1alpha1.BlockVolumeSnapshot, error) {
+	snapshot, ok := obj.(*blockv1alpha1.BlockVolumeSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,110 @@
+package block
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	blockv1alpha1 "github.com/scaleway/scaleway-operator/apis/block/v1alpha1"
+)
+
+// ValidateCreate validates the creation of a Block Volume Snapshot
+func (m *SnapshotManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	snapshot, err := convertSnapshot(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scw.ParseZone(snapshot.Spec.Zone)
+	if snapshot.Spec.Zone != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("zone"), snapshot.Spec.Zone, "zone is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validateVolumeRef(snapshot.Spec.VolumeRef)...)
+	if len(allErrs) > 0 {
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	volumeRef := snapshot.Spec.VolumeRef
+	if volumeRef.ExternalID != "" && snapshot.Spec.SnapshotID == "" {
+		_, err = m.API.GetVolume(&block.GetVolumeRequest{
+			Zone:     scw.Zone(volumeRef.Zone),
+			VolumeID: volumeRef.ExternalID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("volumeRef").Child("externalID"), volumeRef.ExternalID, err.Error()))
+		}
+	}
+
+	if snapshot.Spec.SnapshotID != "" {
+		_, err = m.API.GetSnapshot(&block.GetSnapshotRequest{
+			Zone:       scw.Zone(snapshot.Spec.Zone),
+			SnapshotID: snapshot.Spec.SnapshotID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("snapshotID"), snapshot.Spec.SnapshotID, err.Error()))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Block Volume Snapshot
+func (m *SnapshotManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	snapshot, err := convertSnapshot(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldSnapshot, err := convertSnapshot(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldSnapshot.Spec.SnapshotID != "" && oldSnapshot.Spec.SnapshotID != snapshot.Spec.SnapshotID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("snapshotID"), "field is immutable"))
+	}
+
+	if oldSnapshot.Spec.Zone != "" && oldSnapshot.Spec.Zone != snapshot.Spec.Zone {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("zone"), "field is immutable"))
+	}
+
+	if oldSnapshot.Spec.VolumeRef != snapshot.Spec.VolumeRef {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("volumeRef"), "field is immutable"))
+	}
+
+	return allErrs, nil
+}
+
+func validateVolumeRef(ref blockv1alpha1.BlockVolumeRef) field.ErrorList {
+	var allErrs field.ErrorList
+
+	byName := ref.Name != "" || ref.Namespace != ""
+	byID := ref.ExternalID != "" || ref.Zone != ""
+
+	if ref.Name == "" && ref.ExternalID == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("volumeRef"), "name/namespace or externalID/zone must be specified"))
+		return allErrs
+	}
+	if byName && byID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("volumeRef"), "only one of name/namespace and externalID/zone must be specified"))
+		return allErrs
+	}
+
+	if byID {
+		_, err := scw.ParseZone(ref.Zone)
+		if ref.Zone != "" && err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("volumeRef").Child("zone"), ref.Zone, "zone is not valid"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,422 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	blockv1alpha1 "github.com/scaleway/scaleway-operator/apis/block/v1alpha1"
+)
+
+const (
+	// VolumeLabel is the label holding the name of the volume on scheduled snapshots
+	VolumeLabel = "block.scaleway.com/volume"
+	// SnapshotScheduleLabel is the label holding the UID of the volume on scheduled snapshots
+	// Only the snapshots carrying it are pruned by the retention
+	SnapshotScheduleLabel = "block.scaleway.com/snapshot-schedule"
+
+	instanceServerResourceType = "instance_server"
+)
+
+// VolumeManager manages the block volumes
+type VolumeManager struct {
+	client.Client
+	API         *block.API
+	InstanceAPI *instance.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the block volume resource
+func (m *VolumeManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	volume, err := convertVolume(obj)
+	if err != nil {
+		return false, err
+	}
+
+	// if volumeID is empty, we need to create the volume
+	if volume.Spec.VolumeID == "" {
+		return false, m.createVolume(ctx, volume)
+	}
+
+	volumeResp, err := m.API.GetVolume(&block.GetVolumeRequest{
+		Zone:     scw.Zone(volume.Spec.Zone),
+		VolumeID: volume.Spec.VolumeID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	volume.Status.Size = resource.NewQuantity(int64(volumeResp.Size), resource.DecimalSI)
+	volume.Status.State = volumeResp.Status.String()
+	if volumeResp.Specs != nil {
+		volume.Status.StorageClass = volumeResp.Specs.Class.String()
+		if volumeResp.Specs.PerfIops != nil {
+			volume.Status.PerfIOPS = int32(*volumeResp.Specs.PerfIops)
+		}
+	}
+
+	volume.Status.AttachedServerID = ""
+	volume.Status.AttachmentState = ""
+	serverRef := getServerReference(volumeResp.References)
+	if serverRef != nil {
+		volume.Status.AttachedServerID = serverRef.ProductResourceID
+		volume.Status.AttachmentState = serverRef.Status.String()
+	}
+
+	switch volumeResp.Status {
+	case block.VolumeStatusError:
+		return false, fmt.Errorf("volume is in error")
+	case block.VolumeStatusAvailable, block.VolumeStatusInUse:
+	default:
+		return false, nil
+	}
+
+	needReturn, err := m.updateVolume(ctx, volume, volumeResp)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	needReturn, err = m.ensureAttachment(ctx, volume, serverRef)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	err = m.ensureSnapshots(ctx, volume, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Delete deletes the block volume resource
+// The volume is detached from its Instance server first
+func (m *VolumeManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	volume, err := convertVolume(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := volume.Spec.VolumeID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	volumeResp, err := m.API.GetVolume(&block.GetVolumeRequest{
+		Zone:     scw.Zone(volume.Spec.Zone),
+		VolumeID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	serverRef := getServerReference(volumeResp.References)
+	if serverRef != nil {
+		if serverRef.Status == block.ReferenceStatusAttached {
+			_, err = m.InstanceAPI.DetachServerVolume(&instance.DetachServerVolumeRequest{
+				Zone:     volumeResp.Zone,
+				ServerID: serverRef.ProductResourceID,
+				VolumeID: volumeResp.ID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	err = m.API.DeleteVolume(&block.DeleteVolumeRequest{
+		Zone:     volumeResp.Zone,
+		VolumeID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the block volume resource
+func (m *VolumeManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+// GetRefreshInterval returns the duration until the next scheduled snapshot of the volume
+func (m *VolumeManager) GetRefreshInterval(obj runtime.Object) time.Duration {
+	volume, err := convertVolume(obj)
+	if err != nil {
+		return 0
+	}
+
+	if volume.Spec.SnapshotPolicy == nil {
+		return 0
+	}
+
+	if volume.Status.LastSnapshotTime == nil {
+		return volume.Spec.SnapshotPolicy.Interval.Duration
+	}
+
+	remaining := time.Until(volume.Status.LastSnapshotTime.Add(volume.Spec.SnapshotPolicy.Interval.Duration))
+	if remaining < time.Second {
+		return time.Second
+	}
+
+	return remaining
+}
+
+func (m *VolumeManager) createVolume(ctx context.Context, volume *blockv1alpha1.BlockVolume) error {
+	req := &block.CreateVolumeRequest{
+		Zone: scw.Zone(volume.Spec.Zone),
+		Name: volume.Name,
+		Tags: utils.LabelsToTags(volume.Labels),
+	}
+
+	if volume.Spec.PerfIOPS != nil {
+		req.PerfIops = scw.Uint32Ptr(uint32(*volume.Spec.PerfIOPS))
+	}
+
+	if volume.Spec.FromSnapshotID != "" {
+		req.FromSnapshot = &block.CreateVolumeRequestFromSnapshot{
+			SnapshotID: volume.Spec.FromSnapshotID,
+		}
+		if volume.Spec.Size != nil {
+			size := scw.Size(volume.Spec.Size.Value())
+			req.FromSnapshot.Size = &size
+		}
+	} else {
+		if volume.Spec.Size == nil {
+			return fmt.Errorf("size is required when the volume is not created from a snapshot")
+		}
+		req.FromEmpty = &block.CreateVolumeRequestFromEmpty{
+			Size: scw.Size(volume.Spec.Size.Value()),
+		}
+	}
+
+	volumeResp, err := m.API.CreateVolume(req, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	volume.Spec.VolumeID = volumeResp.ID
+	volume.Spec.Zone = volumeResp.Zone.String()
+
+	return m.Client.Update(ctx, volume)
+}
+
+func (m *VolumeManager) updateVolume(ctx context.Context, volume *blockv1alpha1.BlockVolume, volumeResp *block.Volume) (bool, error) {
+	needsUpdate := false
+	req := &block.UpdateVolumeRequest{
+		Zone:     volumeResp.Zone,
+		VolumeID: volumeResp.ID,
+	}
+
+	// volumes can only grow, which is enforced by the webhook
+	if volume.Spec.Size != nil && scw.Size(volume.Spec.Size.Value()) > volumeResp.Size {
+		size := scw.Size(volume.Spec.Size.Value())
+		req.Size = &size
+		needsUpdate = true
+	}
+
+	if volume.Spec.PerfIOPS != nil && (volumeResp.Specs == nil || volumeResp.Specs.PerfIops == nil || *volumeResp.Specs.PerfIops != uint32(*volume.Spec.PerfIOPS)) {
+		req.PerfIops = scw.Uint32Ptr(uint32(*volume.Spec.PerfIOPS))
+		needsUpdate = true
+	}
+
+	if !utils.CompareTagsLabels(volumeResp.Tags, volume.Labels) {
+		req.Tags = scw.StringsPtr(utils.LabelsToTags(volume.Labels))
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		return false, nil
+	}
+
+	_, err := m.API.UpdateVolume(req, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ensureAttachment attaches the volume to the wanted Instance server, detaching it first if needed
+func (m *VolumeManager) ensureAttachment(ctx context.Context, volume *blockv1alpha1.BlockVolume, serverRef *block.Reference) (bool, error) {
+	if serverRef != nil {
+		if serverRef.ProductResourceID == volume.Spec.ServerID {
+			return serverRef.Status != block.ReferenceStatusAttached, nil
+		}
+
+		if serverRef.Status != block.ReferenceStatusAttached {
+			return true, nil
+		}
+
+		_, err := m.InstanceAPI.DetachServerVolume(&instance.DetachServerVolumeRequest{
+			Zone:     scw.Zone(volume.Spec.Zone),
+			ServerID: serverRef.ProductResourceID,
+			VolumeID: volume.Spec.VolumeID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if volume.Spec.ServerID == "" {
+		return false, nil
+	}
+
+	_, err := m.InstanceAPI.AttachServerVolume(&instance.AttachServerVolumeRequest{
+		Zone:       scw.Zone(volume.Spec.Zone),
+		ServerID:   volume.Spec.ServerID,
+		VolumeID:   volume.Spec.VolumeID,
+		VolumeType: instance.AttachServerVolumeRequestVolumeTypeSbsVolume,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ensureSnapshots creates the scheduled snapshots of the volume and prunes the ones exceeding the retention
+func (m *VolumeManager) ensureSnapshots(ctx context.Context, volume *blockv1alpha1.BlockVolume, now time.Time) error {
+	snapshots := blockv1alpha1.BlockVolumeSnapshotList{}
+	err := m.Client.List(ctx, &snapshots, client.InNamespace(volume.Namespace), client.MatchingLabels(getScheduleLabels(volume)))
+	if err != nil {
+		return err
+	}
+
+	scheduled := snapshots.Items
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].CreationTimestamp.Before(&scheduled[j].CreationTimestamp)
+	})
+
+	if volume.Spec.SnapshotPolicy == nil {
+		// existing scheduled snapshots are kept when the policy is removed
+		return nil
+	}
+
+	if needsSnapshot(scheduled, volume.Spec.SnapshotPolicy.Interval.Duration, now) {
+		snapshot := blockv1alpha1.BlockVolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      volume.Name + "-" + now.UTC().Format("20060102150405"),
+				Namespace: volume.Namespace,
+				Labels:    getScheduledSnapshotLabels(volume),
+			},
+			Spec: blockv1alpha1.BlockVolumeSnapshotSpec{
+				Zone: volume.Spec.Zone,
+				VolumeRef: blockv1alpha1.BlockVolumeRef{
+					Name: volume.Name,
+				},
+			},
+		}
+
+		err = m.Client.Create(ctx, &snapshot)
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		snapshot.CreationTimestamp = metav1.NewTime(now)
+
+		scheduled = append(scheduled, snapshot)
+	}
+
+	for _, snapshot := range getExpiredSnapshots(scheduled, volume.Spec.SnapshotPolicy.Retention) {
+		err = m.Client.Delete(ctx, &snapshot)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	lastSnapshotTime := scheduled[len(scheduled)-1].CreationTimestamp
+	volume.Status.LastSnapshotTime = &lastSnapshotTime
+
+	return nil
+}
+
+// getScheduleLabels returns the labels marking the scheduled snapshots of the volume
+// The UID of the volume keeps snapshots labeled by hand, or by a previous volume
+// of the same name, out of the retention
+func getScheduleLabels(volume *blockv1alpha1.BlockVolume) map[string]string {
+	return map[string]string{
+		VolumeLabel:           volume.Name,
+		SnapshotScheduleLabel: string(volume.UID),
+	}
+}
+
+// getScheduledSnapshotLabels returns the labels of a new scheduled snapshot
+// The labels of the volume are kept so that the snapshot gets the same tags
+func getScheduledSnapshotLabels(volume *blockv1alpha1.BlockVolume) map[string]string {
+	labels := map[string]string{}
+	for key, value := range volume.Labels {
+		labels[key] = value
+	}
+	for key, value := range getScheduleLabels(volume) {
+		labels[key] = value
+	}
+	return labels
+}
+
+// needsSnapshot returns whether a new scheduled snapshot is needed
+// The given snapshots are sorted by creation time
+func needsSnapshot(snapshots []blockv1alpha1.BlockVolumeSnapshot, interval time.Duration, now time.Time) bool {
+	if len(snapshots) == 0 {
+		return true
+	}
+
+	last := snapshots[len(snapshots)-1].CreationTimestamp
+	return !now.Before(last.Add(interval))
+}
+
+// getExpiredSnapshots returns the oldest scheduled snapshots exceeding the retention
+// The given snapshots are sorted by creation time
+func getExpiredSnapshots(snapshots []blockv1alpha1.BlockVolumeSnapshot, retention int32) []blockv1alpha1.BlockVolumeSnapshot {
+	if retention < 1 || len(snapshots) <= int(retention) {
+		return nil
+	}
+
+	return snapshots[:len(snapshots)-int(retention)]
+}
+
+// getServerReference returns the reference of the Instance server the volume is attached to, if any
+func getServerReference(references []*block.Reference) *block.Reference {
+	for _, reference := range references {
+		if reference.ProductResourceType == instanceServerResourceType {
+			return reference
+		}
+	}
+	return nil
+}
+
+func convertVolume(obj runtime.Object) (*blockv1alpha1.BlockVolume, error) {
+	volume, ok := obj.(*blockv1alpha1.BlockVolume)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return volume, nil
+}
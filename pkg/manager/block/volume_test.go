@@ -0,0 +1,117 @@
+package block
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	blockv1alpha1 "github.com/scaleway/scaleway-operator/apis/block/v1alpha1"
+)
+
+func newSnapshots(now time.Time, ages ...time.Duration) []blockv1alpha1.BlockVolumeSnapshot {
+	snapshots := []blockv1alpha1.BlockVolumeSnapshot{}
+	for _, age := range ages {
+		snapshots = append(snapshots, blockv1alpha1.BlockVolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+		})
+	}
+	return snapshots
+}
+
+func Test_needsSnapshot(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		snapshots []blockv1alpha1.BlockVolumeSnapshot
+		interval  time.Duration
+		needed    bool
+	}{
+		{
+			snapshots: newSnapshots(now),
+			interval:  time.Hour,
+			needed:    true,
+		},
+		{
+			snapshots: newSnapshots(now, 3*time.Hour, 30*time.Minute),
+			interval:  time.Hour,
+			needed:    false,
+		},
+		{
+			snapshots: newSnapshots(now, 2*time.Hour, time.Hour),
+			interval:  time.Hour,
+			needed:    true,
+		},
+	}
+
+	for i, c := range cases {
+		if needed := needsSnapshot(c.snapshots, c.interval, now); needed != c.needed {
+			t.Errorf("case %d: got %t instead of %t", i, needed, c.needed)
+		}
+	}
+}
+
+func Test_getExpiredSnapshots(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		snapshots []blockv1alpha1.BlockVolumeSnapshot
+		retention int32
+		expired   int
+	}{
+		{
+			snapshots: newSnapshots(now),
+			retention: 3,
+			expired:   0,
+		},
+		{
+			snapshots: newSnapshots(now, 3*time.Hour, 2*time.Hour, time.Hour),
+			retention: 3,
+			expired:   0,
+		},
+		{
+			snapshots: newSnapshots(now, 4*time.Hour, 3*time.Hour, 2*time.Hour, time.Hour),
+			retention: 2,
+			expired:   2,
+		},
+	}
+
+	for i, c := range cases {
+		expired := getExpiredSnapshots(c.snapshots, c.retention)
+		if len(expired) != c.expired {
+			t.Errorf("case %d: got %d instead of %d", i, len(expired), c.expired)
+			continue
+		}
+		for j := range expired {
+			if !expired[j].CreationTimestamp.Equal(&c.snapshots[j].CreationTimestamp) {
+				t.Errorf("case %d: expected the oldest snapshots to expire", i)
+			}
+		}
+	}
+}
+
+func Test_getScheduledSnapshotLabels(t *testing.T) {
+	volume := &blockv1alpha1.BlockVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "data",
+			UID:    "uid",
+			Labels: map[string]string{"app": "db", SnapshotScheduleLabel: "other-uid"},
+		},
+	}
+
+	labels := getScheduledSnapshotLabels(volume)
+	expected := map[string]string{
+		"app":                 "db",
+		VolumeLabel:           "data",
+		SnapshotScheduleLabel: "uid",
+	}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("Got labels %v instead of %v", labels, expected)
+	}
+	if volume.Labels[SnapshotScheduleLabel] != "other-uid" {
+		t.Errorf("Got volume labels modified to %v", volume.Labels)
+	}
+}
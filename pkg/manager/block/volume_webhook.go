@@ -0,0 +1,139 @@
+package block
+
+import (
+	"context"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	blockv1alpha1 "github.com/scaleway/scaleway-operator/apis/block/v1alpha1"
+)
+
+const (
+	minSnapshotInterval = time.Hour
+)
+
+// ValidateCreate validates the creation of a Block Volume
+func (m *VolumeManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	volume, err := convertVolume(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scw.ParseZone(volume.Spec.Zone)
+	if volume.Spec.Zone != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("zone"), volume.Spec.Zone, "zone is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	if volume.Spec.Size == nil && volume.Spec.FromSnapshotID == "" && volume.Spec.VolumeID == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("size"), "size is required when the volume is not created from a snapshot"))
+	}
+
+	allErrs = append(allErrs, validateSnapshotPolicy(volume.Spec.SnapshotPolicy)...)
+
+	if volume.Spec.VolumeID != "" {
+		_, err = m.API.GetVolume(&block.GetVolumeRequest{
+			Zone:     scw.Zone(volume.Spec.Zone),
+			VolumeID: volume.Spec.VolumeID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("volumeID"), volume.Spec.VolumeID, err.Error()))
+		}
+	}
+
+	if volume.Spec.FromSnapshotID != "" {
+		_, err = m.API.GetSnapshot(&block.GetSnapshotRequest{
+			Zone:       scw.Zone(volume.Spec.Zone),
+			SnapshotID: volume.Spec.FromSnapshotID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("fromSnapshotID"), volume.Spec.FromSnapshotID, err.Error()))
+		}
+	}
+
+	allErrs = append(allErrs, m.validateServer(ctx, volume)...)
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Block Volume
+func (m *VolumeManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	volume, err := convertVolume(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldVolume, err := convertVolume(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldVolume.Spec.VolumeID != "" && oldVolume.Spec.VolumeID != volume.Spec.VolumeID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("volumeID"), "field is immutable"))
+	}
+
+	if oldVolume.Spec.Zone != "" && oldVolume.Spec.Zone != volume.Spec.Zone {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("zone"), "field is immutable"))
+	}
+
+	if oldVolume.Spec.FromSnapshotID != volume.Spec.FromSnapshotID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("fromSnapshotID"), "field is immutable"))
+	}
+
+	if volume.Spec.Size != nil {
+		if oldVolume.Spec.Size != nil && volume.Spec.Size.Cmp(*oldVolume.Spec.Size) < 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("size"), volume.Spec.Size.String(), "volume size can't be decreased"))
+		} else if oldVolume.Status.Size != nil && volume.Spec.Size.Cmp(*oldVolume.Status.Size) < 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("size"), volume.Spec.Size.String(), "volume size can't be decreased"))
+		}
+	}
+
+	allErrs = append(allErrs, validateSnapshotPolicy(volume.Spec.SnapshotPolicy)...)
+
+	if oldVolume.Spec.ServerID != volume.Spec.ServerID {
+		allErrs = append(allErrs, m.validateServer(ctx, volume)...)
+	}
+
+	return allErrs, nil
+}
+
+func (m *VolumeManager) validateServer(ctx context.Context, volume *blockv1alpha1.BlockVolume) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if volume.Spec.ServerID == "" {
+		return allErrs
+	}
+
+	_, err := m.InstanceAPI.GetServer(&instance.GetServerRequest{
+		Zone:     scw.Zone(volume.Spec.Zone),
+		ServerID: volume.Spec.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("serverID"), volume.Spec.ServerID, err.Error()))
+	}
+
+	return allErrs
+}
+
+func validateSnapshotPolicy(policy *blockv1alpha1.BlockSnapshotPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if policy == nil {
+		return allErrs
+	}
+
+	if policy.Interval.Duration < minSnapshotInterval {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("snapshotPolicy").Child("interval"), policy.Interval.Duration.String(), "interval must be at least "+minSnapshotInterval.String()))
+	}
+
+	return allErrs
+}
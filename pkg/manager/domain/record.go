@@ -0,0 +1,283 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	domainv1alpha1 "github.com/scaleway/scaleway-operator/apis/domain/v1alpha1"
+	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+	redisv1alpha1 "github.com/scaleway/scaleway-operator/apis/redis/v1alpha1"
+)
+
+const (
+	defaultTTL = 3600
+
+	recordComment = "managed by scaleway-operator"
+)
+
+// RecordManager manages the DNS records
+type RecordManager struct {
+	client.Client
+	API *domain.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the DNS record resource
+func (m *RecordManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	record, err := convertRecord(obj)
+	if err != nil {
+		return false, err
+	}
+
+	value, err := m.getValue(ctx, record)
+	if err != nil {
+		return false, err
+	}
+	// the value is not available yet on the referenced object
+	if value == "" {
+		return false, nil
+	}
+
+	wantedRecord := getWantedRecord(record, value)
+
+	// if recordID is empty, we need to create the record
+	if record.Spec.RecordID == "" {
+		return false, m.createRecord(ctx, record, wantedRecord)
+	}
+
+	recordsResp, err := m.API.ListDNSZoneRecords(&domain.ListDNSZoneRecordsRequest{
+		DNSZone: record.Spec.DNSZone,
+		ID:      scw.StringPtr(record.Spec.RecordID),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	if len(recordsResp.Records) == 0 {
+		return false, &scw.ResourceNotFoundError{
+			Resource:   "record",
+			ResourceID: record.Spec.RecordID,
+		}
+	}
+	existingRecord := recordsResp.Records[0]
+
+	if existingRecord.Data != wantedRecord.Data ||
+		existingRecord.TTL != wantedRecord.TTL ||
+		existingRecord.Priority != wantedRecord.Priority {
+		// keep the record ID so the record stays owned by this object
+		wantedRecord.ID = record.Spec.RecordID
+		_, err := m.API.UpdateDNSZoneRecords(&domain.UpdateDNSZoneRecordsRequest{
+			DNSZone: record.Spec.DNSZone,
+			Changes: []*domain.RecordChange{
+				{
+					Set: &domain.RecordChangeSet{
+						ID:      scw.StringPtr(record.Spec.RecordID),
+						Records: []*domain.Record{wantedRecord},
+					},
+				},
+			},
+			DisallowNewZoneCreation: true,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	record.Status.FQDN = getFQDN(record)
+	record.Status.Value = value
+
+	return true, nil
+}
+
+// Delete deletes the DNS record resource
+func (m *RecordManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	record, err := convertRecord(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := record.Spec.RecordID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	_, err = m.API.UpdateDNSZoneRecords(&domain.UpdateDNSZoneRecordsRequest{
+		DNSZone: record.Spec.DNSZone,
+		Changes: []*domain.RecordChange{
+			{
+				Delete: &domain.RecordChangeDelete{
+					ID: scw.StringPtr(resourceID),
+				},
+			},
+		},
+		DisallowNewZoneCreation: true,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the DNS record resource
+func (m *RecordManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+func (m *RecordManager) createRecord(ctx context.Context, record *domainv1alpha1.DNSRecord, wantedRecord *domain.Record) error {
+	updateResp, err := m.API.UpdateDNSZoneRecords(&domain.UpdateDNSZoneRecordsRequest{
+		DNSZone: record.Spec.DNSZone,
+		Changes: []*domain.RecordChange{
+			{
+				Add: &domain.RecordChangeAdd{
+					Records: []*domain.Record{wantedRecord},
+				},
+			},
+		},
+		DisallowNewZoneCreation: true,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	recordID := findRecordID(updateResp.Records, wantedRecord)
+	if recordID == "" {
+		return fmt.Errorf("could not find created record %s %s in zone %s", wantedRecord.Type, wantedRecord.Name, record.Spec.DNSZone)
+	}
+
+	record.Spec.RecordID = recordID
+	err = m.Client.Update(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *RecordManager) getValue(ctx context.Context, record *domainv1alpha1.DNSRecord) (string, error) {
+	if record.Spec.ValueFrom == nil {
+		return record.Spec.Value, nil
+	}
+
+	objectRef := record.Spec.ValueFrom.ObjectRef
+
+	object, err := newValueSource(objectRef)
+	if err != nil {
+		return "", err
+	}
+
+	err = m.Get(ctx, types.NamespacedName{
+		Name:      objectRef.Name,
+		Namespace: record.Namespace,
+	}, object)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return "", err
+	}
+
+	fieldValue, found, err := unstructured.NestedFieldNoCopy(content, getFieldPath(record.Spec.ValueFrom.FieldPath)...)
+	if err != nil {
+		return "", err
+	}
+	if !found || fieldValue == nil {
+		return "", nil
+	}
+
+	return fmt.Sprint(fieldValue), nil
+}
+
+// valueSources are the kinds a record can get its value from
+var valueSources = map[schema.GroupVersionKind]func() runtime.Object{
+	corev1.SchemeGroupVersion.WithKind("Service"):       func() runtime.Object { return &corev1.Service{} },
+	rdbv1alpha1.GroupVersion.WithKind("RDBInstance"):    func() runtime.Object { return &rdbv1alpha1.RDBInstance{} },
+	rdbv1beta1.GroupVersion.WithKind("RDBInstance"):     func() runtime.Object { return &rdbv1beta1.RDBInstance{} },
+	redisv1alpha1.GroupVersion.WithKind("RedisCluster"): func() runtime.Object { return &redisv1alpha1.RedisCluster{} },
+}
+
+func newValueSource(objectRef domainv1alpha1.DNSRecordObjectRef) (runtime.Object, error) {
+	gv, err := schema.ParseGroupVersion(objectRef.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	newObject, ok := valueSources[gv.WithKind(objectRef.Kind)]
+	if !ok {
+		return nil, fmt.Errorf("kind %s %s is not a supported value source", objectRef.APIVersion, objectRef.Kind)
+	}
+
+	return newObject(), nil
+}
+
+func getFieldPath(fieldPath string) []string {
+	return strings.Split(strings.TrimPrefix(fieldPath, "."), ".")
+}
+
+func getWantedRecord(record *domainv1alpha1.DNSRecord, value string) *domain.Record {
+	wantedRecord := &domain.Record{
+		Name:    record.Spec.Name,
+		Type:    domain.RecordType(record.Spec.Type),
+		Data:    value,
+		TTL:     defaultTTL,
+		Comment: scw.StringPtr(recordComment),
+	}
+
+	if record.Spec.TTL != nil {
+		wantedRecord.TTL = uint32(*record.Spec.TTL)
+	}
+
+	if record.Spec.Type == domainv1alpha1.RecordTypeMX && record.Spec.Priority != nil {
+		wantedRecord.Priority = uint32(*record.Spec.Priority)
+	}
+
+	// TXT records data are returned quoted by the API
+	if record.Spec.Type == domainv1alpha1.RecordTypeTXT && !strings.HasPrefix(value, "\"") {
+		wantedRecord.Data = "\"" + value + "\""
+	}
+
+	return wantedRecord
+}
+
+func findRecordID(records []*domain.Record, wantedRecord *domain.Record) string {
+	for _, record := range records {
+		if record.Name == wantedRecord.Name && record.Type == wantedRecord.Type && record.Data == wantedRecord.Data {
+			return record.ID
+		}
+	}
+	return ""
+}
+
+func getFQDN(record *domainv1alpha1.DNSRecord) string {
+	if record.Spec.Name == "" {
+		return record.Spec.DNSZone
+	}
+	return record.Spec.Name + "." + record.Spec.DNSZone
+}
+
+func convertRecord(obj runtime.Object) (*domainv1alpha1.DNSRecord, error) {
+	record, ok := obj.(*domainv1alpha1.DNSRecord)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return record, nil
+}
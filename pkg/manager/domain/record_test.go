@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+
+	domainv1alpha1 "github.com/scaleway/scaleway-operator/apis/domain/v1alpha1"
+)
+
+func Test_getWantedRecord(t *testing.T) {
+	ttl := int32(300)
+	priority := int32(10)
+
+	cases := []struct {
+		spec     domainv1alpha1.DNSRecordSpec
+		value    string
+		data     string
+		ttl      uint32
+		priority uint32
+	}{
+		{
+			spec:  domainv1alpha1.DNSRecordSpec{Name: "db", Type: domainv1alpha1.RecordTypeA},
+			value: "1.2.3.4",
+			data:  "1.2.3.4",
+			ttl:   defaultTTL,
+		},
+		{
+			spec:  domainv1alpha1.DNSRecordSpec{Name: "txt", Type: domainv1alpha1.RecordTypeTXT, TTL: &ttl},
+			value: "foo=bar",
+			data:  "\"foo=bar\"",
+			ttl:   300,
+		},
+		{
+			spec:  domainv1alpha1.DNSRecordSpec{Name: "txt", Type: domainv1alpha1.RecordTypeTXT},
+			value: "\"foo=bar\"",
+			data:  "\"foo=bar\"",
+			ttl:   defaultTTL,
+		},
+		{
+			spec:     domainv1alpha1.DNSRecordSpec{Type: domainv1alpha1.RecordTypeMX, Priority: &priority},
+			value:    "mx.example.com.",
+			data:     "mx.example.com.",
+			ttl:      defaultTTL,
+			priority: 10,
+		},
+		{
+			spec:  domainv1alpha1.DNSRecordSpec{Name: "www", Type: domainv1alpha1.RecordTypeCNAME, Priority: &priority},
+			value: "example.com.",
+			data:  "example.com.",
+			ttl:   defaultTTL,
+		},
+	}
+
+	for _, c := range cases {
+		record := &domainv1alpha1.DNSRecord{Spec: c.spec}
+		wantedRecord := getWantedRecord(record, c.value)
+		if wantedRecord.Name != c.spec.Name || wantedRecord.Type != domain.RecordType(c.spec.Type) {
+			t.Errorf("Got %s %s instead of %s %s", wantedRecord.Type, wantedRecord.Name, c.spec.Type, c.spec.Name)
+		}
+		if wantedRecord.Data != c.data {
+			t.Errorf("Got data %s instead of %s", wantedRecord.Data, c.data)
+		}
+		if wantedRecord.TTL != c.ttl {
+			t.Errorf("Got ttl %d instead of %d", wantedRecord.TTL, c.ttl)
+		}
+		if wantedRecord.Priority != c.priority {
+			t.Errorf("Got priority %d instead of %d", wantedRecord.Priority, c.priority)
+		}
+	}
+}
+
+func Test_validateValueFrom(t *testing.T) {
+	cases := []struct {
+		objectRef domainv1alpha1.DNSRecordObjectRef
+		valid     bool
+	}{
+		{
+			objectRef: domainv1alpha1.DNSRecordObjectRef{APIVersion: "v1", Kind: "Service", Name: "svc"},
+			valid:     true,
+		},
+		{
+			objectRef: domainv1alpha1.DNSRecordObjectRef{APIVersion: "rdb.scaleway.com/v1alpha1", Kind: "RDBInstance", Name: "db"},
+			valid:     true,
+		},
+		{
+			objectRef: domainv1alpha1.DNSRecordObjectRef{APIVersion: "rdb.scaleway.com/v1beta1", Kind: "RDBInstance", Name: "db"},
+			valid:     true,
+		},
+		{
+			objectRef: domainv1alpha1.DNSRecordObjectRef{APIVersion: "redis.scaleway.com/v1alpha1", Kind: "RedisCluster", Name: "redis"},
+			valid:     true,
+		},
+		{
+			objectRef: domainv1alpha1.DNSRecordObjectRef{APIVersion: "v1", Kind: "Secret", Name: "password"},
+			valid:     false,
+		},
+		{
+			objectRef: domainv1alpha1.DNSRecordObjectRef{APIVersion: "v1", Kind: "ConfigMap", Name: "config"},
+			valid:     false,
+		},
+		{
+			objectRef: domainv1alpha1.DNSRecordObjectRef{APIVersion: "rdb.scaleway.com/v1beta1", Kind: "RDBUser", Name: "user"},
+			valid:     false,
+		},
+		{
+			objectRef: domainv1alpha1.DNSRecordObjectRef{APIVersion: "a/b/c", Kind: "Service", Name: "svc"},
+			valid:     false,
+		},
+	}
+
+	for _, c := range cases {
+		record := &domainv1alpha1.DNSRecord{
+			Spec: domainv1alpha1.DNSRecordSpec{
+				Type: domainv1alpha1.RecordTypeA,
+				ValueFrom: &domainv1alpha1.DNSRecordValueFrom{
+					ObjectRef: c.objectRef,
+					FieldPath: "status.endpoint.ip",
+				},
+			},
+		}
+		errs := validateValue(record)
+		if c.valid && len(errs) != 0 {
+			t.Errorf("Got errors %v for %s %s", errs, c.objectRef.APIVersion, c.objectRef.Kind)
+		}
+		if !c.valid && len(errs) == 0 {
+			t.Errorf("Expected an error for %s %s", c.objectRef.APIVersion, c.objectRef.Kind)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"context"
+	"net"
+
+	"github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	domainv1alpha1 "github.com/scaleway/scaleway-operator/apis/domain/v1alpha1"
+)
+
+// ValidateCreate validates the creation of a DNS Record
+func (m *RecordManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	record, err := convertRecord(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	allErrs = append(allErrs, validateValue(record)...)
+
+	zonesResp, err := m.API.ListDNSZones(&domain.ListDNSZonesRequest{
+		DNSZone: scw.StringPtr(record.Spec.DNSZone),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(zonesResp.DNSZones) == 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("dnsZone"), record.Spec.DNSZone, "dns zone does not exist"))
+		return allErrs, nil
+	}
+
+	if record.Spec.RecordID != "" {
+		recordsResp, err := m.API.ListDNSZoneRecords(&domain.ListDNSZoneRecordsRequest{
+			DNSZone: record.Spec.DNSZone,
+			ID:      scw.StringPtr(record.Spec.RecordID),
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("recordID"), record.Spec.RecordID, err.Error()))
+			return allErrs, nil
+		}
+		if len(recordsResp.Records) == 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("recordID"), record.Spec.RecordID, "record does not exist"))
+			return allErrs, nil
+		}
+		if string(recordsResp.Records[0].Type) != string(record.Spec.Type) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("type"), record.Spec.Type, "type does not match"))
+		}
+		if recordsResp.Records[0].Name != record.Spec.Name {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("name"), record.Spec.Name, "name does not match"))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a DNS Record
+func (m *RecordManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	record, err := convertRecord(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldRecord, err := convertRecord(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldRecord.Spec.RecordID != "" && oldRecord.Spec.RecordID != record.Spec.RecordID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("recordID"), "field is immutable"))
+	}
+
+	if oldRecord.Spec.DNSZone != record.Spec.DNSZone {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("dnsZone"), "field is immutable"))
+	}
+
+	if oldRecord.Spec.Name != record.Spec.Name {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("name"), "field is immutable"))
+	}
+
+	if oldRecord.Spec.Type != record.Spec.Type {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("type"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateValue(record)...)
+
+	return allErrs, nil
+}
+
+func validateValue(record *domainv1alpha1.DNSRecord) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if record.Spec.Value == "" && record.Spec.ValueFrom == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("value"), "value or valueFrom must be specified"))
+		return allErrs
+	}
+	if record.Spec.Value != "" && record.Spec.ValueFrom != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("value"), "only one of value and valueFrom must be specified"))
+		return allErrs
+	}
+
+	if record.Spec.ValueFrom != nil {
+		valueFromPath := field.NewPath("spec").Child("valueFrom")
+		if _, err := newValueSource(record.Spec.ValueFrom.ObjectRef); err != nil {
+			allErrs = append(allErrs, field.Invalid(valueFromPath.Child("objectRef"), record.Spec.ValueFrom.ObjectRef, err.Error()))
+		}
+		if record.Spec.ValueFrom.FieldPath == "" {
+			allErrs = append(allErrs, field.Required(valueFromPath.Child("fieldPath"), "field path must be specified"))
+		}
+		return allErrs
+	}
+
+	ip := net.ParseIP(record.Spec.Value)
+	switch record.Spec.Type {
+	case domainv1alpha1.RecordTypeA:
+		if ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("value"), record.Spec.Value, "value is not a valid IPv4"))
+		}
+	case domainv1alpha1.RecordTypeAAAA:
+		if ip == nil || ip.To4() != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("value"), record.Spec.Value, "value is not a valid IPv6"))
+		}
+	}
+
+	return allErrs
+}
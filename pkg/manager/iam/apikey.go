@@ -0,0 +1,394 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	iamv1alpha1 "github.com/scaleway/scaleway-operator/apis/iam/v1alpha1"
+)
+
+const (
+	// APIKeySecretSuffix is the suffix of the API key secret name
+	APIKeySecretSuffix = "-api-key"
+
+	// APIKeySecretAccessKeyKey is the API key secret key holding the access key
+	APIKeySecretAccessKeyKey = "SCW_ACCESS_KEY"
+	// APIKeySecretSecretKeyKey is the API key secret key holding the secret key
+	APIKeySecretSecretKeyKey = "SCW_SECRET_KEY"
+	// APIKeySecretDefaultProjectIDKey is the API key secret key holding the default project ID
+	APIKeySecretDefaultProjectIDKey = "SCW_DEFAULT_PROJECT_ID"
+
+	// RotateAnnotation is the annotation triggering a rotation of the API key whenever its value changes
+	RotateAnnotation = "iam.scaleway.com/rotate"
+
+	defaultRotationGracePeriod = time.Hour
+)
+
+// APIKeyManager manages the IAM API keys
+type APIKeyManager struct {
+	client.Client
+	API *iam.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the IAM API key resource
+func (m *APIKeyManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	apiKey, err := convertAPIKey(obj)
+	if err != nil {
+		return false, err
+	}
+
+	applicationID, err := getApplicationID(ctx, m.Client, apiKey.Namespace, apiKey.Spec.ApplicationRef)
+	if err != nil {
+		return false, err
+	}
+	if applicationID == "" {
+		// the application is not created yet
+		return false, nil
+	}
+
+	// if accessKey is empty, we need to create the API key
+	if apiKey.Spec.AccessKey == "" {
+		return false, m.createAPIKey(ctx, apiKey, applicationID, "")
+	}
+
+	secret := &corev1.Secret{}
+	err = m.Client.Get(ctx, types.NamespacedName{
+		Name:      apiKey.Name + APIKeySecretSuffix,
+		Namespace: apiKey.Namespace,
+	}, secret)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		secret = nil
+	}
+	if secret != nil && !metav1.IsControlledBy(secret, apiKey) {
+		return false, fmt.Errorf("secret %s already exists and is not controlled by this IAMAPIKey", secret.Name)
+	}
+
+	apiKeyResp, err := m.API.GetAPIKey(&iam.GetAPIKeyRequest{
+		AccessKey: apiKey.Spec.AccessKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+			return false, err
+		}
+		apiKeyResp = nil
+	}
+
+	// the secret key is only returned on creation, so the API key is rotated
+	// whenever it is lost, on demand, or when it is too old
+	if apiKeyResp == nil || needsRotation(apiKey, apiKeyResp, secret, time.Now()) {
+		keptAccessKey, deletedAccessKeys := getRotatedAccessKeys(apiKey, apiKeyResp != nil, secret)
+		for _, accessKey := range deletedAccessKeys {
+			err = m.deleteAPIKey(ctx, accessKey)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		return false, m.createAPIKey(ctx, apiKey, applicationID, keptAccessKey)
+	}
+
+	// the previous API key is deleted once the rotation grace period is over
+	if apiKey.Spec.PreviousAccessKey != "" && !time.Now().Before(getPreviousKeyExpiration(apiKey, apiKeyResp.CreatedAt)) {
+		err = m.deleteAPIKey(ctx, apiKey.Spec.PreviousAccessKey)
+		if err != nil {
+			return false, err
+		}
+
+		apiKey.Spec.PreviousAccessKey = ""
+		return false, m.Client.Update(ctx, apiKey)
+	}
+
+	needsUpdate := false
+	req := &iam.UpdateAPIKeyRequest{
+		AccessKey: apiKeyResp.AccessKey,
+	}
+
+	if apiKeyResp.Description != apiKey.Spec.Description {
+		req.Description = scw.StringPtr(apiKey.Spec.Description)
+		needsUpdate = true
+	}
+
+	if apiKey.Spec.DefaultProjectID != "" && apiKeyResp.DefaultProjectID != apiKey.Spec.DefaultProjectID {
+		req.DefaultProjectID = scw.StringPtr(apiKey.Spec.DefaultProjectID)
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		apiKeyResp, err = m.API.UpdateAPIKey(req, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+
+		err = m.ensureSecret(ctx, apiKey, map[string][]byte{
+			APIKeySecretDefaultProjectIDKey: []byte(apiKeyResp.DefaultProjectID),
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	apiKey.Status.ApplicationID = applicationID
+	apiKey.Status.SecretName = apiKey.Name + APIKeySecretSuffix
+	if apiKeyResp.CreatedAt != nil {
+		lastRotationTime := metav1.NewTime(*apiKeyResp.CreatedAt)
+		apiKey.Status.LastRotationTime = &lastRotationTime
+	}
+
+	return true, nil
+}
+
+// Delete deletes the IAM API key resource, revoking the key
+func (m *APIKeyManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	apiKey, err := convertAPIKey(obj)
+	if err != nil {
+		return false, err
+	}
+
+	for _, accessKey := range []string{apiKey.Spec.PreviousAccessKey, apiKey.Spec.AccessKey} {
+		if accessKey == "" {
+			continue
+		}
+		err = m.deleteAPIKey(ctx, accessKey)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the IAM API key resource
+func (m *APIKeyManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	apiKey, err := convertAPIKey(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return getApplicationOwners(apiKey.Namespace, apiKey.Spec.ApplicationRef), nil
+}
+
+// GetRefreshInterval returns the duration until the next scheduled rotation of the API key,
+// or until the end of the rotation grace period if it comes first
+func (m *APIKeyManager) GetRefreshInterval(obj runtime.Object) time.Duration {
+	apiKey, err := convertAPIKey(obj)
+	if err != nil {
+		return 0
+	}
+
+	var lastRotationTime *time.Time
+	if apiKey.Status.LastRotationTime != nil {
+		lastRotationTime = &apiKey.Status.LastRotationTime.Time
+	}
+
+	var remaining time.Duration
+	if apiKey.Spec.RotationInterval != nil {
+		remaining = apiKey.Spec.RotationInterval.Duration
+		if lastRotationTime != nil {
+			remaining = time.Until(lastRotationTime.Add(apiKey.Spec.RotationInterval.Duration))
+		}
+	}
+
+	if apiKey.Spec.PreviousAccessKey != "" {
+		untilExpiration := time.Until(getPreviousKeyExpiration(apiKey, lastRotationTime))
+		if remaining == 0 || untilExpiration < remaining {
+			remaining = untilExpiration
+		}
+	}
+
+	if apiKey.Spec.RotationInterval == nil && apiKey.Spec.PreviousAccessKey == "" {
+		return 0
+	}
+
+	if remaining < time.Second {
+		return time.Second
+	}
+
+	return remaining
+}
+
+// createAPIKey creates a new API key, saves its access key along with the given previous
+// access key, and writes it in the API key secret
+func (m *APIKeyManager) createAPIKey(ctx context.Context, apiKey *iamv1alpha1.IAMAPIKey, applicationID string, previousAccessKey string) error {
+	req := &iam.CreateAPIKeyRequest{
+		ApplicationID: scw.StringPtr(applicationID),
+		Description:   apiKey.Spec.Description,
+	}
+	if apiKey.Spec.DefaultProjectID != "" {
+		req.DefaultProjectID = scw.StringPtr(apiKey.Spec.DefaultProjectID)
+	}
+
+	apiKeyResp, err := m.API.CreateAPIKey(req, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	// the spec update overwrites the status, so it is kept aside
+	status := apiKey.Status.DeepCopy()
+
+	// the access key is saved before writing the secret, so the API key is never leaked
+	apiKey.Spec.AccessKey = apiKeyResp.AccessKey
+	apiKey.Spec.PreviousAccessKey = previousAccessKey
+	err = m.Client.Update(ctx, apiKey)
+	if err != nil {
+		deleteErr := m.deleteAPIKey(ctx, apiKeyResp.AccessKey)
+		if deleteErr != nil {
+			m.Log.Error(deleteErr, "failed to delete unsaved API key", "accessKey", apiKeyResp.AccessKey)
+		}
+		return err
+	}
+
+	apiKey.Status = *status
+
+	if apiKeyResp.SecretKey == nil {
+		return fmt.Errorf("no secret key returned for API key %s", apiKeyResp.AccessKey)
+	}
+
+	err = m.ensureSecret(ctx, apiKey, map[string][]byte{
+		APIKeySecretAccessKeyKey:        []byte(apiKeyResp.AccessKey),
+		APIKeySecretSecretKeyKey:        []byte(*apiKeyResp.SecretKey),
+		APIKeySecretDefaultProjectIDKey: []byte(apiKeyResp.DefaultProjectID),
+	})
+	if err != nil {
+		return err
+	}
+
+	apiKey.Status.ApplicationID = applicationID
+	apiKey.Status.SecretName = apiKey.Name + APIKeySecretSuffix
+	apiKey.Status.LastRotationRequest = apiKey.Annotations[RotateAnnotation]
+	if apiKeyResp.CreatedAt != nil {
+		lastRotationTime := metav1.NewTime(*apiKeyResp.CreatedAt)
+		apiKey.Status.LastRotationTime = &lastRotationTime
+	}
+
+	return nil
+}
+
+// deleteAPIKey deletes the given API key, ignoring already deleted ones
+func (m *APIKeyManager) deleteAPIKey(ctx context.Context, accessKey string) error {
+	err := m.API.DeleteAPIKey(&iam.DeleteAPIKeyRequest{
+		AccessKey: accessKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// ensureSecret creates or updates the API key secret owned by the given API key
+// The given data is merged with the existing one, since the secret key is only returned on creation
+func (m *APIKeyManager) ensureSecret(ctx context.Context, apiKey *iamv1alpha1.IAMAPIKey, data map[string][]byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiKey.Name + APIKeySecretSuffix,
+			Namespace: apiKey.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, m.Client, secret, func() error {
+		// refuse to overwrite a secret the operator did not create
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, apiKey) {
+			return fmt.Errorf("secret %s already exists and is not controlled by this IAMAPIKey", secret.Name)
+		}
+		secret.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(apiKey, iamv1alpha1.GroupVersion.WithKind("IAMAPIKey")),
+		}
+		secret.Type = corev1.SecretTypeOpaque
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for key, value := range data {
+			secret.Data[key] = value
+		}
+		return nil
+	})
+
+	return err
+}
+
+// needsRotation returns whether the API key needs to be rotated
+func needsRotation(apiKey *iamv1alpha1.IAMAPIKey, apiKeyResp *iam.APIKey, secret *corev1.Secret, now time.Time) bool {
+	if secret == nil ||
+		len(secret.Data[APIKeySecretSecretKeyKey]) == 0 ||
+		string(secret.Data[APIKeySecretAccessKeyKey]) != apiKeyResp.AccessKey {
+		return true
+	}
+
+	if apiKey.Annotations[RotateAnnotation] != apiKey.Status.LastRotationRequest {
+		return true
+	}
+
+	if apiKey.Spec.RotationInterval != nil && apiKeyResp.CreatedAt != nil &&
+		!now.Before(apiKeyResp.CreatedAt.Add(apiKey.Spec.RotationInterval.Duration)) {
+		return true
+	}
+
+	return false
+}
+
+// getRotatedAccessKeys returns, on rotation, the access key to keep during the grace period
+// and the access keys to delete right away
+// The key held by the secret is kept, since it is the one workloads may still be using
+func getRotatedAccessKeys(apiKey *iamv1alpha1.IAMAPIKey, exists bool, secret *corev1.Secret) (string, []string) {
+	keptAccessKey := ""
+	if exists {
+		keptAccessKey = apiKey.Spec.AccessKey
+	}
+
+	if apiKey.Spec.PreviousAccessKey == "" {
+		return keptAccessKey, nil
+	}
+
+	// the current API key was never written in the secret, so the previous one is still in use
+	if secret != nil && string(secret.Data[APIKeySecretAccessKeyKey]) == apiKey.Spec.PreviousAccessKey {
+		if exists {
+			return apiKey.Spec.PreviousAccessKey, []string{apiKey.Spec.AccessKey}
+		}
+		return apiKey.Spec.PreviousAccessKey, nil
+	}
+
+	return keptAccessKey, []string{apiKey.Spec.PreviousAccessKey}
+}
+
+// getPreviousKeyExpiration returns the end of the grace period of the previous API key,
+// given the creation time of the current one
+func getPreviousKeyExpiration(apiKey *iamv1alpha1.IAMAPIKey, createdAt *time.Time) time.Time {
+	if createdAt == nil {
+		return time.Time{}
+	}
+
+	gracePeriod := defaultRotationGracePeriod
+	if apiKey.Spec.RotationGracePeriod != nil {
+		gracePeriod = apiKey.Spec.RotationGracePeriod.Duration
+	}
+
+	return createdAt.Add(gracePeriod)
+}
+
+func convertAPIKey(obj runtime.Object) (*iamv1alpha1.IAMAPIKey, error) {
+	apiKey, ok := obj.(*iamv1alpha1.IAMAPIKey)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,169 @@
+package iam
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	iamv1alpha1 "github.com/scaleway/scaleway-operator/apis/iam/v1alpha1"
+)
+
+func Test_getRotatedAccessKeys(t *testing.T) {
+	cases := []struct {
+		accessKey         string
+		previousAccessKey string
+		exists            bool
+		secretAccessKey   string
+		kept              string
+		deleted           []string
+	}{
+		{
+			accessKey:       "current",
+			exists:          true,
+			secretAccessKey: "current",
+			kept:            "current",
+		},
+		{
+			accessKey:       "current",
+			exists:          false,
+			secretAccessKey: "current",
+			kept:            "",
+		},
+		{
+			accessKey:         "current",
+			previousAccessKey: "previous",
+			exists:            true,
+			secretAccessKey:   "current",
+			kept:              "current",
+			deleted:           []string{"previous"},
+		},
+		{
+			accessKey:         "current",
+			previousAccessKey: "previous",
+			exists:            true,
+			secretAccessKey:   "previous",
+			kept:              "previous",
+			deleted:           []string{"current"},
+		},
+		{
+			accessKey:         "current",
+			previousAccessKey: "previous",
+			exists:            false,
+			secretAccessKey:   "previous",
+			kept:              "previous",
+		},
+	}
+
+	for _, c := range cases {
+		apiKey := &iamv1alpha1.IAMAPIKey{
+			Spec: iamv1alpha1.IAMAPIKeySpec{
+				AccessKey:         c.accessKey,
+				PreviousAccessKey: c.previousAccessKey,
+			},
+		}
+		secret := &corev1.Secret{
+			Data: map[string][]byte{
+				APIKeySecretAccessKeyKey: []byte(c.secretAccessKey),
+			},
+		}
+		kept, deleted := getRotatedAccessKeys(apiKey, c.exists, secret)
+		if kept != c.kept {
+			t.Errorf("Got kept access key %q instead of %q", kept, c.kept)
+		}
+		if !reflect.DeepEqual(deleted, c.deleted) {
+			t.Errorf("Got deleted access keys %v instead of %v", deleted, c.deleted)
+		}
+	}
+}
+
+func Test_getPreviousKeyExpiration(t *testing.T) {
+	createdAt := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		createdAt   *time.Time
+		gracePeriod *metav1.Duration
+		expiration  time.Time
+	}{
+		{
+			createdAt:  nil,
+			expiration: time.Time{},
+		},
+		{
+			createdAt:  &createdAt,
+			expiration: createdAt.Add(time.Hour),
+		},
+		{
+			createdAt:   &createdAt,
+			gracePeriod: &metav1.Duration{Duration: 24 * time.Hour},
+			expiration:  createdAt.Add(24 * time.Hour),
+		},
+		{
+			createdAt:   &createdAt,
+			gracePeriod: &metav1.Duration{},
+			expiration:  createdAt,
+		},
+	}
+
+	for _, c := range cases {
+		apiKey := &iamv1alpha1.IAMAPIKey{
+			Spec: iamv1alpha1.IAMAPIKeySpec{
+				RotationGracePeriod: c.gracePeriod,
+			},
+		}
+		if expiration := getPreviousKeyExpiration(apiKey, c.createdAt); !expiration.Equal(c.expiration) {
+			t.Errorf("Got expiration %s instead of %s", expiration, c.expiration)
+		}
+	}
+}
+
+func Test_ensureSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = iamv1alpha1.AddToScheme(scheme)
+
+	owned := &iamv1alpha1.IAMAPIKey{
+		ObjectMeta: metav1.ObjectMeta{Name: "owned", Namespace: "default", UID: "owned-uid"},
+	}
+	unowned := &iamv1alpha1.IAMAPIKey{
+		ObjectMeta: metav1.ObjectMeta{Name: "unowned", Namespace: "default", UID: "unowned-uid"},
+	}
+
+	m := &APIKeyManager{
+		Client: fake.NewFakeClientWithScheme(scheme, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "unowned" + APIKeySecretSuffix, Namespace: "default", ResourceVersion: "1"},
+		}),
+	}
+
+	cases := []struct {
+		apiKey *iamv1alpha1.IAMAPIKey
+		err    bool
+	}{
+		{
+			apiKey: owned,
+			err:    false,
+		},
+		{
+			// the secret created on the previous case is updated
+			apiKey: owned,
+			err:    false,
+		},
+		{
+			apiKey: unowned,
+			err:    true,
+		},
+	}
+
+	for i, c := range cases {
+		err := m.ensureSecret(context.Background(), c.apiKey, map[string][]byte{APIKeySecretAccessKeyKey: []byte("SCWXXX")})
+		if (err != nil) != c.err {
+			t.Errorf("case %d: got error %v", i, err)
+		}
+	}
+}
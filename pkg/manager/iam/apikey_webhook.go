@@ -0,0 +1,90 @@
+package iam
+
+import (
+	"context"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	minRotationInterval = time.Hour
+)
+
+// ValidateCreate validates the creation of an IAM API Key
+func (m *APIKeyManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	apiKey, err := convertAPIKey(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	applicationRefPath := field.NewPath("spec").Child("applicationRef")
+	allErrs = append(allErrs, validateApplicationRef(applicationRefPath, apiKey.Spec.ApplicationRef)...)
+	if len(allErrs) > 0 {
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+	allErrs = append(allErrs, checkExternalApplication(m.API, applicationRefPath, apiKey.Spec.ApplicationRef)...)
+
+	if apiKey.Spec.RotationInterval != nil && apiKey.Spec.RotationInterval.Duration < minRotationInterval {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("rotationInterval"), apiKey.Spec.RotationInterval.Duration.String(), "rotationInterval must be at least "+minRotationInterval.String()))
+	}
+
+	if apiKey.Spec.PreviousAccessKey != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("previousAccessKey"), "field is managed by the operator"))
+	}
+
+	if apiKey.Spec.AccessKey != "" {
+		_, err = m.API.GetAPIKey(&iam.GetAPIKeyRequest{
+			AccessKey: apiKey.Spec.AccessKey,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("accessKey"), apiKey.Spec.AccessKey, err.Error()))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of an IAM API Key
+func (m *APIKeyManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	apiKey, err := convertAPIKey(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldAPIKey, err := convertAPIKey(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldAPIKey.Spec.AccessKey != "" && oldAPIKey.Spec.AccessKey != apiKey.Spec.AccessKey {
+		// the access key is updated by the operator when the API key is rotated
+		if apiKey.Spec.AccessKey == "" {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("accessKey"), "field is immutable"))
+		}
+	}
+
+	// the previous access key can only be set to the replaced access key, or cleared
+	if apiKey.Spec.PreviousAccessKey != "" &&
+		apiKey.Spec.PreviousAccessKey != oldAPIKey.Spec.PreviousAccessKey &&
+		apiKey.Spec.PreviousAccessKey != oldAPIKey.Spec.AccessKey {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("previousAccessKey"), "field is managed by the operator"))
+	}
+
+	if oldAPIKey.Spec.ApplicationRef != apiKey.Spec.ApplicationRef {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("applicationRef"), "field is immutable"))
+	}
+
+	if apiKey.Spec.RotationInterval != nil && apiKey.Spec.RotationInterval.Duration < minRotationInterval {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("rotationInterval"), apiKey.Spec.RotationInterval.Duration.String(), "rotationInterval must be at least "+minRotationInterval.String()))
+	}
+
+	return allErrs, nil
+}
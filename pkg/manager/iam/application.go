@@ -0,0 +1,174 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iamv1alpha1 "github.com/scaleway/scaleway-operator/apis/iam/v1alpha1"
+)
+
+// ApplicationManager manages the IAM applications
+type ApplicationManager struct {
+	client.Client
+	API *iam.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the IAM application resource
+func (m *ApplicationManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	application, err := convertApplication(obj)
+	if err != nil {
+		return false, err
+	}
+
+	// if applicationID is empty, we need to create the application
+	if application.Spec.ApplicationID == "" {
+		applicationResp, err := m.API.CreateApplication(&iam.CreateApplicationRequest{
+			Name:        getName(application),
+			Description: application.Spec.Description,
+			Tags:        application.Spec.Tags,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+
+		application.Spec.ApplicationID = applicationResp.ID
+
+		return false, m.Client.Update(ctx, application)
+	}
+
+	applicationResp, err := m.API.GetApplication(&iam.GetApplicationRequest{
+		ApplicationID: application.Spec.ApplicationID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	needsUpdate := false
+	req := &iam.UpdateApplicationRequest{
+		ApplicationID: applicationResp.ID,
+	}
+
+	if applicationResp.Description != application.Spec.Description {
+		req.Description = scw.StringPtr(application.Spec.Description)
+		needsUpdate = true
+	}
+
+	if !utils.CompareTags(applicationResp.Tags, application.Spec.Tags) {
+		tags := application.Spec.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		req.Tags = &tags
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		_, err = m.API.UpdateApplication(req, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	application.Status.OrganizationID = applicationResp.OrganizationID
+
+	return true, nil
+}
+
+// Delete deletes the IAM application resource
+func (m *ApplicationManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	application, err := convertApplication(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := application.Spec.ApplicationID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	// deleting the application also deletes its API keys and policies
+	err = m.API.DeleteApplication(&iam.DeleteApplicationRequest{
+		ApplicationID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the IAM application resource
+func (m *ApplicationManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+// getName returns the name of the IAM resource created for the given object
+// IAM resources are scoped to the organization, so the namespace is part of the name
+func getName(obj metav1.Object) string {
+	return obj.GetNamespace() + "-" + obj.GetName()
+}
+
+// getApplicationID returns the ID of the referenced application
+// The ID is empty when the application is not created yet
+func getApplicationID(ctx context.Context, c client.Client, objNamespace string, ref iamv1alpha1.IAMApplicationRef) (string, error) {
+	if ref.Name == "" {
+		return ref.ExternalID, nil
+	}
+
+	applicationNamespace := ref.Namespace
+	if applicationNamespace == "" {
+		applicationNamespace = objNamespace
+	}
+
+	application := &iamv1alpha1.IAMApplication{}
+	err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: applicationNamespace}, application)
+	if err != nil {
+		return "", err
+	}
+
+	return application.Spec.ApplicationID, nil
+}
+
+func getApplicationOwners(objNamespace string, ref iamv1alpha1.IAMApplicationRef) []scaleway.Owner {
+	if ref.Name == "" {
+		return nil
+	}
+
+	applicationNamespace := ref.Namespace
+	if applicationNamespace == "" {
+		applicationNamespace = objNamespace
+	}
+
+	return []scaleway.Owner{
+		{
+			Key: types.NamespacedName{
+				Name:      ref.Name,
+				Namespace: applicationNamespace,
+			},
+			Object: &iamv1alpha1.IAMApplication{},
+		},
+	}
+}
+
+func convertApplication(obj runtime.Object) (*iamv1alpha1.IAMApplication, error) {
+	application, ok := obj.(*iamv1alpha1.IAMApplication)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return application, nil
+}
@@ -0,0 +1,87 @@
+package iam
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	iamv1alpha1 "github.com/scaleway/scaleway-operator/apis/iam/v1alpha1"
+)
+
+// ValidateCreate validates the creation of an IAM Application
+func (m *ApplicationManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	application, err := convertApplication(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Spec.ApplicationID != "" {
+		_, err = m.API.GetApplication(&iam.GetApplicationRequest{
+			ApplicationID: application.Spec.ApplicationID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("applicationID"), application.Spec.ApplicationID, err.Error()))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of an IAM Application
+func (m *ApplicationManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	application, err := convertApplication(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldApplication, err := convertApplication(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldApplication.Spec.ApplicationID != "" && oldApplication.Spec.ApplicationID != application.Spec.ApplicationID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("applicationID"), "field is immutable"))
+	}
+
+	return allErrs, nil
+}
+
+func validateApplicationRef(path *field.Path, ref iamv1alpha1.IAMApplicationRef) field.ErrorList {
+	var allErrs field.ErrorList
+
+	byName := ref.Name != "" || ref.Namespace != ""
+
+	if ref.Name == "" && ref.ExternalID == "" {
+		allErrs = append(allErrs, field.Required(path, "name/namespace or externalID must be specified"))
+		return allErrs
+	}
+	if byName && ref.ExternalID != "" {
+		allErrs = append(allErrs, field.Forbidden(path, "only one of name/namespace and externalID must be specified"))
+	}
+
+	return allErrs
+}
+
+func checkExternalApplication(api *iam.API, path *field.Path, ref iamv1alpha1.IAMApplicationRef) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ref.ExternalID == "" {
+		return allErrs
+	}
+
+	_, err := api.GetApplication(&iam.GetApplicationRequest{
+		ApplicationID: ref.ExternalID,
+	})
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("externalID"), ref.ExternalID, err.Error()))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,260 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iamv1alpha1 "github.com/scaleway/scaleway-operator/apis/iam/v1alpha1"
+)
+
+// PolicyManager manages the IAM policies
+type PolicyManager struct {
+	client.Client
+	API *iam.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the IAM policy resource
+func (m *PolicyManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	policy, err := convertPolicy(obj)
+	if err != nil {
+		return false, err
+	}
+
+	applicationID := ""
+	if policy.Spec.ApplicationRef != nil {
+		applicationID, err = getApplicationID(ctx, m.Client, policy.Namespace, *policy.Spec.ApplicationRef)
+		if err != nil {
+			return false, err
+		}
+		if applicationID == "" {
+			// the application is not created yet
+			return false, nil
+		}
+	}
+
+	// if policyID is empty, we need to create the policy
+	// rules are set afterwards, once the organization of the policy is known
+	if policy.Spec.PolicyID == "" {
+		req := &iam.CreatePolicyRequest{
+			Name:        getName(policy),
+			Description: policy.Spec.Description,
+			Tags:        policy.Spec.Tags,
+		}
+		if applicationID != "" {
+			req.ApplicationID = scw.StringPtr(applicationID)
+		} else {
+			req.NoPrincipal = scw.BoolPtr(true)
+		}
+
+		policyResp, err := m.API.CreatePolicy(req, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+
+		policy.Spec.PolicyID = policyResp.ID
+
+		return false, m.Client.Update(ctx, policy)
+	}
+
+	policyResp, err := m.API.GetPolicy(&iam.GetPolicyRequest{
+		PolicyID: policy.Spec.PolicyID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	err = m.updatePolicy(ctx, policy, policyResp, applicationID)
+	if err != nil {
+		return false, err
+	}
+
+	rulesResp, err := m.API.ListRules(&iam.ListRulesRequest{
+		PolicyID: policyResp.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	wantedRules := getRuleSpecs(policy.Spec.Rules, policyResp.OrganizationID)
+	if checkRulesUpdate(rulesResp.Rules, wantedRules) {
+		_, err = m.API.SetRules(&iam.SetRulesRequest{
+			PolicyID: policyResp.ID,
+			Rules:    wantedRules,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	policy.Status.ApplicationID = applicationID
+
+	return true, nil
+}
+
+// Delete deletes the IAM policy resource
+func (m *PolicyManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	policy, err := convertPolicy(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := policy.Spec.PolicyID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	err = m.API.DeletePolicy(&iam.DeletePolicyRequest{
+		PolicyID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the IAM policy resource
+func (m *PolicyManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	policy, err := convertPolicy(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.Spec.ApplicationRef == nil {
+		return nil, nil
+	}
+
+	return getApplicationOwners(policy.Namespace, *policy.Spec.ApplicationRef), nil
+}
+
+func (m *PolicyManager) updatePolicy(ctx context.Context, policy *iamv1alpha1.IAMPolicy, policyResp *iam.Policy, applicationID string) error {
+	needsUpdate := false
+	req := &iam.UpdatePolicyRequest{
+		PolicyID: policyResp.ID,
+	}
+
+	if policyResp.Description != policy.Spec.Description {
+		req.Description = scw.StringPtr(policy.Spec.Description)
+		needsUpdate = true
+	}
+
+	if !utils.CompareTags(policyResp.Tags, policy.Spec.Tags) {
+		tags := policy.Spec.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		req.Tags = &tags
+		needsUpdate = true
+	}
+
+	if applicationID != "" {
+		if policyResp.ApplicationID == nil || *policyResp.ApplicationID != applicationID {
+			req.ApplicationID = scw.StringPtr(applicationID)
+			needsUpdate = true
+		}
+	} else if policyResp.NoPrincipal == nil || !*policyResp.NoPrincipal {
+		req.NoPrincipal = scw.BoolPtr(true)
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		return nil
+	}
+
+	_, err := m.API.UpdatePolicy(req, scw.WithContext(ctx))
+	return err
+}
+
+// getRuleSpecs returns the rules to set on the policy
+func getRuleSpecs(rules []iamv1alpha1.IAMPolicyRule, organizationID string) []*iam.RuleSpecs {
+	ruleSpecs := make([]*iam.RuleSpecs, 0, len(rules))
+
+	for _, rule := range rules {
+		permissionSetNames := append([]string{}, rule.PermissionSetNames...)
+		ruleSpec := &iam.RuleSpecs{
+			PermissionSetNames: &permissionSetNames,
+			Condition:          rule.Condition,
+		}
+		if rule.OrganizationScope {
+			ruleSpec.OrganizationID = scw.StringPtr(organizationID)
+		} else {
+			projectIDs := append([]string{}, rule.ProjectIDs...)
+			ruleSpec.ProjectIDs = &projectIDs
+		}
+		ruleSpecs = append(ruleSpecs, ruleSpec)
+	}
+
+	return ruleSpecs
+}
+
+// checkRulesUpdate returns whether the existing rules differ from the wanted ones
+// Rules are compared regardless of their order
+func checkRulesUpdate(existing []*iam.Rule, wanted []*iam.RuleSpecs) bool {
+	if len(existing) != len(wanted) {
+		return true
+	}
+
+	existingKeys := make([]string, 0, len(existing))
+	for _, rule := range existing {
+		existingKeys = append(existingKeys, getRuleKey(rule.PermissionSetNames, rule.ProjectIDs, rule.OrganizationID, rule.Condition))
+	}
+
+	wantedKeys := make([]string, 0, len(wanted))
+	for _, rule := range wanted {
+		wantedKeys = append(wantedKeys, getRuleKey(rule.PermissionSetNames, rule.ProjectIDs, rule.OrganizationID, rule.Condition))
+	}
+
+	sort.Strings(existingKeys)
+	sort.Strings(wantedKeys)
+
+	for i := range existingKeys {
+		if existingKeys[i] != wantedKeys[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getRuleKey(permissionSetNames *[]string, projectIDs *[]string, organizationID *string, condition string) string {
+	key := strings.Join(sortedStrings(permissionSetNames), ",") + "|" + strings.Join(sortedStrings(projectIDs), ",")
+	if organizationID != nil {
+		key += "|" + *organizationID
+	} else {
+		key += "|"
+	}
+	return key + "|" + strconv.Quote(condition)
+}
+
+func sortedStrings(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	sorted := append([]string{}, *values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func convertPolicy(obj runtime.Object) (*iamv1alpha1.IAMPolicy, error) {
+	policy, ok := obj.(*iamv1alpha1.IAMPolicy)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return policy, nil
+}
@@ -0,0 +1,122 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func Test_checkRulesUpdate(t *testing.T) {
+	cases := []struct {
+		existing []*iam.Rule
+		wanted   []*iam.RuleSpecs
+		update   bool
+	}{
+		{
+			existing: []*iam.Rule{},
+			wanted:   []*iam.RuleSpecs{},
+			update:   false,
+		},
+		{
+			existing: []*iam.Rule{
+				{
+					PermissionSetNames: &[]string{"InstancesReadOnly", "ObjectStorageFullAccess"},
+					ProjectIDs:         &[]string{"project-a", "project-b"},
+				},
+			},
+			wanted: []*iam.RuleSpecs{
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess", "InstancesReadOnly"},
+					ProjectIDs:         &[]string{"project-b", "project-a"},
+				},
+			},
+			update: false,
+		},
+		{
+			existing: []*iam.Rule{
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess"},
+					ProjectIDs:         &[]string{"project-a"},
+				},
+				{
+					PermissionSetNames: &[]string{"IAMReadOnly"},
+					OrganizationID:     scw.StringPtr("organization"),
+				},
+			},
+			wanted: []*iam.RuleSpecs{
+				{
+					PermissionSetNames: &[]string{"IAMReadOnly"},
+					OrganizationID:     scw.StringPtr("organization"),
+				},
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess"},
+					ProjectIDs:         &[]string{"project-a"},
+				},
+			},
+			update: false,
+		},
+		{
+			existing: []*iam.Rule{
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess"},
+					ProjectIDs:         &[]string{"project-a"},
+				},
+			},
+			wanted: []*iam.RuleSpecs{
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess"},
+					ProjectIDs:         &[]string{"project-a", "project-b"},
+				},
+			},
+			update: true,
+		},
+		{
+			existing: []*iam.Rule{
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess"},
+					ProjectIDs:         &[]string{"project-a"},
+				},
+			},
+			wanted: []*iam.RuleSpecs{
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess"},
+					OrganizationID:     scw.StringPtr("organization"),
+				},
+			},
+			update: true,
+		},
+		{
+			existing: []*iam.Rule{
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess"},
+					ProjectIDs:         &[]string{"project-a"},
+				},
+			},
+			wanted: []*iam.RuleSpecs{
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess"},
+					ProjectIDs:         &[]string{"project-a"},
+					Condition:          "request.ip in [\"1.2.3.4\"]",
+				},
+			},
+			update: true,
+		},
+		{
+			existing: []*iam.Rule{
+				{
+					PermissionSetNames: &[]string{"ObjectStorageFullAccess"},
+					ProjectIDs:         &[]string{"project-a"},
+				},
+			},
+			wanted: []*iam.RuleSpecs{},
+			update: true,
+		},
+	}
+
+	for i, c := range cases {
+		if update := checkRulesUpdate(c.existing, c.wanted); update != c.update {
+			t.Errorf("case %d: got %t instead of %t", i, update, c.update)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package iam
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	iamv1alpha1 "github.com/scaleway/scaleway-operator/apis/iam/v1alpha1"
+)
+
+// ValidateCreate validates the creation of an IAM Policy
+func (m *PolicyManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	policy, err := convertPolicy(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.Spec.ApplicationRef != nil {
+		applicationRefPath := field.NewPath("spec").Child("applicationRef")
+		allErrs = append(allErrs, validateApplicationRef(applicationRefPath, *policy.Spec.ApplicationRef)...)
+		if len(allErrs) > 0 {
+			return allErrs, nil // stop validation here since future calls will fail
+		}
+		allErrs = append(allErrs, checkExternalApplication(m.API, applicationRefPath, *policy.Spec.ApplicationRef)...)
+	}
+
+	rulesErrs, err := m.validateRules(ctx, policy.Spec.Rules)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, rulesErrs...)
+
+	if policy.Spec.PolicyID != "" {
+		_, err = m.API.GetPolicy(&iam.GetPolicyRequest{
+			PolicyID: policy.Spec.PolicyID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("policyID"), policy.Spec.PolicyID, err.Error()))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of an IAM Policy
+func (m *PolicyManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	policy, err := convertPolicy(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPolicy, err := convertPolicy(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldPolicy.Spec.PolicyID != "" && oldPolicy.Spec.PolicyID != policy.Spec.PolicyID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("policyID"), "field is immutable"))
+	}
+
+	if policy.Spec.ApplicationRef != nil {
+		applicationRefPath := field.NewPath("spec").Child("applicationRef")
+		allErrs = append(allErrs, validateApplicationRef(applicationRefPath, *policy.Spec.ApplicationRef)...)
+		if len(allErrs) > 0 {
+			return allErrs, nil // stop validation here since future calls will fail
+		}
+		allErrs = append(allErrs, checkExternalApplication(m.API, applicationRefPath, *policy.Spec.ApplicationRef)...)
+	}
+
+	rulesErrs, err := m.validateRules(ctx, policy.Spec.Rules)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, rulesErrs...)
+
+	return allErrs, nil
+}
+
+func (m *PolicyManager) validateRules(ctx context.Context, rules []iamv1alpha1.IAMPolicyRule) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	permissionSetsResp, err := m.API.ListPermissionSets(&iam.ListPermissionSetsRequest{}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	permissionSets := make(map[string]struct{}, len(permissionSetsResp.PermissionSets))
+	for _, permissionSet := range permissionSetsResp.PermissionSets {
+		permissionSets[permissionSet.Name] = struct{}{}
+	}
+
+	for i, rule := range rules {
+		rulePath := field.NewPath("spec").Child("rules").Index(i)
+
+		if rule.OrganizationScope && len(rule.ProjectIDs) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath, "only one of projectIDs and organizationScope must be specified"))
+		}
+		if !rule.OrganizationScope && len(rule.ProjectIDs) == 0 {
+			allErrs = append(allErrs, field.Required(rulePath, "projectIDs or organizationScope must be specified"))
+		}
+
+		for j, permissionSetName := range rule.PermissionSetNames {
+			if _, ok := permissionSets[permissionSetName]; !ok {
+				allErrs = append(allErrs, field.NotFound(rulePath.Child("permissionSetNames").Index(j), permissionSetName))
+			}
+		}
+	}
+
+	return allErrs, nil
+}
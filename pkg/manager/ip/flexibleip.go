@@ -0,0 +1,236 @@
+package ip
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ipv1alpha1 "github.com/scaleway/scaleway-operator/apis/ip/v1alpha1"
+)
+
+// FlexibleIPManager manages the flexible IPs
+type FlexibleIPManager struct {
+	client.Client
+	InstanceAPI *instance.API
+	LBAPI       *lb.ZonedAPI
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the flexible IP resource
+func (m *FlexibleIPManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	flexibleIP, err := convertFlexibleIP(obj)
+	if err != nil {
+		return false, err
+	}
+
+	if getType(flexibleIP) == ipv1alpha1.FlexibleIPTypeLoadBalancer {
+		return m.ensureLBIP(ctx, flexibleIP)
+	}
+
+	return m.ensureInstanceIP(ctx, flexibleIP)
+}
+
+// Delete deletes the flexible IP resource
+// The flexible IP is only released with the Delete reclaim policy
+func (m *FlexibleIPManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	flexibleIP, err := convertFlexibleIP(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := flexibleIP.Spec.IPID
+	if resourceID == "" || getReclaimPolicy(flexibleIP) != ipv1alpha1.FlexibleIPReclaimPolicyDelete {
+		return true, nil
+	}
+
+	if getType(flexibleIP) == ipv1alpha1.FlexibleIPTypeLoadBalancer {
+		err = m.LBAPI.ReleaseIP(&lb.ZonedAPIReleaseIPRequest{
+			Zone: scw.Zone(flexibleIP.Spec.Zone),
+			IPID: resourceID,
+		}, scw.WithContext(ctx))
+	} else {
+		err = m.InstanceAPI.DeleteIP(&instance.DeleteIPRequest{
+			Zone: scw.Zone(flexibleIP.Spec.Zone),
+			IP:   resourceID,
+		}, scw.WithContext(ctx))
+	}
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the flexible IP resource
+func (m *FlexibleIPManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+func (m *FlexibleIPManager) ensureInstanceIP(ctx context.Context, flexibleIP *ipv1alpha1.FlexibleIP) (bool, error) {
+	tags := getTags(flexibleIP.Spec.Tags, flexibleIP.Labels)
+
+	// if ipID is empty, we need to reserve the IP
+	// the reverse is set afterwards, since it can't be set on creation
+	if flexibleIP.Spec.IPID == "" {
+		ipResp, err := m.InstanceAPI.CreateIP(&instance.CreateIPRequest{
+			Zone: scw.Zone(flexibleIP.Spec.Zone),
+			Tags: tags,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+
+		return false, m.setIPID(ctx, flexibleIP, ipResp.IP.ID, ipResp.IP.Zone)
+	}
+
+	ipResp, err := m.InstanceAPI.GetIP(&instance.GetIPRequest{
+		Zone: scw.Zone(flexibleIP.Spec.Zone),
+		IP:   flexibleIP.Spec.IPID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	needsUpdate := false
+	req := &instance.UpdateIPRequest{
+		Zone: ipResp.IP.Zone,
+		IP:   ipResp.IP.ID,
+	}
+
+	if flexibleIP.Spec.Reverse != "" && (ipResp.IP.Reverse == nil || *ipResp.IP.Reverse != flexibleIP.Spec.Reverse) {
+		req.Reverse = &instance.NullableStringValue{Value: flexibleIP.Spec.Reverse}
+		needsUpdate = true
+	}
+
+	if !utils.CompareTags(ipResp.IP.Tags, tags) {
+		req.Tags = &tags
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		updateResp, err := m.InstanceAPI.UpdateIP(req, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		ipResp.IP = updateResp.IP
+	}
+
+	flexibleIP.Status.Address = ipResp.IP.Address.String()
+	flexibleIP.Status.Reverse = ""
+	if ipResp.IP.Reverse != nil {
+		flexibleIP.Status.Reverse = *ipResp.IP.Reverse
+	}
+	flexibleIP.Status.AttachedTo = ""
+	if ipResp.IP.Server != nil {
+		flexibleIP.Status.AttachedTo = ipResp.IP.Server.ID
+	}
+
+	return true, nil
+}
+
+func (m *FlexibleIPManager) ensureLBIP(ctx context.Context, flexibleIP *ipv1alpha1.FlexibleIP) (bool, error) {
+	tags := getTags(flexibleIP.Spec.Tags, flexibleIP.Labels)
+
+	// if ipID is empty, we need to reserve the IP
+	if flexibleIP.Spec.IPID == "" {
+		req := &lb.ZonedAPICreateIPRequest{
+			Zone: scw.Zone(flexibleIP.Spec.Zone),
+			Tags: tags,
+		}
+		if flexibleIP.Spec.Reverse != "" {
+			req.Reverse = scw.StringPtr(flexibleIP.Spec.Reverse)
+		}
+
+		ipResp, err := m.LBAPI.CreateIP(req, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+
+		return false, m.setIPID(ctx, flexibleIP, ipResp.ID, ipResp.Zone)
+	}
+
+	ipResp, err := m.LBAPI.GetIP(&lb.ZonedAPIGetIPRequest{
+		Zone: scw.Zone(flexibleIP.Spec.Zone),
+		IPID: flexibleIP.Spec.IPID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	needsUpdate := false
+	req := &lb.ZonedAPIUpdateIPRequest{
+		Zone: ipResp.Zone,
+		IPID: ipResp.ID,
+	}
+
+	if flexibleIP.Spec.Reverse != "" && ipResp.Reverse != flexibleIP.Spec.Reverse {
+		req.Reverse = scw.StringPtr(flexibleIP.Spec.Reverse)
+		needsUpdate = true
+	}
+
+	if !utils.CompareTags(ipResp.Tags, tags) {
+		req.Tags = &tags
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		ipResp, err = m.LBAPI.UpdateIP(req, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	flexibleIP.Status.Address = ipResp.IPAddress
+	flexibleIP.Status.Reverse = ipResp.Reverse
+	flexibleIP.Status.AttachedTo = ""
+	if ipResp.LBID != nil {
+		flexibleIP.Status.AttachedTo = *ipResp.LBID
+	}
+
+	return true, nil
+}
+
+func (m *FlexibleIPManager) setIPID(ctx context.Context, flexibleIP *ipv1alpha1.FlexibleIP, ipID string, zone scw.Zone) error {
+	flexibleIP.Spec.IPID = ipID
+	flexibleIP.Spec.Zone = zone.String()
+	return m.Client.Update(ctx, flexibleIP)
+}
+
+// getTags returns the tags of the flexible IP, the labels being added as key=value tags
+func getTags(specTags []string, labels map[string]string) []string {
+	return append(append([]string{}, specTags...), utils.LabelsToTags(labels)...)
+}
+
+func getType(flexibleIP *ipv1alpha1.FlexibleIP) ipv1alpha1.FlexibleIPType {
+	if flexibleIP.Spec.Type == "" {
+		return ipv1alpha1.FlexibleIPTypeInstance
+	}
+	return flexibleIP.Spec.Type
+}
+
+func getReclaimPolicy(flexibleIP *ipv1alpha1.FlexibleIP) ipv1alpha1.FlexibleIPReclaimPolicy {
+	if flexibleIP.Spec.ReclaimPolicy == "" {
+		return ipv1alpha1.FlexibleIPReclaimPolicyRetain
+	}
+	return flexibleIP.Spec.ReclaimPolicy
+}
+
+func convertFlexibleIP(obj runtime.Object) (*ipv1alpha1.FlexibleIP, error) {
+	flexibleIP, ok := obj.(*ipv1alpha1.FlexibleIP)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return flexibleIP, nil
+}
@@ -0,0 +1,40 @@
+package ip
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+)
+
+func Test_getTags(t *testing.T) {
+	cases := []struct {
+		specTags []string
+		labels   map[string]string
+		tags     []string
+	}{
+		{
+			specTags: nil,
+			labels:   nil,
+			tags:     []string{},
+		},
+		{
+			specTags: []string{"production"},
+			labels:   nil,
+			tags:     []string{"production"},
+		},
+		{
+			specTags: []string{"production"},
+			labels: map[string]string{
+				"team": "payments",
+				"app":  "api",
+			},
+			tags: []string{"production", "app=api", "team=payments"},
+		},
+	}
+
+	for i, c := range cases {
+		if tags := getTags(c.specTags, c.labels); !utils.CompareTags(tags, c.tags) {
+			t.Errorf("case %d: got %v instead of %v", i, tags, c.tags)
+		}
+	}
+}
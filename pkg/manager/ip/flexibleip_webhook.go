@@ -0,0 +1,97 @@
+package ip
+
+import (
+	"context"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ipv1alpha1 "github.com/scaleway/scaleway-operator/apis/ip/v1alpha1"
+)
+
+// ValidateCreate validates the creation of a Flexible IP
+func (m *FlexibleIPManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	flexibleIP, err := convertFlexibleIP(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scw.ParseZone(flexibleIP.Spec.Zone)
+	if flexibleIP.Spec.Zone != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("zone"), flexibleIP.Spec.Zone, "zone is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validateReverse(flexibleIP.Spec.Reverse)...)
+
+	if flexibleIP.Spec.IPID != "" {
+		if getType(flexibleIP) == ipv1alpha1.FlexibleIPTypeLoadBalancer {
+			_, err = m.LBAPI.GetIP(&lb.ZonedAPIGetIPRequest{
+				Zone: scw.Zone(flexibleIP.Spec.Zone),
+				IPID: flexibleIP.Spec.IPID,
+			}, scw.WithContext(ctx))
+		} else {
+			_, err = m.InstanceAPI.GetIP(&instance.GetIPRequest{
+				Zone: scw.Zone(flexibleIP.Spec.Zone),
+				IP:   flexibleIP.Spec.IPID,
+			}, scw.WithContext(ctx))
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("ipID"), flexibleIP.Spec.IPID, err.Error()))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Flexible IP
+func (m *FlexibleIPManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	flexibleIP, err := convertFlexibleIP(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldFlexibleIP, err := convertFlexibleIP(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldFlexibleIP.Spec.IPID != "" && oldFlexibleIP.Spec.IPID != flexibleIP.Spec.IPID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("ipID"), "field is immutable"))
+	}
+
+	if oldFlexibleIP.Spec.Zone != "" && oldFlexibleIP.Spec.Zone != flexibleIP.Spec.Zone {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("zone"), "field is immutable"))
+	}
+
+	if getType(oldFlexibleIP) != getType(flexibleIP) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("type"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateReverse(flexibleIP.Spec.Reverse)...)
+
+	return allErrs, nil
+}
+
+func validateReverse(reverse string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if reverse == "" {
+		return allErrs
+	}
+
+	if errs := validation.IsDNS1123Subdomain(strings.TrimSuffix(reverse, ".")); len(errs) > 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("reverse"), reverse, "reverse is not a valid hostname"))
+	}
+
+	return allErrs
+}
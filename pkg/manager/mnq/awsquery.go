@@ -0,0 +1,166 @@
+package mnq
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	sqsService = "sqs"
+	sqsVersion = "2012-11-05"
+
+	snsService = "sns"
+	snsVersion = "2010-03-31"
+
+	signatureAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat      = "20060102T150405Z"
+	amzShortDateFormat = "20060102"
+)
+
+// queryClient is a minimal client for the SQS and SNS compatible query APIs
+// of Messaging and Queuing, signing the requests with AWS signature V4
+type queryClient struct {
+	endpoint  string
+	region    string
+	service   string
+	version   string
+	accessKey string
+	secretKey string
+
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// queryError is an error returned by the query APIs
+type queryError struct {
+	StatusCode int
+	Code       string `xml:"Error>Code"`
+	Message    string `xml:"Error>Message"`
+}
+
+func (e *queryError) Error() string {
+	return fmt.Sprintf("%s: %s (status %d)", e.Code, e.Message, e.StatusCode)
+}
+
+func newQueryClient(endpoint, region, service, version, accessKey, secretKey string) *queryClient {
+	return &queryClient{
+		endpoint:   endpoint,
+		region:     region,
+		service:    service,
+		version:    version,
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		httpClient: http.DefaultClient,
+		now:        time.Now,
+	}
+}
+
+// do sends the given action and decodes the XML response into out
+func (c *queryClient) do(ctx context.Context, action string, params url.Values, out interface{}) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("Action", action)
+	params.Set("Version", c.version)
+	body := []byte(params.Encode())
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	c.sign(req, body)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		queryErr := &queryError{StatusCode: resp.StatusCode}
+		if xmlErr := xml.Unmarshal(respBody, queryErr); xmlErr != nil {
+			queryErr.Message = string(respBody)
+		}
+		return queryErr
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return xml.Unmarshal(respBody, out)
+}
+
+// sign adds the AWS signature V4 headers to the request
+func (c *queryClient) sign(req *http.Request, body []byte) {
+	now := c.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	shortDate := now.Format(amzShortDateFormat)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	host := req.URL.Host
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	signedHeaders := "content-type;host;x-amz-date"
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + host + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		hashHex(body),
+	}, "\n")
+
+	scope := strings.Join([]string{shortDate, c.region, c.service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		signatureAlgorithm,
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+c.secretKey), shortDate)
+	signingKey = hmacSHA256(signingKey, c.region)
+	signingKey = hmacSHA256(signingKey, c.service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signatureAlgorithm, c.accessKey, scope, signedHeaders, signature))
+}
+
+func hashHex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,79 @@
+package mnq
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test_sign checks the signature against the examples published by AWS
+// for the signature V4 signing process and its test suite
+func Test_sign(t *testing.T) {
+	const (
+		accessKey = "AKIDEXAMPLE"
+		secretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	)
+
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	cases := []struct {
+		name          string
+		method        string
+		url           string
+		contentType   string
+		body          string
+		service       string
+		authorization string
+	}{
+		{
+			// https://docs.aws.amazon.com/general/latest/gr/sigv4-create-canonical-request.html
+			name:          "iam-list-users",
+			method:        http.MethodGet,
+			url:           "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08",
+			contentType:   "application/x-www-form-urlencoded; charset=utf-8",
+			service:       "iam",
+			authorization: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		},
+		{
+			// aws-sig-v4-test-suite/post-x-www-form-urlencoded
+			name:          "post-x-www-form-urlencoded",
+			method:        http.MethodPost,
+			url:           "https://example.amazonaws.com/",
+			contentType:   "application/x-www-form-urlencoded",
+			body:          "Param1=value1",
+			service:       "service",
+			authorization: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+		{
+			// aws-sig-v4-test-suite/post-x-www-form-urlencoded-parameters
+			name:          "post-x-www-form-urlencoded-parameters",
+			method:        http.MethodPost,
+			url:           "https://example.amazonaws.com/",
+			contentType:   "application/x-www-form-urlencoded; charset=utf8",
+			body:          "Param1=value1",
+			service:       "service",
+			authorization: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=1a72ec8f64bd914b0e42e42607c7fbce7fb2c7465f63e3092b3b0d39fa77a6fe",
+		},
+	}
+
+	for _, c := range cases {
+		client := newQueryClient("", "us-east-1", c.service, "", accessKey, secretKey)
+		client.now = func() time.Time { return now }
+
+		req, err := http.NewRequest(c.method, c.url, strings.NewReader(c.body))
+		if err != nil {
+			t.Fatalf("%s: got error %v", c.name, err)
+		}
+		req.Header.Set("Content-Type", c.contentType)
+
+		client.sign(req, []byte(c.body))
+
+		if date := req.Header.Get("X-Amz-Date"); date != "20150830T123600Z" {
+			t.Errorf("%s: got date %s instead of 20150830T123600Z", c.name, date)
+		}
+		if authorization := req.Header.Get("Authorization"); authorization != c.authorization {
+			t.Errorf("%s: got authorization\n%s\ninstead of\n%s", c.name, authorization, c.authorization)
+		}
+	}
+}
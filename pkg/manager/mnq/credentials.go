@@ -0,0 +1,132 @@
+package mnq
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mnqv1alpha1 "github.com/scaleway/scaleway-operator/apis/mnq/v1alpha1"
+)
+
+const (
+	// CredentialsSecretEndpointKey is the credentials secret key holding the endpoint
+	CredentialsSecretEndpointKey = "endpoint"
+	// CredentialsSecretRegionKey is the credentials secret key holding the region
+	CredentialsSecretRegionKey = "region"
+	// CredentialsSecretAccessKeyKey is the credentials secret key holding the access key
+	CredentialsSecretAccessKeyKey = "accessKey"
+	// CredentialsSecretSecretKeyKey is the credentials secret key holding the secret key
+	CredentialsSecretSecretKeyKey = "secretKey"
+	// CredentialsSecretQueueURLKey is the credentials secret key holding the queue URL
+	CredentialsSecretQueueURLKey = "queueURL"
+	// CredentialsSecretTopicARNKey is the credentials secret key holding the topic ARN
+	CredentialsSecretTopicARNKey = "topicARN"
+	// CredentialsSecretNatsCredentialsKey is the credentials secret key holding the NATS credentials file
+	CredentialsSecretNatsCredentialsKey = "nats.creds"
+
+	fifoSuffix = ".fifo"
+
+	manageCredentialsSuffix = "-manage"
+)
+
+// getCredentialsName returns the name of the credentials generated for the given object
+func getCredentialsName(obj metav1.Object) string {
+	return obj.GetNamespace() + "-" + obj.GetName()
+}
+
+// getResourceName returns the name of the queue or topic, with the .fifo suffix for FIFO ones
+func getResourceName(objName string, specName string, fifo bool) string {
+	name := specName
+	if name == "" {
+		name = objName
+	}
+	if fifo && !strings.HasSuffix(name, fifoSuffix) {
+		name += fifoSuffix
+	}
+	return name
+}
+
+// getPermissions returns the wanted permissions, defaulting to publish and receive
+func getPermissions(permissions *mnqv1alpha1.MNQPermissions) mnqv1alpha1.MNQPermissions {
+	if permissions == nil {
+		return mnqv1alpha1.MNQPermissions{
+			CanPublish: true,
+			CanReceive: true,
+		}
+	}
+	return *permissions
+}
+
+// checkPermissionsUpdate returns whether the existing permissions differ from the wanted ones
+func checkPermissionsUpdate(canPublish *bool, canReceive *bool, canManage *bool, wanted mnqv1alpha1.MNQPermissions) bool {
+	return boolValue(canPublish) != wanted.CanPublish ||
+		boolValue(canReceive) != wanted.CanReceive ||
+		boolValue(canManage) != wanted.CanManage
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+// getCredentialsSecret returns the credentials secret, or nil if it does not exist
+func getCredentialsSecret(ctx context.Context, c client.Client, name string, namespace string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// hasKeys returns whether the secret holds all the given keys
+func hasKeys(secret *corev1.Secret, keys ...string) bool {
+	if secret == nil {
+		return false
+	}
+	for _, key := range keys {
+		if len(secret.Data[key]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ensureCredentialsSecret creates or updates the credentials secret owned by the given object
+// The given data is merged with the existing one, since secret keys are only returned on creation
+func ensureCredentialsSecret(ctx context.Context, c client.Client, owner metav1.Object, gvk schema.GroupVersionKind, name string, data map[string][]byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+		secret.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(owner, gvk),
+		}
+		secret.Type = corev1.SecretTypeOpaque
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for key, value := range data {
+			secret.Data[key] = value
+		}
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,45 @@
+package mnq
+
+import (
+	"testing"
+)
+
+func Test_getResourceName(t *testing.T) {
+	cases := []struct {
+		objName  string
+		specName string
+		fifo     bool
+		name     string
+	}{
+		{
+			objName:  "orders",
+			specName: "",
+			fifo:     false,
+			name:     "orders",
+		},
+		{
+			objName:  "orders",
+			specName: "my-orders",
+			fifo:     false,
+			name:     "my-orders",
+		},
+		{
+			objName:  "orders",
+			specName: "",
+			fifo:     true,
+			name:     "orders.fifo",
+		},
+		{
+			objName:  "orders",
+			specName: "my-orders.fifo",
+			fifo:     true,
+			name:     "my-orders.fifo",
+		},
+	}
+
+	for i, c := range cases {
+		if name := getResourceName(c.objName, c.specName, c.fifo); name != c.name {
+			t.Errorf("case %d: got %s instead of %s", i, name, c.name)
+		}
+	}
+}
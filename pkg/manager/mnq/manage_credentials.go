@@ -0,0 +1,165 @@
+package mnq
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// ManageCredentialsLabel is the label set on the secrets holding the credentials
+	// shared by the operator to manage the queues or topics of a namespace
+	ManageCredentialsLabel = "mnq.scaleway.com/manage-credentials"
+
+	manageCredentialsSecretIDKey = "credentialsID"
+)
+
+// manageCredentials are the credentials allowed to manage the queues or topics
+type manageCredentials struct {
+	ID        string
+	AccessKey string
+	SecretKey string
+}
+
+// manageCredentialsAPI abstracts the SQS and SNS credentials calls
+type manageCredentialsAPI struct {
+	// exists returns whether the credentials with the given ID still exist
+	exists func(id string) (bool, error)
+	// create creates new credentials allowed to manage the queues or topics
+	create func(name string) (*manageCredentials, error)
+	// delete deletes the credentials with the given ID, ignoring already deleted ones
+	delete func(id string) error
+}
+
+// getManageCredentialsSecretName returns the name of the secret holding the manage credentials
+// of the given service, shared by the resources of a namespace in a region of a project
+func getManageCredentialsSecretName(service string, region string, projectID string) string {
+	return "scaleway-" + service + "-manage-" + region + "-" + projectID
+}
+
+// getManageCredentialsName returns the name of the manage credentials of a namespace
+func getManageCredentialsName(namespace string) string {
+	return "scaleway-operator-" + namespace + manageCredentialsSuffix
+}
+
+// ensureManageCredentials returns the manage credentials kept in the given secret,
+// and creates them when the secret is lost or the credentials were deleted
+// The credentials are shared by the resources of the namespace, so they are only
+// created once instead of on each reconcile
+func ensureManageCredentials(ctx context.Context, c client.Client, api manageCredentialsAPI, name string, namespace string) (*manageCredentials, error) {
+	secret, err := getCredentialsSecret(ctx, c, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if secret != nil && secret.Labels[ManageCredentialsLabel] != "true" {
+		return nil, fmt.Errorf("secret %s/%s already exists and is not managed by the operator", namespace, name)
+	}
+
+	if hasKeys(secret, manageCredentialsSecretIDKey, CredentialsSecretAccessKeyKey, CredentialsSecretSecretKeyKey) {
+		credentials := &manageCredentials{
+			ID:        string(secret.Data[manageCredentialsSecretIDKey]),
+			AccessKey: string(secret.Data[CredentialsSecretAccessKeyKey]),
+			SecretKey: string(secret.Data[CredentialsSecretSecretKeyKey]),
+		}
+		exists, err := api.exists(credentials.ID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return credentials, nil
+		}
+	}
+
+	credentials, err := api.create(getManageCredentialsName(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	staleID := ""
+	if secret != nil {
+		staleID = string(secret.Data[manageCredentialsSecretIDKey])
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[ManageCredentialsLabel] = "true"
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{
+			manageCredentialsSecretIDKey:  []byte(credentials.ID),
+			CredentialsSecretAccessKeyKey: []byte(credentials.AccessKey),
+			CredentialsSecretSecretKeyKey: []byte(credentials.SecretKey),
+		}
+		return nil
+	})
+	if err != nil {
+		// the credentials are deleted so they are never leaked
+		deleteErr := api.delete(credentials.ID)
+		if deleteErr != nil {
+			return nil, fmt.Errorf("%v, and failed to delete unsaved manage credentials %s: %v", err, credentials.ID, deleteErr)
+		}
+		return nil, err
+	}
+
+	if staleID != "" && staleID != credentials.ID {
+		err = api.delete(staleID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return credentials, nil
+}
+
+// deleteManageCredentials deletes the manage credentials kept in the given secret, along with the secret
+func deleteManageCredentials(ctx context.Context, c client.Client, api manageCredentialsAPI, name string, namespace string) error {
+	secret, err := getCredentialsSecret(ctx, c, name, namespace)
+	if err != nil {
+		return err
+	}
+	if secret == nil || secret.Labels[ManageCredentialsLabel] != "true" {
+		return nil
+	}
+
+	if id := string(secret.Data[manageCredentialsSecretIDKey]); id != "" {
+		err = api.delete(id)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.Delete(ctx, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// isManageCredentialsInUse returns whether the manage credentials of the given region are still used
+// by other resources of the namespace, given the regions of all of them by UID
+func isManageCredentialsInUse(uid types.UID, region string, regions map[types.UID]string) bool {
+	for otherUID, otherRegion := range regions {
+		if otherUID == uid {
+			continue
+		}
+		// the resources without region get the default region of the API, so they may use the credentials
+		if otherRegion == "" || otherRegion == region {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package mnq
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeManageCredentialsAPI keeps the existing credentials in memory
+type fakeManageCredentialsAPI struct {
+	credentials map[string]bool
+	created     int
+}
+
+func (f *fakeManageCredentialsAPI) api() manageCredentialsAPI {
+	return manageCredentialsAPI{
+		exists: func(id string) (bool, error) {
+			return f.credentials[id], nil
+		},
+		create: func(name string) (*manageCredentials, error) {
+			f.created++
+			id := fmt.Sprintf("credentials-%d", f.created)
+			f.credentials[id] = true
+			return &manageCredentials{ID: id, AccessKey: "access-" + id, SecretKey: "secret-" + id}, nil
+		},
+		delete: func(id string) error {
+			delete(f.credentials, id)
+			return nil
+		},
+	}
+}
+
+func Test_ensureManageCredentials(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	c := fake.NewFakeClientWithScheme(scheme, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-secret", Namespace: "default"},
+	})
+	api := &fakeManageCredentialsAPI{credentials: map[string]bool{}}
+	ctx := context.Background()
+
+	_, err := ensureManageCredentials(ctx, c, api.api(), "user-secret", "default")
+	if err == nil {
+		t.Errorf("Got no error for a secret not managed by the operator")
+	}
+
+	first, err := ensureManageCredentials(ctx, c, api.api(), "manage", "default")
+	if err != nil {
+		t.Fatalf("Got error %s", err)
+	}
+	second, err := ensureManageCredentials(ctx, c, api.api(), "manage", "default")
+	if err != nil {
+		t.Fatalf("Got error %s", err)
+	}
+	if api.created != 1 || second.ID != first.ID || second.SecretKey != first.SecretKey {
+		t.Errorf("Got %d created credentials instead of 1 reused", api.created)
+	}
+
+	// the credentials deleted out of the operator are created again
+	delete(api.credentials, first.ID)
+	third, err := ensureManageCredentials(ctx, c, api.api(), "manage", "default")
+	if err != nil {
+		t.Fatalf("Got error %s", err)
+	}
+	if third.ID == first.ID || api.created != 2 {
+		t.Errorf("Got credentials %s instead of new ones", third.ID)
+	}
+
+	err = deleteManageCredentials(ctx, c, api.api(), "manage", "default")
+	if err != nil {
+		t.Fatalf("Got error %s", err)
+	}
+	if len(api.credentials) != 0 {
+		t.Errorf("Got %d remaining credentials instead of 0", len(api.credentials))
+	}
+	secret, err := getCredentialsSecret(ctx, c, "manage", "default")
+	if err != nil {
+		t.Fatalf("Got error %s", err)
+	}
+	if secret != nil {
+		t.Errorf("Got remaining manage credentials secret")
+	}
+}
+
+func Test_isManageCredentialsInUse(t *testing.T) {
+	cases := []struct {
+		regions map[types.UID]string
+		inUse   bool
+	}{
+		{
+			regions: map[types.UID]string{"self": "fr-par"},
+			inUse:   false,
+		},
+		{
+			regions: map[types.UID]string{"self": "fr-par", "other": "nl-ams"},
+			inUse:   false,
+		},
+		{
+			regions: map[types.UID]string{"self": "fr-par", "other": "fr-par"},
+			inUse:   true,
+		},
+		{
+			regions: map[types.UID]string{"self": "fr-par", "other": ""},
+			inUse:   true,
+		},
+	}
+
+	for i, c := range cases {
+		if inUse := isManageCredentialsInUse("self", "fr-par", c.regions); inUse != c.inUse {
+			t.Errorf("case %d: got %t instead of %t", i, inUse, c.inUse)
+		}
+	}
+}
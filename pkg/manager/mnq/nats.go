@@ -0,0 +1,202 @@
+package mnq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mnqv1alpha1 "github.com/scaleway/scaleway-operator/apis/mnq/v1alpha1"
+)
+
+const (
+	// NatsCredentialsSecretSuffix is the suffix of the NATS credentials secret name
+	NatsCredentialsSecretSuffix = "-nats-credentials"
+)
+
+// NatsAccountManager manages the NATS accounts
+type NatsAccountManager struct {
+	client.Client
+	API *mnq.NatsAPI
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the NATS account resource
+func (m *NatsAccountManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	account, err := convertNatsAccount(obj)
+	if err != nil {
+		return false, err
+	}
+
+	// if accountID is empty, we need to create the account
+	if account.Spec.AccountID == "" {
+		return false, m.createAccount(ctx, account)
+	}
+
+	natsAccountResp, err := m.API.GetNatsAccount(&mnq.NatsAPIGetNatsAccountRequest{
+		Region:        scw.Region(account.Spec.Region),
+		NatsAccountID: account.Spec.AccountID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	account.Status.Endpoint = natsAccountResp.Endpoint
+
+	err = m.ensureCredentials(ctx, account, natsAccountResp)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Delete deletes the NATS account resource
+func (m *NatsAccountManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	account, err := convertNatsAccount(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := account.Spec.AccountID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	// deleting the account also deletes its credentials
+	err = m.API.DeleteNatsAccount(&mnq.NatsAPIDeleteNatsAccountRequest{
+		Region:        scw.Region(account.Spec.Region),
+		NatsAccountID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the NATS account resource
+func (m *NatsAccountManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+func (m *NatsAccountManager) createAccount(ctx context.Context, account *mnqv1alpha1.MNQNatsAccount) error {
+	natsAccount, err := m.API.CreateNatsAccount(&mnq.NatsAPICreateNatsAccountRequest{
+		Region: scw.Region(account.Spec.Region),
+		Name:   getCredentialsName(account),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	account.Spec.AccountID = natsAccount.ID
+	account.Spec.Region = natsAccount.Region.String()
+
+	err = m.Client.Update(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ensureCredentials generates the NATS credentials and writes them in the credentials secret
+// The credentials file is only returned on creation, so they are generated again
+// whenever the secret is lost
+func (m *NatsAccountManager) ensureCredentials(ctx context.Context, account *mnqv1alpha1.MNQNatsAccount, natsAccount *mnq.NatsAccount) error {
+	secretName := account.Name + NatsCredentialsSecretSuffix
+
+	secret, err := getCredentialsSecret(ctx, m.Client, secretName, account.Namespace)
+	if err != nil {
+		return err
+	}
+
+	data := map[string][]byte{
+		CredentialsSecretEndpointKey: []byte(natsAccount.Endpoint),
+	}
+
+	needCredentials := account.Spec.CredentialsID == "" || !hasKeys(secret, CredentialsSecretNatsCredentialsKey)
+	if !needCredentials {
+		_, err := m.API.GetNatsCredentials(&mnq.NatsAPIGetNatsCredentialsRequest{
+			Region:            natsAccount.Region,
+			NatsCredentialsID: account.Spec.CredentialsID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+				return err
+			}
+			needCredentials = true
+		}
+	}
+
+	if needCredentials {
+		if account.Spec.CredentialsID != "" {
+			err := m.API.DeleteNatsCredentials(&mnq.NatsAPIDeleteNatsCredentialsRequest{
+				Region:            natsAccount.Region,
+				NatsCredentialsID: account.Spec.CredentialsID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+					return err
+				}
+			}
+		}
+
+		credentials, err := m.API.CreateNatsCredentials(&mnq.NatsAPICreateNatsCredentialsRequest{
+			Region:        natsAccount.Region,
+			NatsAccountID: natsAccount.ID,
+			Name:          getCredentialsName(account),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		// the credentials ID is saved before writing the secret, so the credentials are never leaked
+		// the spec update overwrites the status, so it is kept aside
+		status := account.Status.DeepCopy()
+		account.Spec.CredentialsID = credentials.ID
+		err = m.Client.Update(ctx, account)
+		if err != nil {
+			deleteErr := m.API.DeleteNatsCredentials(&mnq.NatsAPIDeleteNatsCredentialsRequest{
+				Region:            natsAccount.Region,
+				NatsCredentialsID: credentials.ID,
+			}, scw.WithContext(ctx))
+			if deleteErr != nil {
+				m.Log.Error(deleteErr, "failed to delete unsaved credentials", "credentialsID", credentials.ID)
+			}
+			return err
+		}
+		account.Status = *status
+
+		if credentials.Credentials == nil {
+			return fmt.Errorf("no credentials file returned for credentials %s", credentials.ID)
+		}
+		data[CredentialsSecretNatsCredentialsKey] = []byte(credentials.Credentials.Content)
+	}
+
+	err = ensureCredentialsSecret(ctx, m.Client, account, mnqv1alpha1.GroupVersion.WithKind("MNQNatsAccount"), secretName, data)
+	if err != nil {
+		return err
+	}
+
+	account.Status.CredentialsSecret = secretName
+
+	return nil
+}
+
+func convertNatsAccount(obj runtime.Object) (*mnqv1alpha1.MNQNatsAccount, error) {
+	account, ok := obj.(*mnqv1alpha1.MNQNatsAccount)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return account, nil
+}
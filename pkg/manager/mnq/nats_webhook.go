@@ -0,0 +1,63 @@
+package mnq
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateCreate validates the creation of a NATS account
+func (m *NatsAccountManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	account, err := convertNatsAccount(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scw.ParseRegion(account.Spec.Region)
+	if account.Spec.Region != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("region"), account.Spec.Region, "region is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	if account.Spec.AccountID != "" {
+		_, err = m.API.GetNatsAccount(&mnq.NatsAPIGetNatsAccountRequest{
+			Region:        scw.Region(account.Spec.Region),
+			NatsAccountID: account.Spec.AccountID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("accountID"), account.Spec.AccountID, err.Error()))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a NATS account
+func (m *NatsAccountManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	account, err := convertNatsAccount(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldAccount, err := convertNatsAccount(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldAccount.Spec.AccountID != "" && oldAccount.Spec.AccountID != account.Spec.AccountID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("accountID"), "field is immutable"))
+	}
+
+	if oldAccount.Spec.Region != "" && oldAccount.Spec.Region != account.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("region"), "field is immutable"))
+	}
+
+	return allErrs, nil
+}
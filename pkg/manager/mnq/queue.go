@@ -0,0 +1,492 @@
+package mnq
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mnqv1alpha1 "github.com/scaleway/scaleway-operator/apis/mnq/v1alpha1"
+)
+
+const (
+	// QueueCredentialsSecretSuffix is the suffix of the queue credentials secret name
+	QueueCredentialsSecretSuffix = "-sqs-credentials"
+
+	queueAttributeFIFO                      = "FifoQueue"
+	queueAttributeContentBasedDeduplication = "ContentBasedDeduplication"
+	queueAttributeVisibilityTimeout         = "VisibilityTimeout"
+	queueAttributeMessageRetentionPeriod    = "MessageRetentionPeriod"
+	queueAttributeMaximumMessageSize        = "MaximumMessageSize"
+)
+
+// QueueManager manages the SQS queues
+type QueueManager struct {
+	client.Client
+	API *mnq.SqsAPI
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the SQS queue resource
+func (m *QueueManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	queue, err := convertQueue(obj)
+	if err != nil {
+		return false, err
+	}
+
+	sqsInfo, err := m.API.GetSqsInfo(&mnq.SqsAPIGetSqsInfoRequest{
+		Region: scw.Region(queue.Spec.Region),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	// SQS is activated at the project level and is never deactivated by the operator
+	if sqsInfo.Status != mnq.SqsInfoStatusEnabled {
+		_, err = m.API.ActivateSqs(&mnq.SqsAPIActivateSqsRequest{
+			Region: sqsInfo.Region,
+		}, scw.WithContext(ctx))
+		return false, err
+	}
+
+	if queue.Spec.Region == "" {
+		queue.Spec.Region = sqsInfo.Region.String()
+		return false, m.Client.Update(ctx, queue)
+	}
+
+	queueName := getResourceName(queue.Name, queue.Spec.QueueName, queue.Spec.FIFO)
+	attributes := getQueueAttributes(queue)
+	attributesHash := utils.HashValues(attributes)
+
+	// the queue is checked on each reconcile, so it is created again if deleted
+	queueURL := queue.Spec.QueueURL
+	err = m.withManageCredentials(ctx, queue, sqsInfo, func(c *queryClient) error {
+		var err error
+		if queueURL != "" {
+			exists, err := queueExists(ctx, c, queueURL)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				queueURL = ""
+			}
+		}
+
+		if queueURL == "" {
+			queueURL, err = getQueueURL(ctx, c, queueName)
+			if err != nil {
+				return err
+			}
+		}
+
+		if queueURL == "" {
+			queueURL, err = createQueue(ctx, c, queueName, attributes)
+			if err != nil {
+				return err
+			}
+		} else if queue.Status.AttributesHash != attributesHash {
+			// the queue type can't be updated
+			delete(attributes, queueAttributeFIFO)
+			err = setQueueAttributes(ctx, c, queueURL, attributes)
+			if err != nil {
+				return err
+			}
+		}
+
+		queue.Status.AttributesHash = attributesHash
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if queueURL != queue.Spec.QueueURL {
+		// the spec update overwrites the status, so it is kept aside
+		status := queue.Status.DeepCopy()
+		queue.Spec.QueueURL = queueURL
+		err = m.Client.Update(ctx, queue)
+		if err != nil {
+			return false, err
+		}
+		queue.Status = *status
+	}
+
+	err = m.ensureCredentials(ctx, queue, sqsInfo)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Delete deletes the SQS queue resource
+func (m *QueueManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	queue, err := convertQueue(obj)
+	if err != nil {
+		return false, err
+	}
+
+	if queue.Spec.Region == "" {
+		return true, nil
+	}
+
+	sqsInfo, err := m.API.GetSqsInfo(&mnq.SqsAPIGetSqsInfoRequest{
+		Region: scw.Region(queue.Spec.Region),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	if sqsInfo.Status == mnq.SqsInfoStatusEnabled {
+		if queue.Spec.QueueURL != "" {
+			err = m.withManageCredentials(ctx, queue, sqsInfo, func(c *queryClient) error {
+				return deleteQueue(ctx, c, queue.Spec.QueueURL)
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+
+		err = m.deleteManageCredentials(ctx, queue, sqsInfo)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if queue.Spec.CredentialsID != "" {
+		err = m.API.DeleteSqsCredentials(&mnq.SqsAPIDeleteSqsCredentialsRequest{
+			Region:           sqsInfo.Region,
+			SqsCredentialsID: queue.Spec.CredentialsID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the SQS queue resource
+func (m *QueueManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+// withManageCredentials runs the given function with the credentials allowed to manage the queues,
+// so the generated credentials can stay restricted
+// The manage credentials are shared by the queues of the namespace and kept in a secret
+func (m *QueueManager) withManageCredentials(ctx context.Context, queue *mnqv1alpha1.MNQQueue, sqsInfo *mnq.SqsInfo, f func(c *queryClient) error) error {
+	credentials, err := ensureManageCredentials(ctx, m.Client, m.manageCredentialsAPI(ctx, sqsInfo.Region),
+		getManageCredentialsSecretName(sqsService, sqsInfo.Region.String(), sqsInfo.ProjectID), queue.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return f(newQueryClient(sqsInfo.SqsEndpointURL, sqsInfo.Region.String(), sqsService, sqsVersion, credentials.AccessKey, credentials.SecretKey))
+}
+
+// deleteManageCredentials deletes the manage credentials of the namespace
+// once no other queue of the namespace uses them
+func (m *QueueManager) deleteManageCredentials(ctx context.Context, queue *mnqv1alpha1.MNQQueue, sqsInfo *mnq.SqsInfo) error {
+	queues := &mnqv1alpha1.MNQQueueList{}
+	err := m.Client.List(ctx, queues, client.InNamespace(queue.Namespace))
+	if err != nil {
+		return err
+	}
+
+	regions := map[types.UID]string{}
+	for _, other := range queues.Items {
+		regions[other.UID] = other.Spec.Region
+	}
+	if isManageCredentialsInUse(queue.UID, queue.Spec.Region, regions) {
+		return nil
+	}
+
+	return deleteManageCredentials(ctx, m.Client, m.manageCredentialsAPI(ctx, sqsInfo.Region),
+		getManageCredentialsSecretName(sqsService, sqsInfo.Region.String(), sqsInfo.ProjectID), queue.Namespace)
+}
+
+// manageCredentialsAPI returns the Sqs calls used to handle the manage credentials in the given region
+func (m *QueueManager) manageCredentialsAPI(ctx context.Context, region scw.Region) manageCredentialsAPI {
+	return manageCredentialsAPI{
+		exists: func(id string) (bool, error) {
+			_, err := m.API.GetSqsCredentials(&mnq.SqsAPIGetSqsCredentialsRequest{
+				Region:           region,
+				SqsCredentialsID: id,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				if _, ok := err.(*scw.ResourceNotFoundError); ok {
+					return false, nil
+				}
+				return false, err
+			}
+			return true, nil
+		},
+		create: func(name string) (*manageCredentials, error) {
+			credentials, err := m.API.CreateSqsCredentials(&mnq.SqsAPICreateSqsCredentialsRequest{
+				Region: region,
+				Name:   name,
+				Permissions: &mnq.SqsPermissions{
+					CanPublish: scw.BoolPtr(false),
+					CanReceive: scw.BoolPtr(false),
+					CanManage:  scw.BoolPtr(true),
+				},
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return &manageCredentials{
+				ID:        credentials.ID,
+				AccessKey: credentials.AccessKey,
+				SecretKey: credentials.SecretKey,
+			}, nil
+		},
+		delete: func(id string) error {
+			err := m.API.DeleteSqsCredentials(&mnq.SqsAPIDeleteSqsCredentialsRequest{
+				Region:           region,
+				SqsCredentialsID: id,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// ensureCredentials generates the SQS credentials and writes them in the credentials secret
+// The secret key is only returned on creation, so they are generated again
+// whenever the secret is lost
+func (m *QueueManager) ensureCredentials(ctx context.Context, queue *mnqv1alpha1.MNQQueue, sqsInfo *mnq.SqsInfo) error {
+	secretName := queue.Name + QueueCredentialsSecretSuffix
+
+	secret, err := getCredentialsSecret(ctx, m.Client, secretName, queue.Namespace)
+	if err != nil {
+		return err
+	}
+
+	data := map[string][]byte{
+		CredentialsSecretEndpointKey: []byte(sqsInfo.SqsEndpointURL),
+		CredentialsSecretRegionKey:   []byte(sqsInfo.Region.String()),
+		CredentialsSecretQueueURLKey: []byte(queue.Spec.QueueURL),
+	}
+
+	permissions := getPermissions(queue.Spec.Permissions)
+
+	needCredentials := queue.Spec.CredentialsID == "" || !hasKeys(secret, CredentialsSecretAccessKeyKey, CredentialsSecretSecretKeyKey)
+	if !needCredentials {
+		credentials, err := m.API.GetSqsCredentials(&mnq.SqsAPIGetSqsCredentialsRequest{
+			Region:           sqsInfo.Region,
+			SqsCredentialsID: queue.Spec.CredentialsID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+				return err
+			}
+			needCredentials = true
+		} else if credentials.AccessKey != string(secret.Data[CredentialsSecretAccessKeyKey]) {
+			needCredentials = true
+		} else if credentials.Permissions == nil ||
+			checkPermissionsUpdate(credentials.Permissions.CanPublish, credentials.Permissions.CanReceive, credentials.Permissions.CanManage, permissions) {
+			_, err = m.API.UpdateSqsCredentials(&mnq.SqsAPIUpdateSqsCredentialsRequest{
+				Region:           sqsInfo.Region,
+				SqsCredentialsID: credentials.ID,
+				Permissions:      getSqsPermissions(permissions),
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if needCredentials {
+		if queue.Spec.CredentialsID != "" {
+			err := m.API.DeleteSqsCredentials(&mnq.SqsAPIDeleteSqsCredentialsRequest{
+				Region:           sqsInfo.Region,
+				SqsCredentialsID: queue.Spec.CredentialsID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+					return err
+				}
+			}
+		}
+
+		credentials, err := m.API.CreateSqsCredentials(&mnq.SqsAPICreateSqsCredentialsRequest{
+			Region:      sqsInfo.Region,
+			Name:        getCredentialsName(queue),
+			Permissions: getSqsPermissions(permissions),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		// the credentials ID is saved before writing the secret, so the credentials are never leaked
+		// the spec update overwrites the status, so it is kept aside
+		status := queue.Status.DeepCopy()
+		queue.Spec.CredentialsID = credentials.ID
+		err = m.Client.Update(ctx, queue)
+		if err != nil {
+			deleteErr := m.API.DeleteSqsCredentials(&mnq.SqsAPIDeleteSqsCredentialsRequest{
+				Region:           sqsInfo.Region,
+				SqsCredentialsID: credentials.ID,
+			}, scw.WithContext(ctx))
+			if deleteErr != nil {
+				m.Log.Error(deleteErr, "failed to delete unsaved credentials", "credentialsID", credentials.ID)
+			}
+			return err
+		}
+		queue.Status = *status
+
+		data[CredentialsSecretAccessKeyKey] = []byte(credentials.AccessKey)
+		data[CredentialsSecretSecretKeyKey] = []byte(credentials.SecretKey)
+	}
+
+	err = ensureCredentialsSecret(ctx, m.Client, queue, mnqv1alpha1.GroupVersion.WithKind("MNQQueue"), secretName, data)
+	if err != nil {
+		return err
+	}
+
+	queue.Status.CredentialsSecret = secretName
+
+	return nil
+}
+
+func getSqsPermissions(permissions mnqv1alpha1.MNQPermissions) *mnq.SqsPermissions {
+	return &mnq.SqsPermissions{
+		CanPublish: scw.BoolPtr(permissions.CanPublish),
+		CanReceive: scw.BoolPtr(permissions.CanReceive),
+		CanManage:  scw.BoolPtr(permissions.CanManage),
+	}
+}
+
+func getQueueAttributes(queue *mnqv1alpha1.MNQQueue) map[string]string {
+	attributes := map[string]string{}
+
+	if queue.Spec.FIFO {
+		attributes[queueAttributeFIFO] = "true"
+		attributes[queueAttributeContentBasedDeduplication] = strconv.FormatBool(queue.Spec.ContentBasedDeduplication)
+	}
+
+	if queue.Spec.VisibilityTimeout != nil {
+		attributes[queueAttributeVisibilityTimeout] = strconv.Itoa(int(queue.Spec.VisibilityTimeout.Duration.Seconds()))
+	}
+
+	if queue.Spec.MessageRetentionPeriod != nil {
+		attributes[queueAttributeMessageRetentionPeriod] = strconv.Itoa(int(queue.Spec.MessageRetentionPeriod.Duration.Seconds()))
+	}
+
+	if queue.Spec.MaximumMessageSize != nil {
+		attributes[queueAttributeMaximumMessageSize] = strconv.Itoa(int(*queue.Spec.MaximumMessageSize))
+	}
+
+	return attributes
+}
+
+// addQueueAttributes adds the given attributes to the query parameters, sorted by name
+func addQueueAttributes(params url.Values, attributes map[string]string) {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		params.Set(fmt.Sprintf("Attribute.%d.Name", i+1), name)
+		params.Set(fmt.Sprintf("Attribute.%d.Value", i+1), attributes[name])
+	}
+}
+
+// queueExists returns whether the queue with the given URL exists
+func queueExists(ctx context.Context, c *queryClient, queueURL string) (bool, error) {
+	err := c.do(ctx, "GetQueueAttributes", url.Values{"QueueUrl": []string{queueURL}}, nil)
+	if err != nil {
+		if isNonExistentQueue(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// getQueueURL returns the URL of the given queue, or an empty string if it does not exist
+func getQueueURL(ctx context.Context, c *queryClient, queueName string) (string, error) {
+	resp := struct {
+		QueueURL string `xml:"GetQueueUrlResult>QueueUrl"`
+	}{}
+
+	err := c.do(ctx, "GetQueueUrl", url.Values{"QueueName": []string{queueName}}, &resp)
+	if err != nil {
+		if isNonExistentQueue(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return resp.QueueURL, nil
+}
+
+func createQueue(ctx context.Context, c *queryClient, queueName string, attributes map[string]string) (string, error) {
+	resp := struct {
+		QueueURL string `xml:"CreateQueueResult>QueueUrl"`
+	}{}
+
+	params := url.Values{"QueueName": []string{queueName}}
+	addQueueAttributes(params, attributes)
+
+	err := c.do(ctx, "CreateQueue", params, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.QueueURL, nil
+}
+
+func setQueueAttributes(ctx context.Context, c *queryClient, queueURL string, attributes map[string]string) error {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	params := url.Values{"QueueUrl": []string{queueURL}}
+	addQueueAttributes(params, attributes)
+
+	return c.do(ctx, "SetQueueAttributes", params, nil)
+}
+
+func deleteQueue(ctx context.Context, c *queryClient, queueURL string) error {
+	err := c.do(ctx, "DeleteQueue", url.Values{"QueueUrl": []string{queueURL}}, nil)
+	if err != nil && !isNonExistentQueue(err) {
+		return err
+	}
+	return nil
+}
+
+func isNonExistentQueue(err error) bool {
+	queryErr, ok := err.(*queryError)
+	if !ok {
+		return false
+	}
+	return queryErr.Code == "AWS.SimpleQueueService.NonExistentQueue" || queryErr.Code == "QueueDoesNotExist"
+}
+
+func convertQueue(obj runtime.Object) (*mnqv1alpha1.MNQQueue, error) {
+	queue, ok := obj.(*mnqv1alpha1.MNQQueue)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return queue, nil
+}
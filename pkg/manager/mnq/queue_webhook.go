@@ -0,0 +1,104 @@
+package mnq
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	mnqv1alpha1 "github.com/scaleway/scaleway-operator/apis/mnq/v1alpha1"
+)
+
+const (
+	maxVisibilityTimeout      = 12 * time.Hour
+	minMessageRetentionPeriod = time.Minute
+	maxMessageRetentionPeriod = 14 * 24 * time.Hour
+)
+
+var queueNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,80}$`)
+
+// ValidateCreate validates the creation of a SQS queue
+func (m *QueueManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	queue, err := convertQueue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scw.ParseRegion(queue.Spec.Region)
+	if queue.Spec.Region != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("region"), queue.Spec.Region, "region is not valid"))
+	}
+
+	allErrs = append(allErrs, validateQueue(queue)...)
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a SQS queue
+func (m *QueueManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	queue, err := convertQueue(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldQueue, err := convertQueue(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldQueue.Spec.Region != "" && oldQueue.Spec.Region != queue.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("region"), "field is immutable"))
+	}
+
+	if oldQueue.Spec.QueueName != queue.Spec.QueueName {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("queueName"), "field is immutable"))
+	}
+
+	if oldQueue.Spec.FIFO != queue.Spec.FIFO {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("fifo"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateQueue(queue)...)
+
+	return allErrs, nil
+}
+
+func validateQueue(queue *mnqv1alpha1.MNQQueue) field.ErrorList {
+	var allErrs field.ErrorList
+
+	queueName := getResourceName(queue.Name, queue.Spec.QueueName, queue.Spec.FIFO)
+	if !queueNameRegexp.MatchString(strings.TrimSuffix(queueName, fifoSuffix)) || len(queueName) > 80 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("queueName"), queueName, "queue name must contain up to 80 alphanumeric characters, hyphens and underscores"))
+	}
+	if !queue.Spec.FIFO && strings.HasSuffix(queue.Spec.QueueName, fifoSuffix) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("queueName"), queue.Spec.QueueName, "only FIFO queues can have the .fifo suffix"))
+	}
+
+	if !queue.Spec.FIFO && queue.Spec.ContentBasedDeduplication {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("contentBasedDeduplication"), "content based deduplication is only available for FIFO queues"))
+	}
+
+	if queue.Spec.VisibilityTimeout != nil {
+		visibilityTimeout := queue.Spec.VisibilityTimeout.Duration
+		if visibilityTimeout < 0 || visibilityTimeout > maxVisibilityTimeout {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("visibilityTimeout"), visibilityTimeout.String(), "visibility timeout must be between 0s and 12h"))
+		}
+	}
+
+	if queue.Spec.MessageRetentionPeriod != nil {
+		messageRetentionPeriod := queue.Spec.MessageRetentionPeriod.Duration
+		if messageRetentionPeriod < minMessageRetentionPeriod || messageRetentionPeriod > maxMessageRetentionPeriod {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("messageRetentionPeriod"), messageRetentionPeriod.String(), "message retention period must be between 1m and 336h"))
+		}
+	}
+
+	return allErrs
+}
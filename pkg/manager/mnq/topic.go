@@ -0,0 +1,440 @@
+package mnq
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mnqv1alpha1 "github.com/scaleway/scaleway-operator/apis/mnq/v1alpha1"
+)
+
+const (
+	// TopicCredentialsSecretSuffix is the suffix of the topic credentials secret name
+	TopicCredentialsSecretSuffix = "-sns-credentials"
+
+	topicAttributeFIFO                      = "FifoTopic"
+	topicAttributeContentBasedDeduplication = "ContentBasedDeduplication"
+)
+
+// TopicManager manages the SNS topics
+type TopicManager struct {
+	client.Client
+	API *mnq.SnsAPI
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the SNS topic resource
+func (m *TopicManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	topic, err := convertTopic(obj)
+	if err != nil {
+		return false, err
+	}
+
+	snsInfo, err := m.API.GetSnsInfo(&mnq.SnsAPIGetSnsInfoRequest{
+		Region: scw.Region(topic.Spec.Region),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	// SNS is activated at the project level and is never deactivated by the operator
+	if snsInfo.Status != mnq.SnsInfoStatusEnabled {
+		_, err = m.API.ActivateSns(&mnq.SnsAPIActivateSnsRequest{
+			Region: snsInfo.Region,
+		}, scw.WithContext(ctx))
+		return false, err
+	}
+
+	if topic.Spec.Region == "" {
+		topic.Spec.Region = snsInfo.Region.String()
+		return false, m.Client.Update(ctx, topic)
+	}
+
+	attributes := getTopicAttributes(topic)
+	attributesHash := utils.HashValues(attributes)
+
+	// the topic is checked on each reconcile, so it is created again if deleted
+	topicARN := topic.Spec.TopicARN
+	err = m.withManageCredentials(ctx, topic, snsInfo, func(c *queryClient) error {
+		if topicARN != "" {
+			exists, err := topicExists(ctx, c, topicARN)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				topicARN = ""
+			}
+		}
+
+		if topicARN == "" {
+			// topic creation is idempotent and returns the existing topic if any
+			var err error
+			topicARN, err = createTopic(ctx, c, getResourceName(topic.Name, topic.Spec.TopicName, topic.Spec.FIFO), attributes)
+			if err != nil {
+				return err
+			}
+		} else if topic.Spec.FIFO && topic.Status.AttributesHash != attributesHash {
+			err := setTopicAttribute(ctx, c, topicARN, topicAttributeContentBasedDeduplication, attributes[topicAttributeContentBasedDeduplication])
+			if err != nil {
+				return err
+			}
+		}
+
+		topic.Status.AttributesHash = attributesHash
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if topicARN != topic.Spec.TopicARN {
+		// the spec update overwrites the status, so it is kept aside
+		status := topic.Status.DeepCopy()
+		topic.Spec.TopicARN = topicARN
+		err = m.Client.Update(ctx, topic)
+		if err != nil {
+			return false, err
+		}
+		topic.Status = *status
+	}
+
+	err = m.ensureCredentials(ctx, topic, snsInfo)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Delete deletes the SNS topic resource
+func (m *TopicManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	topic, err := convertTopic(obj)
+	if err != nil {
+		return false, err
+	}
+
+	if topic.Spec.Region == "" {
+		return true, nil
+	}
+
+	snsInfo, err := m.API.GetSnsInfo(&mnq.SnsAPIGetSnsInfoRequest{
+		Region: scw.Region(topic.Spec.Region),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	if snsInfo.Status == mnq.SnsInfoStatusEnabled {
+		if topic.Spec.TopicARN != "" {
+			err = m.withManageCredentials(ctx, topic, snsInfo, func(c *queryClient) error {
+				return deleteTopic(ctx, c, topic.Spec.TopicARN)
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+
+		err = m.deleteManageCredentials(ctx, topic, snsInfo)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if topic.Spec.CredentialsID != "" {
+		err = m.API.DeleteSnsCredentials(&mnq.SnsAPIDeleteSnsCredentialsRequest{
+			Region:           snsInfo.Region,
+			SnsCredentialsID: topic.Spec.CredentialsID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the SNS topic resource
+func (m *TopicManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+// withManageCredentials runs the given function with the credentials allowed to manage the topics,
+// so the generated credentials can stay restricted
+// The manage credentials are shared by the topics of the namespace and kept in a secret
+func (m *TopicManager) withManageCredentials(ctx context.Context, topic *mnqv1alpha1.MNQTopic, snsInfo *mnq.SnsInfo, f func(c *queryClient) error) error {
+	credentials, err := ensureManageCredentials(ctx, m.Client, m.manageCredentialsAPI(ctx, snsInfo.Region),
+		getManageCredentialsSecretName(snsService, snsInfo.Region.String(), snsInfo.ProjectID), topic.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return f(newQueryClient(snsInfo.SnsEndpointURL, snsInfo.Region.String(), snsService, snsVersion, credentials.AccessKey, credentials.SecretKey))
+}
+
+// deleteManageCredentials deletes the manage credentials of the namespace
+// once no other topic of the namespace uses them
+func (m *TopicManager) deleteManageCredentials(ctx context.Context, topic *mnqv1alpha1.MNQTopic, snsInfo *mnq.SnsInfo) error {
+	topics := &mnqv1alpha1.MNQTopicList{}
+	err := m.Client.List(ctx, topics, client.InNamespace(topic.Namespace))
+	if err != nil {
+		return err
+	}
+
+	regions := map[types.UID]string{}
+	for _, other := range topics.Items {
+		regions[other.UID] = other.Spec.Region
+	}
+	if isManageCredentialsInUse(topic.UID, topic.Spec.Region, regions) {
+		return nil
+	}
+
+	return deleteManageCredentials(ctx, m.Client, m.manageCredentialsAPI(ctx, snsInfo.Region),
+		getManageCredentialsSecretName(snsService, snsInfo.Region.String(), snsInfo.ProjectID), topic.Namespace)
+}
+
+// manageCredentialsAPI returns the Sns calls used to handle the manage credentials in the given region
+func (m *TopicManager) manageCredentialsAPI(ctx context.Context, region scw.Region) manageCredentialsAPI {
+	return manageCredentialsAPI{
+		exists: func(id string) (bool, error) {
+			_, err := m.API.GetSnsCredentials(&mnq.SnsAPIGetSnsCredentialsRequest{
+				Region:           region,
+				SnsCredentialsID: id,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				if _, ok := err.(*scw.ResourceNotFoundError); ok {
+					return false, nil
+				}
+				return false, err
+			}
+			return true, nil
+		},
+		create: func(name string) (*manageCredentials, error) {
+			credentials, err := m.API.CreateSnsCredentials(&mnq.SnsAPICreateSnsCredentialsRequest{
+				Region: region,
+				Name:   name,
+				Permissions: &mnq.SnsPermissions{
+					CanPublish: scw.BoolPtr(false),
+					CanReceive: scw.BoolPtr(false),
+					CanManage:  scw.BoolPtr(true),
+				},
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return &manageCredentials{
+				ID:        credentials.ID,
+				AccessKey: credentials.AccessKey,
+				SecretKey: credentials.SecretKey,
+			}, nil
+		},
+		delete: func(id string) error {
+			err := m.API.DeleteSnsCredentials(&mnq.SnsAPIDeleteSnsCredentialsRequest{
+				Region:           region,
+				SnsCredentialsID: id,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// ensureCredentials generates the SNS credentials and writes them in the credentials secret
+// The secret key is only returned on creation, so they are generated again
+// whenever the secret is lost
+func (m *TopicManager) ensureCredentials(ctx context.Context, topic *mnqv1alpha1.MNQTopic, snsInfo *mnq.SnsInfo) error {
+	secretName := topic.Name + TopicCredentialsSecretSuffix
+
+	secret, err := getCredentialsSecret(ctx, m.Client, secretName, topic.Namespace)
+	if err != nil {
+		return err
+	}
+
+	data := map[string][]byte{
+		CredentialsSecretEndpointKey: []byte(snsInfo.SnsEndpointURL),
+		CredentialsSecretRegionKey:   []byte(snsInfo.Region.String()),
+		CredentialsSecretTopicARNKey: []byte(topic.Spec.TopicARN),
+	}
+
+	permissions := getPermissions(topic.Spec.Permissions)
+
+	needCredentials := topic.Spec.CredentialsID == "" || !hasKeys(secret, CredentialsSecretAccessKeyKey, CredentialsSecretSecretKeyKey)
+	if !needCredentials {
+		credentials, err := m.API.GetSnsCredentials(&mnq.SnsAPIGetSnsCredentialsRequest{
+			Region:           snsInfo.Region,
+			SnsCredentialsID: topic.Spec.CredentialsID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+				return err
+			}
+			needCredentials = true
+		} else if credentials.AccessKey != string(secret.Data[CredentialsSecretAccessKeyKey]) {
+			needCredentials = true
+		} else if credentials.Permissions == nil ||
+			checkPermissionsUpdate(credentials.Permissions.CanPublish, credentials.Permissions.CanReceive, credentials.Permissions.CanManage, permissions) {
+			_, err = m.API.UpdateSnsCredentials(&mnq.SnsAPIUpdateSnsCredentialsRequest{
+				Region:           snsInfo.Region,
+				SnsCredentialsID: credentials.ID,
+				Permissions:      getSnsPermissions(permissions),
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if needCredentials {
+		if topic.Spec.CredentialsID != "" {
+			err := m.API.DeleteSnsCredentials(&mnq.SnsAPIDeleteSnsCredentialsRequest{
+				Region:           snsInfo.Region,
+				SnsCredentialsID: topic.Spec.CredentialsID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+					return err
+				}
+			}
+		}
+
+		credentials, err := m.API.CreateSnsCredentials(&mnq.SnsAPICreateSnsCredentialsRequest{
+			Region:      snsInfo.Region,
+			Name:        getCredentialsName(topic),
+			Permissions: getSnsPermissions(permissions),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		// the credentials ID is saved before writing the secret, so the credentials are never leaked
+		// the spec update overwrites the status, so it is kept aside
+		status := topic.Status.DeepCopy()
+		topic.Spec.CredentialsID = credentials.ID
+		err = m.Client.Update(ctx, topic)
+		if err != nil {
+			deleteErr := m.API.DeleteSnsCredentials(&mnq.SnsAPIDeleteSnsCredentialsRequest{
+				Region:           snsInfo.Region,
+				SnsCredentialsID: credentials.ID,
+			}, scw.WithContext(ctx))
+			if deleteErr != nil {
+				m.Log.Error(deleteErr, "failed to delete unsaved credentials", "credentialsID", credentials.ID)
+			}
+			return err
+		}
+		topic.Status = *status
+
+		data[CredentialsSecretAccessKeyKey] = []byte(credentials.AccessKey)
+		data[CredentialsSecretSecretKeyKey] = []byte(credentials.SecretKey)
+	}
+
+	err = ensureCredentialsSecret(ctx, m.Client, topic, mnqv1alpha1.GroupVersion.WithKind("MNQTopic"), secretName, data)
+	if err != nil {
+		return err
+	}
+
+	topic.Status.CredentialsSecret = secretName
+
+	return nil
+}
+
+func getSnsPermissions(permissions mnqv1alpha1.MNQPermissions) *mnq.SnsPermissions {
+	return &mnq.SnsPermissions{
+		CanPublish: scw.BoolPtr(permissions.CanPublish),
+		CanReceive: scw.BoolPtr(permissions.CanReceive),
+		CanManage:  scw.BoolPtr(permissions.CanManage),
+	}
+}
+
+func getTopicAttributes(topic *mnqv1alpha1.MNQTopic) map[string]string {
+	attributes := map[string]string{}
+
+	if topic.Spec.FIFO {
+		attributes[topicAttributeFIFO] = "true"
+		attributes[topicAttributeContentBasedDeduplication] = strconv.FormatBool(topic.Spec.ContentBasedDeduplication)
+	}
+
+	return attributes
+}
+
+func createTopic(ctx context.Context, c *queryClient, topicName string, attributes map[string]string) (string, error) {
+	resp := struct {
+		TopicARN string `xml:"CreateTopicResult>TopicArn"`
+	}{}
+
+	params := url.Values{"Name": []string{topicName}}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		params.Set(fmt.Sprintf("Attributes.entry.%d.key", i+1), name)
+		params.Set(fmt.Sprintf("Attributes.entry.%d.value", i+1), attributes[name])
+	}
+
+	err := c.do(ctx, "CreateTopic", params, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.TopicARN, nil
+}
+
+func setTopicAttribute(ctx context.Context, c *queryClient, topicARN string, name string, value string) error {
+	return c.do(ctx, "SetTopicAttributes", url.Values{
+		"TopicArn":       []string{topicARN},
+		"AttributeName":  []string{name},
+		"AttributeValue": []string{value},
+	}, nil)
+}
+
+// topicExists returns whether the topic with the given ARN exists
+func topicExists(ctx context.Context, c *queryClient, topicARN string) (bool, error) {
+	err := c.do(ctx, "GetTopicAttributes", url.Values{"TopicArn": []string{topicARN}}, nil)
+	if err != nil {
+		if isNotFoundTopic(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func deleteTopic(ctx context.Context, c *queryClient, topicARN string) error {
+	err := c.do(ctx, "DeleteTopic", url.Values{"TopicArn": []string{topicARN}}, nil)
+	if err != nil && !isNotFoundTopic(err) {
+		return err
+	}
+	return nil
+}
+
+func isNotFoundTopic(err error) bool {
+	queryErr, ok := err.(*queryError)
+	return ok && queryErr.Code == "NotFound"
+}
+
+func convertTopic(obj runtime.Object) (*mnqv1alpha1.MNQTopic, error) {
+	topic, ok := obj.(*mnqv1alpha1.MNQTopic)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return topic, nil
+}
@@ -0,0 +1,83 @@
+package mnq
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	mnqv1alpha1 "github.com/scaleway/scaleway-operator/apis/mnq/v1alpha1"
+)
+
+var topicNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,256}$`)
+
+// ValidateCreate validates the creation of a SNS topic
+func (m *TopicManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	topic, err := convertTopic(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scw.ParseRegion(topic.Spec.Region)
+	if topic.Spec.Region != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("region"), topic.Spec.Region, "region is not valid"))
+	}
+
+	allErrs = append(allErrs, validateTopic(topic)...)
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a SNS topic
+func (m *TopicManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	topic, err := convertTopic(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldTopic, err := convertTopic(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldTopic.Spec.Region != "" && oldTopic.Spec.Region != topic.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("region"), "field is immutable"))
+	}
+
+	if oldTopic.Spec.TopicName != topic.Spec.TopicName {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("topicName"), "field is immutable"))
+	}
+
+	if oldTopic.Spec.FIFO != topic.Spec.FIFO {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("fifo"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateTopic(topic)...)
+
+	return allErrs, nil
+}
+
+func validateTopic(topic *mnqv1alpha1.MNQTopic) field.ErrorList {
+	var allErrs field.ErrorList
+
+	topicName := getResourceName(topic.Name, topic.Spec.TopicName, topic.Spec.FIFO)
+	if !topicNameRegexp.MatchString(strings.TrimSuffix(topicName, fifoSuffix)) || len(topicName) > 256 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("topicName"), topicName, "topic name must contain up to 256 alphanumeric characters, hyphens and underscores"))
+	}
+	if !topic.Spec.FIFO && strings.HasSuffix(topic.Spec.TopicName, fifoSuffix) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("topicName"), topic.Spec.TopicName, "only FIFO topics can have the .fifo suffix"))
+	}
+
+	if !topic.Spec.FIFO && topic.Spec.ContentBasedDeduplication {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("contentBasedDeduplication"), "content based deduplication is only available for FIFO topics"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,170 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ipv1alpha1 "github.com/scaleway/scaleway-operator/apis/ip/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+)
+
+const (
+	// ACLSourceService is the kind of the Service ACL sources
+	ACLSourceService = "Service"
+	// ACLSourceConfigMap is the kind of the ConfigMap ACL sources
+	ACLSourceConfigMap = "ConfigMap"
+	// ACLSourceRDBInstance is the kind of the RDBInstance ACL sources
+	ACLSourceRDBInstance = "RDBInstance"
+	// ACLSourcePublicGateway is the kind of the PublicGateway ACL sources
+	ACLSourcePublicGateway = "PublicGateway"
+	// ACLSourceFlexibleIP is the kind of the FlexibleIP ACL sources
+	ACLSourceFlexibleIP = "FlexibleIP"
+)
+
+// GetACLSourceKindAndRef returns the kind and the reference of the object of the given ACL source
+func GetACLSourceKindAndRef(source rdbv1beta1.RDBACLSource) (string, *rdbv1beta1.RDBACLSourceRef) {
+	switch {
+	case source.Service != nil:
+		return ACLSourceService, source.Service
+	case source.ConfigMap != nil:
+		return ACLSourceConfigMap, &source.ConfigMap.RDBACLSourceRef
+	case source.RDBInstance != nil:
+		return ACLSourceRDBInstance, source.RDBInstance
+	case source.PublicGateway != nil:
+		return ACLSourcePublicGateway, source.PublicGateway
+	case source.FlexibleIP != nil:
+		return ACLSourceFlexibleIP, source.FlexibleIP
+	}
+	return "", nil
+}
+
+// getSourcesRules returns the ACL rules resolved from the ACL sources of the instance
+func (m *InstanceManager) getSourcesRules(ctx context.Context, instance *rdbv1beta1.RDBInstance) ([]*rdb.ACLRuleRequest, error) {
+	rules := []*rdb.ACLRuleRequest{}
+
+	for _, source := range instance.Spec.ACL.Sources {
+		sourceIPs, err := m.getSourceIPs(ctx, instance, source)
+		if err != nil {
+			return nil, err
+		}
+		description := source.Description
+		if description == "" {
+			kind, ref := GetACLSourceKindAndRef(source)
+			description = fmt.Sprintf("%s %s", kind, ref.Name)
+		}
+		for _, sourceIP := range sourceIPs {
+			rules = append(rules, &rdb.ACLRuleRequest{
+				IP: scw.IPNet{
+					IPNet: sourceIP,
+				},
+				Description: description,
+			})
+		}
+	}
+
+	return rules, nil
+}
+
+// getSourceIPs returns the IP ranges resolved from the given ACL source
+// Sources not having an IP yet, like a pending LoadBalancer, resolve to no IP
+func (m *InstanceManager) getSourceIPs(ctx context.Context, instance *rdbv1beta1.RDBInstance, source rdbv1beta1.RDBACLSource) ([]net.IPNet, error) {
+	kind, ref := GetACLSourceKindAndRef(source)
+	if ref == nil {
+		return nil, fmt.Errorf("acl source has no object specified")
+	}
+
+	key := types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: ref.Namespace,
+	}
+	if key.Namespace == "" {
+		key.Namespace = instance.Namespace
+	}
+
+	var ips []string
+
+	switch kind {
+	case ACLSourceService:
+		service := corev1.Service{}
+		err := m.Get(ctx, key, &service)
+		if err != nil {
+			return nil, err
+		}
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			return nil, fmt.Errorf("service %s is not of type %s", key.String(), corev1.ServiceTypeLoadBalancer)
+		}
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				ips = append(ips, ingress.IP)
+			}
+		}
+	case ACLSourceConfigMap:
+		configMap := corev1.ConfigMap{}
+		err := m.Get(ctx, key, &configMap)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := configMap.Data[source.ConfigMap.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in configmap %s", source.ConfigMap.Key, key.String())
+		}
+		ips = strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		})
+	case ACLSourceRDBInstance:
+		sourceInstance := rdbv1beta1.RDBInstance{}
+		err := m.Get(ctx, key, &sourceInstance)
+		if err != nil {
+			return nil, err
+		}
+		if sourceInstance.Status.Endpoint.IP != "" {
+			ips = append(ips, sourceInstance.Status.Endpoint.IP)
+		}
+	case ACLSourcePublicGateway:
+		gateway := vpcv1alpha1.PublicGateway{}
+		err := m.Get(ctx, key, &gateway)
+		if err != nil {
+			return nil, err
+		}
+		if gateway.Status.IP != "" {
+			ips = append(ips, gateway.Status.IP)
+		}
+	case ACLSourceFlexibleIP:
+		flexibleIP := ipv1alpha1.FlexibleIP{}
+		err := m.Get(ctx, key, &flexibleIP)
+		if err != nil {
+			return nil, err
+		}
+		if flexibleIP.Status.Address != "" {
+			ips = append(ips, flexibleIP.Status.Address)
+		}
+	}
+
+	return parseIPRanges(ips)
+}
+
+// parseIPRanges parses the given IPs or CIDRs to IP ranges
+func parseIPRanges(ipRanges []string) ([]net.IPNet, error) {
+	ipNets := []net.IPNet{}
+	for _, ipRange := range ipRanges {
+		normalized, err := utils.NormalizeIPRange(ipRange)
+		if err != nil {
+			return nil, err
+		}
+		_, ipNet, err := net.ParseCIDR(normalized)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, *ipNet)
+	}
+	return ipNets, nil
+}
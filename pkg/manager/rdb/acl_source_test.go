@@ -0,0 +1,173 @@
+package rdb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ipv1alpha1 "github.com/scaleway/scaleway-operator/apis/ip/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+)
+
+func Test_getSourcesRules(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = rdbv1beta1.AddToScheme(scheme)
+	_ = vpcv1alpha1.AddToScheme(scheme)
+	_ = ipv1alpha1.AddToScheme(scheme)
+
+	c := fake.NewFakeClientWithScheme(scheme,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "lb", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{IP: "51.15.0.1"}, {Hostname: "lb.example.com"}},
+				},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "clusterip", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "ips", Namespace: "other"},
+			Data:       map[string]string{"allowed": "10.0.0.0/8, 192.168.1.1\n172.16.0.1/12"},
+		},
+		&rdbv1beta1.RDBInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "replica", Namespace: "default"},
+			Status: rdbv1beta1.RDBInstanceStatus{
+				Endpoint: rdbv1beta1.RDBInstanceEndpoint{IP: "51.15.0.2", Port: 5432},
+			},
+		},
+		&vpcv1alpha1.PublicGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+			Status:     vpcv1alpha1.PublicGatewayStatus{IP: "51.15.0.3"},
+		},
+		&ipv1alpha1.FlexibleIP{
+			ObjectMeta: metav1.ObjectMeta{Name: "egress", Namespace: "default"},
+			Status:     ipv1alpha1.FlexibleIPStatus{Address: "51.15.0.4"},
+		},
+		&ipv1alpha1.FlexibleIP{
+			ObjectMeta: metav1.ObjectMeta{Name: "reserving", Namespace: "default"},
+		},
+	)
+	m := &InstanceManager{Client: c, Log: logf.Log}
+
+	cases := []struct {
+		source       rdbv1beta1.RDBACLSource
+		ipRanges     []string
+		descriptions []string
+		err          bool
+	}{
+		{
+			rdbv1beta1.RDBACLSource{Service: &rdbv1beta1.RDBACLSourceRef{Name: "lb"}},
+			[]string{"51.15.0.1/32"},
+			[]string{"Service lb"},
+			false,
+		},
+		{
+			rdbv1beta1.RDBACLSource{Service: &rdbv1beta1.RDBACLSourceRef{Name: "pending"}},
+			nil,
+			nil,
+			false,
+		},
+		{
+			rdbv1beta1.RDBACLSource{Service: &rdbv1beta1.RDBACLSourceRef{Name: "clusterip"}},
+			nil,
+			nil,
+			true,
+		},
+		{
+			rdbv1beta1.RDBACLSource{Service: &rdbv1beta1.RDBACLSourceRef{Name: "missing"}},
+			nil,
+			nil,
+			true,
+		},
+		{
+			rdbv1beta1.RDBACLSource{
+				Description: "partners",
+				ConfigMap: &rdbv1beta1.RDBACLSourceConfigMapRef{
+					RDBACLSourceRef: rdbv1beta1.RDBACLSourceRef{Name: "ips", Namespace: "other"},
+					Key:             "allowed",
+				},
+			},
+			[]string{"10.0.0.0/8", "192.168.1.1/32", "172.16.0.0/12"},
+			[]string{"partners", "partners", "partners"},
+			false,
+		},
+		{
+			rdbv1beta1.RDBACLSource{
+				ConfigMap: &rdbv1beta1.RDBACLSourceConfigMapRef{
+					RDBACLSourceRef: rdbv1beta1.RDBACLSourceRef{Name: "ips", Namespace: "other"},
+					Key:             "missing",
+				},
+			},
+			nil,
+			nil,
+			true,
+		},
+		{
+			rdbv1beta1.RDBACLSource{RDBInstance: &rdbv1beta1.RDBACLSourceRef{Name: "replica"}},
+			[]string{"51.15.0.2/32"},
+			[]string{"RDBInstance replica"},
+			false,
+		},
+		{
+			rdbv1beta1.RDBACLSource{PublicGateway: &rdbv1beta1.RDBACLSourceRef{Name: "gateway", Namespace: "default"}},
+			[]string{"51.15.0.3/32"},
+			[]string{"PublicGateway gateway"},
+			false,
+		},
+		{
+			rdbv1beta1.RDBACLSource{FlexibleIP: &rdbv1beta1.RDBACLSourceRef{Name: "egress"}},
+			[]string{"51.15.0.4/32"},
+			[]string{"FlexibleIP egress"},
+			false,
+		},
+		{
+			rdbv1beta1.RDBACLSource{FlexibleIP: &rdbv1beta1.RDBACLSourceRef{Name: "reserving"}},
+			nil,
+			nil,
+			false,
+		},
+	}
+
+	for i, c := range cases {
+		instance := &rdbv1beta1.RDBInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+			Spec: rdbv1beta1.RDBInstanceSpec{
+				ACL: &rdbv1beta1.RDBACL{Sources: []rdbv1beta1.RDBACLSource{c.source}},
+			},
+		}
+		rules, err := m.getSourcesRules(context.Background(), instance)
+		if (err != nil) != c.err {
+			t.Errorf("case %d: got error %v instead of %t", i, err, c.err)
+			continue
+		}
+		var ipRanges []string
+		var descriptions []string
+		for _, rule := range rules {
+			ipRanges = append(ipRanges, rule.IP.String())
+			descriptions = append(descriptions, rule.Description)
+		}
+		if !reflect.DeepEqual(ipRanges, c.ipRanges) {
+			t.Errorf("case %d: got %v instead of %v", i, ipRanges, c.ipRanges)
+		}
+		if !reflect.DeepEqual(descriptions, c.descriptions) {
+			t.Errorf("case %d: got %v instead of %v", i, descriptions, c.descriptions)
+		}
+	}
+}
@@ -7,12 +7,12 @@ import (
 	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
 	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
 )
 
 // DatabaseManager manages the RDB databsses
@@ -20,6 +20,8 @@ type DatabaseManager struct {
 	client.Client
 	API *rdb.API
 	scaleway.Manager
+	// DefaultRegion is the region used when none is specified
+	DefaultRegion scw.Region
 }
 
 // Ensure reconciles the RDB database resource
@@ -46,14 +48,18 @@ func (m *DatabaseManager) Ensure(ctx context.Context, obj runtime.Object) (bool,
 		if database.Spec.OverrideName != "" {
 			name = database.Spec.OverrideName
 		}
+		if scaleway.PlanAction(ctx, "create database %s on instance %s", name, instanceID) {
+			return false, nil
+		}
 		rdbDatabase, err = m.API.CreateDatabase(&rdb.CreateDatabaseRequest{
 			Region:     region,
 			InstanceID: instanceID,
 			Name:       name,
-		})
+		}, scw.WithContext(ctx))
 		if err != nil {
 			return false, err
 		}
+		scaleway.RecordEvent(ctx, database, corev1.EventTypeNormal, scaleway.ReasonDatabaseCreated, "Created database %s on instance %s", name, instanceID)
 	}
 
 	database.Status.Managed = rdbDatabase.Managed
@@ -80,22 +86,32 @@ func (m *DatabaseManager) Delete(ctx context.Context, obj runtime.Object) (bool,
 		name = database.Spec.OverrideName
 	}
 
+	if scaleway.PlanAction(ctx, "delete database %s on instance %s", name, instanceID) {
+		return false, nil
+	}
+
 	err = m.API.DeleteDatabase(&rdb.DeleteDatabaseRequest{
 		Region:     region,
 		InstanceID: instanceID,
 		Name:       name,
-	})
+	}, scw.WithContext(ctx))
 	if err != nil {
 		if _, ok := err.(*scw.ResourceNotFoundError); ok {
 			return true, nil
 		}
 		return false, err
 	}
+	scaleway.RecordEvent(ctx, database, corev1.EventTypeNormal, scaleway.ReasonDatabaseDeleted, "Deleted database %s on instance %s", name, instanceID)
 
 	return true, nil
 }
 
-func (m *DatabaseManager) getByName(ctx context.Context, database *rdbv1alpha1.RDBDatabase) (*rdb.Database, error) {
+// SupportsDryRun returns whether the manager supports the dry-run mode
+func (m *DatabaseManager) SupportsDryRun() bool {
+	return true
+}
+
+func (m *DatabaseManager) getByName(ctx context.Context, database *rdbv1beta1.RDBDatabase) (*rdb.Database, error) {
 	instanceID, region, err := m.getInstanceIDAndRegion(ctx, database)
 	if err != nil {
 		return nil, err
@@ -110,7 +126,7 @@ func (m *DatabaseManager) getByName(ctx context.Context, database *rdbv1alpha1.R
 		Region:     region,
 		InstanceID: instanceID,
 		Name:       scw.StringPtr(name),
-	}, scw.WithAllPages())
+	}, scw.WithAllPages(), scw.WithContext(ctx))
 	if err != nil {
 		if _, ok := err.(*scw.ResourceNotFoundError); ok {
 			return nil, nil
@@ -141,55 +157,15 @@ func (m *DatabaseManager) GetOwners(ctx context.Context, obj runtime.Object) ([]
 		return nil, err
 	}
 
-	if database.Spec.InstanceRef.Name == "" {
-		return nil, nil
-	}
-
-	instance := &rdbv1alpha1.RDBInstance{}
-
-	databaseNamespace := database.Spec.InstanceRef.Namespace
-	if databaseNamespace == "" {
-		databaseNamespace = database.Namespace
-	}
-
-	err = m.Get(ctx, client.ObjectKey{Name: database.Spec.InstanceRef.Name, Namespace: databaseNamespace}, instance)
-	if err != nil {
-		return nil, err
-	}
-
-	return []scaleway.Owner{
-		{
-			Key: types.NamespacedName{
-				Name:      instance.Name,
-				Namespace: instance.Namespace,
-			},
-			Object: &rdbv1alpha1.RDBInstance{},
-		},
-	}, nil
+	return getInstanceRefOwners(ctx, m.Client, database.Spec.InstanceRef, database.Namespace)
 }
 
-func (m *DatabaseManager) getInstanceIDAndRegion(ctx context.Context, database *rdbv1alpha1.RDBDatabase) (string, scw.Region, error) {
-	instance := &rdbv1alpha1.RDBInstance{}
-
-	if database.Spec.InstanceRef.Name != "" {
-		databaseNamespace := database.Spec.InstanceRef.Namespace
-		if databaseNamespace == "" {
-			databaseNamespace = database.Namespace
-		}
-
-		err := m.Get(ctx, client.ObjectKey{Name: database.Spec.InstanceRef.Name, Namespace: databaseNamespace}, instance)
-		if err != nil {
-			return "", "", err
-		}
-
-		return instance.Spec.InstanceID, scw.Region(instance.Spec.Region), nil
-	}
-
-	return database.Spec.InstanceRef.ExternalID, scw.Region(database.Spec.InstanceRef.Region), nil
+func (m *DatabaseManager) getInstanceIDAndRegion(ctx context.Context, database *rdbv1beta1.RDBDatabase) (string, scw.Region, error) {
+	return getInstanceRefIDAndRegion(ctx, m.Client, database.Spec.InstanceRef, database.Namespace)
 }
 
-func convertDatabase(obj runtime.Object) (*rdbv1alpha1.RDBDatabase, error) {
-	database, ok := obj.(*rdbv1alpha1.RDBDatabase)
+func convertDatabase(obj runtime.Object) (*rdbv1beta1.RDBDatabase, error) {
+	database, ok := obj.(*rdbv1beta1.RDBDatabase)
 	if !ok {
 		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
 	}
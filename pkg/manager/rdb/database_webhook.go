@@ -7,8 +7,22 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
 )
 
+// Default sets the default values of a RDB Database
+func (m *DatabaseManager) Default(ctx context.Context, obj runtime.Object) error {
+	database, err := convertDatabase(obj)
+	if err != nil {
+		return err
+	}
+
+	defaultInstanceRef(&database.Spec.InstanceRef, m.DefaultRegion)
+
+	return nil
+}
+
 // ValidateCreate validates the creation of a RDB Database
 func (m *DatabaseManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
 	var allErrs field.ErrorList
@@ -18,34 +32,7 @@ func (m *DatabaseManager) ValidateCreate(ctx context.Context, obj runtime.Object
 		return nil, err
 	}
 
-	byName := database.Spec.InstanceRef.Name != "" || database.Spec.InstanceRef.Namespace != ""
-	byID := database.Spec.InstanceRef.ExternalID != "" || database.Spec.InstanceRef.Region != ""
-
-	if database.Spec.InstanceRef.Name == "" && database.Spec.InstanceRef.ExternalID == "" {
-		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("instanceRef"), "name/namespace or externalID/region must be specified"))
-		return allErrs, nil
-	}
-	if byName && byID {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("instanceRef"), "only on of name/namespace and externalID/region must be specified"))
-		return allErrs, nil
-	}
-
-	if byID {
-		_, err = scw.ParseRegion(database.Spec.InstanceRef.Region)
-		if database.Spec.InstanceRef.Region != "" && err != nil {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("instanceRef").Child("region"), database.Spec.InstanceRef.Region, "region is not valid"))
-			return allErrs, nil
-		}
-
-		_, err = m.API.GetInstance(&rdb.GetInstanceRequest{
-			Region:     scw.Region(database.Spec.InstanceRef.Region),
-			InstanceID: database.Spec.InstanceRef.ExternalID,
-		})
-		if err != nil {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("instanceRef").Child("externalID"), database.Spec.InstanceRef.ExternalID, err.Error()))
-			return allErrs, nil
-		}
-	}
+	allErrs = append(allErrs, validateInstanceRef(ctx, m.API, database.Spec.InstanceRef, field.NewPath("spec").Child("instanceRef"))...)
 
 	return allErrs, nil
 }
@@ -68,21 +55,67 @@ func (m *DatabaseManager) ValidateUpdate(ctx context.Context, oldObj runtime.Obj
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("overrideName"), "field is immutable"))
 	}
 
-	if oldDatabase.Spec.InstanceRef.Region != database.Spec.InstanceRef.Region {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("instanceRef").Child("region"), "field is immutable"))
+	allErrs = append(allErrs, validateInstanceRefUpdate(oldDatabase.Spec.InstanceRef, database.Spec.InstanceRef, field.NewPath("spec").Child("instanceRef"))...)
+
+	return allErrs, nil
+}
+
+// validateInstanceRef checks that an instance reference targets exactly one instance,
+// an external instance having to exist
+func validateInstanceRef(ctx context.Context, api *rdb.API, ref rdbv1beta1.RDBInstanceRef, refPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ref.Object == nil && ref.External == nil {
+		allErrs = append(allErrs, field.Required(refPath, "object or external must be specified"))
+		return allErrs
+	}
+	if ref.Object != nil && ref.External != nil {
+		allErrs = append(allErrs, field.Forbidden(refPath, "only one of object and external must be specified"))
+		return allErrs
+	}
+
+	if ref.External != nil {
+		_, err := scw.ParseRegion(ref.External.Region)
+		if ref.External.Region != "" && err != nil {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("external").Child("region"), ref.External.Region, "region is not valid"))
+			return allErrs
+		}
+
+		_, err = api.GetInstance(&rdb.GetInstanceRequest{
+			Region:     scw.Region(ref.External.Region),
+			InstanceID: ref.External.ID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("external").Child("id"), ref.External.ID, err.Error()))
+			return allErrs
+		}
 	}
 
-	if oldDatabase.Spec.InstanceRef.ExternalID != database.Spec.InstanceRef.ExternalID {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("instanceRef").Child("externalID"), "field is immutable"))
+	return allErrs
+}
+
+// validateInstanceRefUpdate checks that an instance reference is not changed
+// The region of an external instance may only be set when it was empty, as done by the defaulting
+func validateInstanceRefUpdate(oldRef rdbv1beta1.RDBInstanceRef, ref rdbv1beta1.RDBInstanceRef, refPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if (oldRef.Object == nil) != (ref.Object == nil) || (oldRef.External == nil) != (ref.External == nil) {
+		allErrs = append(allErrs, field.Forbidden(refPath, "field is immutable"))
+		return allErrs
 	}
 
-	if oldDatabase.Spec.InstanceRef.Name != database.Spec.InstanceRef.Name {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("instanceRef").Child("name"), "field is immutable"))
+	if oldRef.Object != nil && *oldRef.Object != *ref.Object {
+		allErrs = append(allErrs, field.Forbidden(refPath.Child("object"), "field is immutable"))
 	}
 
-	if oldDatabase.Spec.InstanceRef.Namespace != database.Spec.InstanceRef.Namespace {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("instanceRef").Child("namespace"), "field is immutable"))
+	if oldRef.External != nil {
+		if oldRef.External.ID != ref.External.ID {
+			allErrs = append(allErrs, field.Forbidden(refPath.Child("external").Child("id"), "field is immutable"))
+		}
+		if oldRef.External.Region != "" && oldRef.External.Region != ref.External.Region {
+			allErrs = append(allErrs, field.Forbidden(refPath.Child("external").Child("region"), "field is immutable"))
+		}
 	}
 
-	return allErrs, nil
+	return allErrs
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
@@ -14,15 +15,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
 )
 
+// ACLRuleDescriptionPrefix prefixes the description of the ACL rules created by the operator
+// when only owned rules are managed
+const ACLRuleDescriptionPrefix = "scaleway-operator: "
+
 // InstanceManager manages the RDB instances
 type InstanceManager struct {
 	client.Client
 	API *rdb.API
 	scaleway.Manager
 	Log logr.Logger
+	// DefaultRegion is the region used when none is specified
+	DefaultRegion scw.Region
 }
 
 // Ensure reconciles the RDB instance resource
@@ -42,12 +50,12 @@ func (m *InstanceManager) Ensure(ctx context.Context, obj runtime.Object) (bool,
 	rdbInstanceResp, err := m.API.GetInstance(&rdb.GetInstanceRequest{
 		Region:     region,
 		InstanceID: instance.Spec.InstanceID,
-	})
+	}, scw.WithContext(ctx))
 	if err != nil {
 		return false, err
 	}
 
-	needReturn, err := m.updateInstance(instance, rdbInstanceResp)
+	needReturn, err := m.updateInstance(ctx, instance, rdbInstanceResp)
 	if err != nil {
 		return false, err
 	}
@@ -55,7 +63,7 @@ func (m *InstanceManager) Ensure(ctx context.Context, obj runtime.Object) (bool,
 		return false, nil
 	}
 
-	needReturn, err = m.upgradeInstance(instance, rdbInstanceResp)
+	needReturn, err = m.upgradeInstance(ctx, instance, rdbInstanceResp)
 	if err != nil {
 		return false, err
 	}
@@ -63,12 +71,16 @@ func (m *InstanceManager) Ensure(ctx context.Context, obj runtime.Object) (bool,
 		return false, nil
 	}
 
-	if instance.Spec.ACL != nil {
+	// the ACL can't be updated while the instance is in a transient state
+	if instance.Spec.ACL != nil && rdbInstanceResp.Status == rdb.InstanceStatusReady {
 		err = m.updateACLs(ctx, instance, rdbInstanceResp)
 		if err != nil {
 			return false, err
 		}
 	}
+	if instance.Spec.ACL == nil {
+		instance.Status.ACL = nil
+	}
 
 	if rdbInstanceResp.Endpoint != nil {
 		instance.Status.Endpoint.IP = rdbInstanceResp.Endpoint.IP.String()
@@ -92,16 +104,37 @@ func (m *InstanceManager) Delete(ctx context.Context, obj runtime.Object) (bool,
 		return true, nil
 	}
 
+	instanceResp, err := m.API.GetInstance(&rdb.GetInstanceRequest{
+		Region:     region,
+		InstanceID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	// the instance can't be deleted again while its deletion is in progress
+	if instanceResp.Status == rdb.InstanceStatusDeleting {
+		return false, nil
+	}
+
+	if scaleway.PlanAction(ctx, "delete instance %s", resourceID) {
+		return false, nil
+	}
+
 	_, err = m.API.DeleteInstance(&rdb.DeleteInstanceRequest{
 		Region:     region,
 		InstanceID: resourceID,
-	})
+	}, scw.WithContext(ctx))
 	if err != nil {
 		if _, ok := err.(*scw.ResourceNotFoundError); ok {
 			return true, nil
 		}
 		return false, err
 	}
+	scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonInstanceDeleted, "Deleted instance %s", resourceID)
 
 	//instance.Status.Status = strcase.ToCamel(instanceResp.Status.String())
 
@@ -113,7 +146,7 @@ func (m *InstanceManager) GetOwners(ctx context.Context, obj runtime.Object) ([]
 	return nil, nil
 }
 
-func (m *InstanceManager) createInstance(ctx context.Context, instance *rdbv1alpha1.RDBInstance) error {
+func (m *InstanceManager) createInstance(ctx context.Context, instance *rdbv1beta1.RDBInstance) error {
 	region := scw.Region(instance.Spec.Region)
 
 	if instance.Spec.InstanceFrom != nil {
@@ -121,15 +154,19 @@ func (m *InstanceManager) createInstance(ctx context.Context, instance *rdbv1alp
 		if err != nil {
 			return err
 		}
+		if scaleway.PlanAction(ctx, "clone instance %s with node type %s", instanceID, instance.Spec.NodeType) {
+			return nil
+		}
 		rdbInstanceResp, err := m.API.CloneInstance(&rdb.CloneInstanceRequest{
 			Region:     region,
 			InstanceID: instanceID,
 			NodeType:   scw.StringPtr(instance.Spec.NodeType),
 			Name:       instance.Name,
-		})
+		}, scw.WithContext(ctx))
 		if err != nil {
 			return err
 		}
+		scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonInstanceCloned, "Cloned instance %s to instance %s", instanceID, rdbInstanceResp.ID)
 		instance.Spec.InstanceID = rdbInstanceResp.ID
 		instance.Spec.Region = rdbInstanceResp.Region.String()
 		err = m.Client.Update(ctx, instance)
@@ -155,10 +192,15 @@ func (m *InstanceManager) createInstance(ctx context.Context, instance *rdbv1alp
 		Tags:          utils.LabelsToTags(instance.Labels),
 	}
 
-	rdbInstanceResp, err := m.API.CreateInstance(createRequest)
+	if scaleway.PlanAction(ctx, "create instance with engine %s and node type %s", instance.Spec.Engine, instance.Spec.NodeType) {
+		return nil
+	}
+
+	rdbInstanceResp, err := m.API.CreateInstance(createRequest, scw.WithContext(ctx))
 	if err != nil {
 		return err
 	}
+	scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonInstanceCreated, "Created instance %s with engine %s and node type %s", rdbInstanceResp.ID, instance.Spec.Engine, instance.Spec.NodeType)
 	instance.Spec.InstanceID = rdbInstanceResp.ID
 	instance.Spec.Region = rdbInstanceResp.Region.String()
 	err = m.Client.Update(ctx, instance)
@@ -169,8 +211,9 @@ func (m *InstanceManager) createInstance(ctx context.Context, instance *rdbv1alp
 	return nil
 }
 
-func (m *InstanceManager) updateInstance(instance *rdbv1alpha1.RDBInstance, rdbInstance *rdb.Instance) (bool, error) {
+func (m *InstanceManager) updateInstance(ctx context.Context, instance *rdbv1beta1.RDBInstance, rdbInstance *rdb.Instance) (bool, error) {
 	needsUpdate := false
+	backupScheduleUpdated := false
 	updateRequest := &rdb.UpdateInstanceRequest{
 		Region:     scw.Region(instance.Spec.Region),
 		InstanceID: instance.Spec.InstanceID,
@@ -185,29 +228,41 @@ func (m *InstanceManager) updateInstance(instance *rdbv1alpha1.RDBInstance, rdbI
 		if instance.Spec.AutoBackup.Disabled != rdbInstance.BackupSchedule.Disabled {
 			updateRequest.IsBackupScheduleDisabled = scw.BoolPtr(instance.Spec.AutoBackup.Disabled)
 			needsUpdate = true
+			backupScheduleUpdated = true
 		}
 		if instance.Spec.AutoBackup.Frequency != nil && uint32(*instance.Spec.AutoBackup.Frequency) != rdbInstance.BackupSchedule.Frequency {
 			updateRequest.BackupScheduleFrequency = scw.Uint32Ptr(uint32(*instance.Spec.AutoBackup.Frequency))
 			needsUpdate = true
+			backupScheduleUpdated = true
 		}
 		if instance.Spec.AutoBackup.Retention != nil && uint32(*instance.Spec.AutoBackup.Retention) != rdbInstance.BackupSchedule.Retention {
 			updateRequest.BackupScheduleRetention = scw.Uint32Ptr(uint32(*instance.Spec.AutoBackup.Retention))
 			needsUpdate = true
+			backupScheduleUpdated = true
 		}
 	}
 
 	if needsUpdate {
-		_, err := m.API.UpdateInstance(updateRequest)
+		if scaleway.PlanAction(ctx, "update instance %s tags and backup schedule", instance.Spec.InstanceID) {
+			return false, nil
+		}
+		_, err := m.API.UpdateInstance(updateRequest, scw.WithContext(ctx))
 		if err != nil {
 			return false, err
 		}
+		if updateRequest.Tags != nil {
+			scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonInstanceUpdated, "Updated instance %s tags", instance.Spec.InstanceID)
+		}
+		if backupScheduleUpdated {
+			scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonBackupScheduleUpdated, "Updated instance %s backup schedule", instance.Spec.InstanceID)
+		}
 		return true, nil
 	}
 
 	return false, nil
 }
 
-func (m *InstanceManager) upgradeInstance(instance *rdbv1alpha1.RDBInstance, rdbInstance *rdb.Instance) (bool, error) {
+func (m *InstanceManager) upgradeInstance(ctx context.Context, instance *rdbv1beta1.RDBInstance, rdbInstance *rdb.Instance) (bool, error) {
 	upgradeRequest := &rdb.UpgradeInstanceRequest{
 		Region:     scw.Region(instance.Spec.Region),
 		InstanceID: instance.Spec.InstanceID,
@@ -215,112 +270,138 @@ func (m *InstanceManager) upgradeInstance(instance *rdbv1alpha1.RDBInstance, rdb
 
 	if rdbInstance.IsHaCluster != instance.Spec.IsHaCluster {
 		upgradeRequest.EnableHa = scw.BoolPtr(instance.Spec.IsHaCluster)
-		_, err := m.API.UpgradeInstance(upgradeRequest)
+		if scaleway.PlanAction(ctx, "upgrade instance %s to high availability", instance.Spec.InstanceID) {
+			return false, nil
+		}
+		_, err := m.API.UpgradeInstance(upgradeRequest, scw.WithContext(ctx))
 		if err != nil {
 			return false, err
 		}
+		scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonHAUpgradeStarted, "Started upgrade of instance %s to high availability", instance.Spec.InstanceID)
 		return true, nil
 	}
 
 	if rdbInstance.NodeType != instance.Spec.NodeType {
 		upgradeRequest.NodeType = scw.StringPtr(instance.Spec.NodeType)
-		_, err := m.API.UpgradeInstance(upgradeRequest)
+		if scaleway.PlanAction(ctx, "upgrade instance %s node type from %s to %s", instance.Spec.InstanceID, rdbInstance.NodeType, instance.Spec.NodeType) {
+			return false, nil
+		}
+		_, err := m.API.UpgradeInstance(upgradeRequest, scw.WithContext(ctx))
 		if err != nil {
 			return false, err
 		}
+		scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonNodeTypeUpgradeStarted, "Started upgrade of instance %s node type from %s to %s", instance.Spec.InstanceID, rdbInstance.NodeType, instance.Spec.NodeType)
 		return true, nil
 	}
 
 	return false, nil
 }
 
-func (m *InstanceManager) getNodesIP(ctx context.Context) ([]net.IPNet, error) {
-	var nodesIP []net.IPNet
+// SupportsDryRun returns whether the manager supports the dry-run mode
+func (m *InstanceManager) SupportsDryRun() bool {
+	return true
+}
+
+// GetDrift returns the fields of the RDB instance whose remote state differs from the spec
+func (m *InstanceManager) GetDrift(ctx context.Context, obj runtime.Object) ([]string, error) {
+	instance, err := convertInstance(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.Spec.InstanceID == "" {
+		return nil, nil
+	}
 
-	nodesList := corev1.NodeList{}
-	err := m.Client.List(ctx, &nodesList)
+	rdbInstance, err := m.API.GetInstance(&rdb.GetInstanceRequest{
+		Region:     scw.Region(instance.Spec.Region),
+		InstanceID: instance.Spec.InstanceID,
+	}, scw.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
-	for _, node := range nodesList.Items {
-		for _, addr := range node.Status.Addresses {
-			if addr.Type == corev1.NodeExternalIP || addr.Type == corev1.NodeInternalIP {
-				parsedIP := net.ParseIP(addr.Address)
-				if parsedIP != nil {
-					nodesIP = append(nodesIP, getIPNetFromIP(parsedIP))
-				}
-			}
+
+	drift := getInstanceDrift(instance, rdbInstance)
+
+	if instance.Spec.ACL != nil {
+		diff, err := m.getACLRulesDiff(ctx, instance)
+		if err != nil {
+			return nil, err
+		}
+		scaleway.CacheValue(ctx, getACLRulesDiffCacheKey(instance), diff)
+
+		if !diff.isEmpty() {
+			drift = append(drift, "spec.acl")
 		}
 	}
 
-	return nodesIP, nil
+	return drift, nil
 }
 
-func checkRulesUpdate(instance *rdbv1alpha1.RDBInstance, existingACLs *rdb.ListInstanceACLRulesResponse, nodesIP []net.IPNet) bool {
-	needRulesUpdate := len(existingACLs.Rules) != len(nodesIP)+len(instance.Spec.ACL.Rules)
-
-	if !needRulesUpdate {
-		for _, existingRule := range existingACLs.Rules {
-			foundRule := false
-			for _, wantedRule := range instance.Spec.ACL.Rules {
-				wantedRuleParsed := net.ParseIP(wantedRule.IPRange)
-				if wantedRuleParsed != nil && existingRule.IP.String() == wantedRuleParsed.String() {
-					foundRule = true
-					break
-				}
-			}
-			for _, nodeRule := range nodesIP {
-				if foundRule {
-					break
-				}
-
-				if nodeRule.String() == existingRule.IP.String() {
-					foundRule = true
-				}
-			}
-			if !foundRule {
-				needRulesUpdate = true
-			}
-		}
-	}
-
-	return needRulesUpdate
-}
+// getInstanceDrift returns the fields of the instance spec not matching the given RDB instance
+func getInstanceDrift(instance *rdbv1beta1.RDBInstance, rdbInstance *rdb.Instance) []string {
+	drift := []string{}
 
-func (m *InstanceManager) updateACLs(ctx context.Context, instance *rdbv1alpha1.RDBInstance, rdbInstance *rdb.Instance) error {
-	existingACLs, err := m.API.ListInstanceACLRules(&rdb.ListInstanceACLRulesRequest{
-		Region:     scw.Region(instance.Spec.Region),
-		InstanceID: instance.Spec.InstanceID,
-	}, scw.WithAllPages())
-	if err != nil {
-		return err
+	if !utils.CompareTagsLabels(rdbInstance.Tags, instance.Labels) {
+		drift = append(drift, "metadata.labels")
+	}
+
+	if instance.Spec.AutoBackup != nil && rdbInstance.BackupSchedule != nil {
+		if instance.Spec.AutoBackup.Disabled != rdbInstance.BackupSchedule.Disabled {
+			drift = append(drift, "spec.autoBackup.disabled")
+		}
+		if instance.Spec.AutoBackup.Frequency != nil && uint32(*instance.Spec.AutoBackup.Frequency) != rdbInstance.BackupSchedule.Frequency {
+			drift = append(drift, "spec.autoBackup.frequency")
+		}
+		if instance.Spec.AutoBackup.Retention != nil && uint32(*instance.Spec.AutoBackup.Retention) != rdbInstance.BackupSchedule.Retention {
+			drift = append(drift, "spec.autoBackup.retention")
+		}
 	}
 
-	var nodesIP []net.IPNet
+	if rdbInstance.IsHaCluster != instance.Spec.IsHaCluster {
+		drift = append(drift, "spec.isHaCluster")
+	}
 
-	if instance.Spec.ACL.AllowCluster {
-		nodesIP, err = m.getNodesIP(ctx)
+	if rdbInstance.NodeType != instance.Spec.NodeType {
+		drift = append(drift, "spec.nodeType")
+	}
+
+	return drift
+}
+
+func (m *InstanceManager) getNodesIP(ctx context.Context) ([]net.IPNet, error) {
+	return utils.GetNodesIP(ctx, m.Client)
+}
+
+// getWantedRules returns the ACL rules wanted for the instance, from the static rules,
+// the ACL sources and the cluster nodes
+// The rules are normalized and deduplicated, and prefixed when only owned rules are managed
+func (m *InstanceManager) getWantedRules(ctx context.Context, instance *rdbv1beta1.RDBInstance) ([]*rdb.ACLRuleRequest, error) {
+	rules := []*rdb.ACLRuleRequest{}
+	for _, wantedRule := range instance.Spec.ACL.Rules {
+		wantedRuleParsed, err := parseIPRanges([]string{wantedRule.IPRange})
 		if err != nil {
-			return err
+			m.Log.Error(err, "error parsing ip range, ignoring")
+			continue
 		}
+		rules = append(rules, &rdb.ACLRuleRequest{
+			IP: scw.IPNet{
+				IPNet: wantedRuleParsed[0],
+			},
+			Description: wantedRule.Description,
+		})
+	}
+
+	sourcesRules, err := m.getSourcesRules(ctx, instance)
+	if err != nil {
+		return nil, err
 	}
+	rules = append(rules, sourcesRules...)
 
-	if checkRulesUpdate(instance, existingACLs, nodesIP) {
-		rules := []*rdb.ACLRuleRequest{}
-		for _, wantedRule := range instance.Spec.ACL.Rules {
-			_, wantedRuleParsed, err := net.ParseCIDR(wantedRule.IPRange)
-			if err != nil {
-				m.Log.Error(err, "error parsing ip range, ignoring")
-				continue
-			}
-			if wantedRuleParsed != nil {
-				rules = append(rules, &rdb.ACLRuleRequest{
-					IP: scw.IPNet{
-						IPNet: *wantedRuleParsed,
-					},
-					Description: wantedRule.Description,
-				})
-			}
+	if instance.Spec.ACL.AllowCluster {
+		nodesIP, err := m.getNodesIP(ctx)
+		if err != nil {
+			return nil, err
 		}
 		for _, nodeIP := range nodesIP {
 			rules = append(rules, &rdb.ACLRuleRequest{
@@ -330,55 +411,254 @@ func (m *InstanceManager) updateACLs(ctx context.Context, instance *rdbv1alpha1.
 				Description: "Kuberentes node",
 			})
 		}
-		_, err = m.API.SetInstanceACLRules(&rdb.SetInstanceACLRulesRequest{
-			Region:     scw.Region(instance.Spec.Region),
-			InstanceID: instance.Spec.InstanceID,
-			Rules:      rules,
-		})
-		if err != nil {
-			return err
+	}
+
+	// the same range may be wanted several times, for instance by a rule and a source
+	dedupedRules := []*rdb.ACLRuleRequest{}
+	seen := map[string]bool{}
+	for _, rule := range rules {
+		key := aclRuleKey(rule.IP)
+		if seen[key] {
+			continue
 		}
+		seen[key] = true
+		if isACLOwnershipOwned(instance) {
+			rule.Description = ACLRuleDescriptionPrefix + rule.Description
+		}
+		dedupedRules = append(dedupedRules, rule)
 	}
 
-	return nil
+	return dedupedRules, nil
 }
 
-func convertInstance(obj runtime.Object) (*rdbv1alpha1.RDBInstance, error) {
-	instance, ok := obj.(*rdbv1alpha1.RDBInstance)
-	if !ok {
-		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+// isACLOwnershipOwned returns whether only the rules created by the operator are managed
+func isACLOwnershipOwned(instance *rdbv1beta1.RDBInstance) bool {
+	return instance.Spec.ACL.Ownership == rdbv1beta1.RDBACLOwnershipOwned
+}
+
+// isOwnedACLRule returns whether the given rule has been created by the operator
+func isOwnedACLRule(rule *rdb.ACLRule) bool {
+	return strings.HasPrefix(rule.Description, ACLRuleDescriptionPrefix)
+}
+
+// aclRuleKey returns the normalized CIDR of an ACL rule range
+func aclRuleKey(ipNet scw.IPNet) string {
+	ip := ipNet.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
 	}
-	return instance, nil
+	mask := ipNet.Mask
+	if mask == nil {
+		mask = utils.GetIPNetFromIP(ip).Mask
+	}
+	return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+}
+
+// diffACLRules returns the wanted rules missing from the existing ones, and the existing
+// rules not wanted anymore
+// Only the existing rules matched by managed are removed, the other ones are left untouched
+// and wanted rules with the same range are not added again
+func diffACLRules(existingRules []*rdb.ACLRule, wantedRules []*rdb.ACLRuleRequest, managed func(*rdb.ACLRule) bool) ([]*rdb.ACLRuleRequest, []*rdb.ACLRule) {
+	wanted := map[string]*rdb.ACLRuleRequest{}
+	for _, wantedRule := range wantedRules {
+		wanted[aclRuleKey(wantedRule.IP)] = wantedRule
+	}
+
+	existing := map[string]bool{}
+	toDelete := []*rdb.ACLRule{}
+	for _, existingRule := range existingRules {
+		key := aclRuleKey(existingRule.IP)
+		if !managed(existingRule) {
+			existing[key] = true
+			continue
+		}
+		wantedRule, ok := wanted[key]
+		if !ok || wantedRule.Description != existingRule.Description {
+			toDelete = append(toDelete, existingRule)
+			continue
+		}
+		existing[key] = true
+	}
+
+	toAdd := []*rdb.ACLRuleRequest{}
+	for _, wantedRule := range wantedRules {
+		if !existing[aclRuleKey(wantedRule.IP)] {
+			toAdd = append(toAdd, wantedRule)
+		}
+	}
+
+	return toAdd, toDelete
+}
+
+// aclRulesDiff is the difference between the existing and the wanted rules of an instance ACL
+type aclRulesDiff struct {
+	existing []*rdb.ACLRule
+	wanted   []*rdb.ACLRuleRequest
+	toAdd    []*rdb.ACLRuleRequest
+	toDelete []*rdb.ACLRule
+}
+
+// isEmpty returns whether the existing rules match the wanted ones
+func (d *aclRulesDiff) isEmpty() bool {
+	return len(d.toAdd) == 0 && len(d.toDelete) == 0
 }
 
-func getIPNetFromIP(ip net.IP) net.IPNet {
-	ipNet := net.IPNet{
-		IP: ip,
+// getACLRulesDiff returns the difference between the existing and the wanted rules of the instance ACL
+func (m *InstanceManager) getACLRulesDiff(ctx context.Context, instance *rdbv1beta1.RDBInstance) (*aclRulesDiff, error) {
+	existingACLs, err := m.API.ListInstanceACLRules(&rdb.ListInstanceACLRulesRequest{
+		Region:     scw.Region(instance.Spec.Region),
+		InstanceID: instance.Spec.InstanceID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
 	}
-	if ip.To4() != nil {
-		ipNet.Mask = net.CIDRMask(32, 32)
-	} else {
-		ipNet.Mask = net.CIDRMask(128, 128)
+
+	wantedRules, err := m.getWantedRules(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	managed := func(*rdb.ACLRule) bool { return true }
+	if isACLOwnershipOwned(instance) {
+		managed = isOwnedACLRule
 	}
-	return ipNet
+
+	toAdd, toDelete := diffACLRules(existingACLs.Rules, wantedRules, managed)
+	return &aclRulesDiff{
+		existing: existingACLs.Rules,
+		wanted:   wantedRules,
+		toAdd:    toAdd,
+		toDelete: toDelete,
+	}, nil
+}
+
+// getACLRulesDiffCacheKey returns the key of the ACL rules diff of the instance in the reconcile cache
+func getACLRulesDiffCacheKey(instance *rdbv1beta1.RDBInstance) string {
+	return "rdb/acl/" + instance.Spec.Region + "/" + instance.Spec.InstanceID
 }
 
-func (m *InstanceManager) getInstanceFromIDAndRegion(ctx context.Context, instance *rdbv1alpha1.RDBInstance) (string, scw.Region, error) {
-	instanceFrom := &rdbv1alpha1.RDBInstance{}
+// getCachedACLRulesDiff returns the ACL rules diff of the instance computed earlier in the reconcile
+func getCachedACLRulesDiff(ctx context.Context, instance *rdbv1beta1.RDBInstance) (*aclRulesDiff, bool) {
+	value, ok := scaleway.TakeCachedValue(ctx, getACLRulesDiffCacheKey(instance))
+	if !ok {
+		return nil, false
+	}
+	diff, ok := value.(*aclRulesDiff)
+	return diff, ok
+}
 
-	if instance.Spec.InstanceFrom.Name != "" {
-		instanceNamespace := instance.Spec.InstanceFrom.Namespace
-		if instanceNamespace == "" {
-			instanceNamespace = instance.Namespace
+// updateACLs updates the instance ACL to match the wanted rules, either by replacing
+// the whole ACL or, when only owned rules are managed, by adding and deleting rules
+// The diff computed while detecting the drift in the same reconcile is reused
+func (m *InstanceManager) updateACLs(ctx context.Context, instance *rdbv1beta1.RDBInstance, rdbInstance *rdb.Instance) error {
+	var err error
+	diff, ok := getCachedACLRulesDiff(ctx, instance)
+	if !ok {
+		diff, err = m.getACLRulesDiff(ctx, instance)
+		if err != nil {
+			return err
 		}
+	}
 
-		err := m.Get(ctx, client.ObjectKey{Name: instance.Spec.InstanceFrom.Name, Namespace: instanceNamespace}, instanceFrom)
+	if diff.isEmpty() {
+		setStatusACL(instance, diff.existing)
+		return nil
+	}
+
+	if !isACLOwnershipOwned(instance) {
+		if scaleway.PlanAction(ctx, "set %d acl rules on instance %s", len(diff.wanted), instance.Spec.InstanceID) {
+			setStatusACL(instance, diff.existing)
+			return nil
+		}
+		resp, err := m.API.SetInstanceACLRules(&rdb.SetInstanceACLRulesRequest{
+			Region:     scw.Region(instance.Spec.Region),
+			InstanceID: instance.Spec.InstanceID,
+			Rules:      diff.wanted,
+		}, scw.WithContext(ctx))
 		if err != nil {
-			return "", "", err
+			return err
 		}
+		scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonACLUpdated, "Set %d acl rules on instance %s", len(diff.wanted), instance.Spec.InstanceID)
+		setStatusACL(instance, resp.Rules)
+		return nil
+	}
+
+	effectiveRules := diff.existing
 
-		return instanceFrom.Spec.InstanceID, scw.Region(instanceFrom.Spec.Region), nil
+	if len(diff.toDelete) > 0 && !scaleway.PlanAction(ctx, "delete %d acl rules on instance %s", len(diff.toDelete), instance.Spec.InstanceID) {
+		ips := []string{}
+		for _, rule := range diff.toDelete {
+			ips = append(ips, rule.IP.String())
+		}
+		_, err = m.API.DeleteInstanceACLRules(&rdb.DeleteInstanceACLRulesRequest{
+			Region:     scw.Region(instance.Spec.Region),
+			InstanceID: instance.Spec.InstanceID,
+			ACLRuleIPs: ips,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonACLUpdated, "Deleted %d acl rules on instance %s", len(diff.toDelete), instance.Spec.InstanceID)
+		effectiveRules = removeACLRules(effectiveRules, diff.toDelete)
 	}
 
-	return instance.Spec.InstanceFrom.ExternalID, scw.Region(instance.Spec.InstanceFrom.Region), nil
+	if len(diff.toAdd) > 0 && !scaleway.PlanAction(ctx, "add %d acl rules on instance %s", len(diff.toAdd), instance.Spec.InstanceID) {
+		_, err = m.API.AddInstanceACLRules(&rdb.AddInstanceACLRulesRequest{
+			Region:     scw.Region(instance.Spec.Region),
+			InstanceID: instance.Spec.InstanceID,
+			Rules:      diff.toAdd,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		scaleway.RecordEvent(ctx, instance, corev1.EventTypeNormal, scaleway.ReasonACLUpdated, "Added %d acl rules on instance %s", len(diff.toAdd), instance.Spec.InstanceID)
+		for _, rule := range diff.toAdd {
+			effectiveRules = append(effectiveRules, &rdb.ACLRule{
+				IP:          rule.IP,
+				Description: rule.Description,
+			})
+		}
+	}
+
+	setStatusACL(instance, effectiveRules)
+
+	return nil
+}
+
+// removeACLRules returns the given rules without the removed ones
+func removeACLRules(rules []*rdb.ACLRule, removed []*rdb.ACLRule) []*rdb.ACLRule {
+	removedKeys := map[string]bool{}
+	for _, rule := range removed {
+		removedKeys[aclRuleKey(rule.IP)] = true
+	}
+	remaining := []*rdb.ACLRule{}
+	for _, rule := range rules {
+		if !removedKeys[aclRuleKey(rule.IP)] {
+			remaining = append(remaining, rule)
+		}
+	}
+	return remaining
+}
+
+// setStatusACL records the effective rules of the instance ACL in its status
+func setStatusACL(instance *rdbv1beta1.RDBInstance, rules []*rdb.ACLRule) {
+	instance.Status.ACL = nil
+	for _, rule := range rules {
+		instance.Status.ACL = append(instance.Status.ACL, scalewaymetav1alpha1.ACLRule{
+			IPRange:     aclRuleKey(rule.IP),
+			Description: rule.Description,
+		})
+	}
+}
+
+func convertInstance(obj runtime.Object) (*rdbv1beta1.RDBInstance, error) {
+	instance, ok := obj.(*rdbv1beta1.RDBInstance)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return instance, nil
+}
+
+func (m *InstanceManager) getInstanceFromIDAndRegion(ctx context.Context, instance *rdbv1beta1.RDBInstance) (string, scw.Region, error) {
+	return getInstanceRefIDAndRegion(ctx, m.Client, *instance.Spec.InstanceFrom, instance.Namespace)
 }
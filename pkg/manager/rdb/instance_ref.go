@@ -0,0 +1,67 @@
+package rdb
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+)
+
+// getInstanceRefKey returns the key of the RDBInstance object referenced by ref
+// The namespace defaults to the namespace of the referencing object
+func getInstanceRefKey(ref rdbv1beta1.RDBInstanceRef, namespace string) client.ObjectKey {
+	if ref.Object.Namespace != "" {
+		namespace = ref.Object.Namespace
+	}
+	return client.ObjectKey{Name: ref.Object.Name, Namespace: namespace}
+}
+
+// getInstanceRefIDAndRegion returns the ID and region of the instance referenced by ref
+func getInstanceRefIDAndRegion(ctx context.Context, c client.Client, ref rdbv1beta1.RDBInstanceRef, namespace string) (string, scw.Region, error) {
+	if ref.Object != nil {
+		instance := &rdbv1beta1.RDBInstance{}
+		err := c.Get(ctx, getInstanceRefKey(ref, namespace), instance)
+		if err != nil {
+			return "", "", err
+		}
+
+		return instance.Spec.InstanceID, scw.Region(instance.Spec.Region), nil
+	}
+
+	if ref.External != nil {
+		return ref.External.ID, scw.Region(ref.External.Region), nil
+	}
+
+	return "", "", nil
+}
+
+// getInstanceRefOwners returns the RDBInstance object referenced by ref as owner
+func getInstanceRefOwners(ctx context.Context, c client.Client, ref rdbv1beta1.RDBInstanceRef, namespace string) ([]scaleway.Owner, error) {
+	if ref.Object == nil {
+		return nil, nil
+	}
+
+	key := getInstanceRefKey(ref, namespace)
+
+	err := c.Get(ctx, key, &rdbv1beta1.RDBInstance{})
+	if err != nil {
+		return nil, err
+	}
+
+	return []scaleway.Owner{
+		{
+			Key:    key,
+			Object: &rdbv1beta1.RDBInstance{},
+		},
+	}, nil
+}
+
+// defaultInstanceRef sets the region of a reference to an external instance
+func defaultInstanceRef(ref *rdbv1beta1.RDBInstanceRef, defaultRegion scw.Region) {
+	if ref.External != nil && ref.External.Region == "" {
+		ref.External.Region = defaultRegion.String()
+	}
+}
@@ -0,0 +1,234 @@
+package rdb
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+)
+
+func Test_getInstanceDrift(t *testing.T) {
+	frequency := int32(24)
+	retention := int32(7)
+
+	instance := &rdbv1beta1.RDBInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app": "db"},
+		},
+		Spec: rdbv1beta1.RDBInstanceSpec{
+			NodeType:    "db-dev-s",
+			IsHaCluster: true,
+			AutoBackup: &rdbv1beta1.RDBInstanceAutoBackup{
+				Frequency: &frequency,
+				Retention: &retention,
+			},
+		},
+	}
+
+	cases := []struct {
+		rdbInstance *rdb.Instance
+		drift       []string
+	}{
+		{
+			rdbInstance: &rdb.Instance{
+				Tags:           []string{"app=db"},
+				NodeType:       "db-dev-s",
+				IsHaCluster:    true,
+				BackupSchedule: &rdb.BackupSchedule{Frequency: 24, Retention: 7},
+			},
+			drift: []string{},
+		},
+		{
+			rdbInstance: &rdb.Instance{
+				Tags:           []string{"app=db", "manual"},
+				NodeType:       "db-gp-xs",
+				IsHaCluster:    true,
+				BackupSchedule: &rdb.BackupSchedule{Frequency: 24, Retention: 7},
+			},
+			drift: []string{"metadata.labels", "spec.nodeType"},
+		},
+		{
+			rdbInstance: &rdb.Instance{
+				Tags:           []string{"app=db"},
+				NodeType:       "db-dev-s",
+				IsHaCluster:    false,
+				BackupSchedule: &rdb.BackupSchedule{Disabled: true, Frequency: 12, Retention: 7},
+			},
+			drift: []string{"spec.autoBackup.disabled", "spec.autoBackup.frequency", "spec.isHaCluster"},
+		},
+	}
+
+	for i, c := range cases {
+		drift := getInstanceDrift(instance, c.rdbInstance)
+		if !reflect.DeepEqual(drift, c.drift) {
+			t.Errorf("case %d: got %v instead of %v", i, drift, c.drift)
+		}
+	}
+}
+
+func Test_defaultInstance(t *testing.T) {
+	frequency := int32(12)
+	defaultFrequency := int32(24)
+	defaultRetention := int32(7)
+
+	cases := []struct {
+		spec     rdbv1beta1.RDBInstanceSpec
+		expected rdbv1beta1.RDBInstanceSpec
+	}{
+		{
+			spec:     rdbv1beta1.RDBInstanceSpec{},
+			expected: rdbv1beta1.RDBInstanceSpec{Region: "fr-par"},
+		},
+		{
+			spec:     rdbv1beta1.RDBInstanceSpec{Region: "nl-ams"},
+			expected: rdbv1beta1.RDBInstanceSpec{Region: "nl-ams"},
+		},
+		{
+			spec: rdbv1beta1.RDBInstanceSpec{
+				InstanceFrom: &rdbv1beta1.RDBInstanceRef{Object: &rdbv1beta1.RDBInstanceObjectRef{Name: "source"}},
+			},
+			expected: rdbv1beta1.RDBInstanceSpec{
+				InstanceFrom: &rdbv1beta1.RDBInstanceRef{Object: &rdbv1beta1.RDBInstanceObjectRef{Name: "source"}},
+			},
+		},
+		{
+			spec: rdbv1beta1.RDBInstanceSpec{
+				InstanceFrom: &rdbv1beta1.RDBInstanceRef{External: &rdbv1beta1.RDBInstanceExternalRef{ID: "11111111-1111-1111-1111-111111111111"}},
+			},
+			expected: rdbv1beta1.RDBInstanceSpec{
+				InstanceFrom: &rdbv1beta1.RDBInstanceRef{External: &rdbv1beta1.RDBInstanceExternalRef{ID: "11111111-1111-1111-1111-111111111111", Region: "fr-par"}},
+			},
+		},
+		{
+			spec: rdbv1beta1.RDBInstanceSpec{
+				Region:     "fr-par",
+				AutoBackup: &rdbv1beta1.RDBInstanceAutoBackup{Frequency: &frequency},
+			},
+			expected: rdbv1beta1.RDBInstanceSpec{
+				Region:     "fr-par",
+				AutoBackup: &rdbv1beta1.RDBInstanceAutoBackup{Frequency: &frequency, Retention: &defaultRetention},
+			},
+		},
+		{
+			spec: rdbv1beta1.RDBInstanceSpec{
+				Region:     "fr-par",
+				AutoBackup: &rdbv1beta1.RDBInstanceAutoBackup{Disabled: true},
+			},
+			expected: rdbv1beta1.RDBInstanceSpec{
+				Region:     "fr-par",
+				AutoBackup: &rdbv1beta1.RDBInstanceAutoBackup{Disabled: true, Frequency: &defaultFrequency, Retention: &defaultRetention},
+			},
+		},
+		{
+			spec: rdbv1beta1.RDBInstanceSpec{
+				Region: "fr-par",
+				ACL: &rdbv1beta1.RDBACL{
+					Rules: []scalewaymetav1alpha1.ACLRule{
+						{IPRange: "1.2.3.4"},
+						{IPRange: "10.1.2.3/24"},
+						{IPRange: "2001:db8::1"},
+						{IPRange: "::ffff:1.2.3.4"},
+						{IPRange: "invalid"},
+					},
+				},
+			},
+			expected: rdbv1beta1.RDBInstanceSpec{
+				Region: "fr-par",
+				ACL: &rdbv1beta1.RDBACL{
+					Rules: []scalewaymetav1alpha1.ACLRule{
+						{IPRange: "1.2.3.4/32"},
+						{IPRange: "10.1.2.0/24"},
+						{IPRange: "2001:db8::1/128"},
+						{IPRange: "1.2.3.4/32"},
+						{IPRange: "invalid"},
+					},
+					Ownership: rdbv1beta1.RDBACLOwnershipFull,
+				},
+			},
+		},
+	}
+
+	for i, c := range cases {
+		instance := &rdbv1beta1.RDBInstance{Spec: c.spec}
+		defaultInstance(instance, scw.RegionFrPar)
+		if !reflect.DeepEqual(instance.Spec, c.expected) {
+			t.Errorf("case %d: got %+v instead of %+v", i, instance.Spec, c.expected)
+		}
+	}
+}
+
+func Test_diffACLRules(t *testing.T) {
+	newRule := func(ipRange string, description string) *rdb.ACLRule {
+		_, ipNet, _ := net.ParseCIDR(ipRange)
+		return &rdb.ACLRule{IP: scw.IPNet{IPNet: *ipNet}, Description: description}
+	}
+	newRequest := func(ipRange string, description string) *rdb.ACLRuleRequest {
+		_, ipNet, _ := net.ParseCIDR(ipRange)
+		return &rdb.ACLRuleRequest{IP: scw.IPNet{IPNet: *ipNet}, Description: description}
+	}
+	all := func(*rdb.ACLRule) bool { return true }
+
+	cases := []struct {
+		existing []*rdb.ACLRule
+		wanted   []*rdb.ACLRuleRequest
+		managed  func(*rdb.ACLRule) bool
+		toAdd    []string
+		toDelete []string
+	}{
+		{
+			existing: []*rdb.ACLRule{newRule("10.0.0.0/24", ""), newRule("1.2.3.4/32", "")},
+			wanted:   []*rdb.ACLRuleRequest{newRequest("1.2.3.4/32", ""), newRequest("10.0.0.0/24", "")},
+			managed:  all,
+		},
+		{
+			existing: []*rdb.ACLRule{newRule("10.0.0.0/24", "")},
+			wanted:   []*rdb.ACLRuleRequest{newRequest("10.0.0.0/16", "")},
+			managed:  all,
+			toAdd:    []string{"10.0.0.0/16"},
+			toDelete: []string{"10.0.0.0/24"},
+		},
+		{
+			existing: []*rdb.ACLRule{newRule("10.0.0.0/24", "old")},
+			wanted:   []*rdb.ACLRuleRequest{newRequest("10.0.0.0/24", "new")},
+			managed:  all,
+			toAdd:    []string{"10.0.0.0/24"},
+			toDelete: []string{"10.0.0.0/24"},
+		},
+		{
+			existing: []*rdb.ACLRule{newRule("10.0.0.0/24", "other tool"), newRule("1.2.3.4/32", ACLRuleDescriptionPrefix)},
+			wanted:   []*rdb.ACLRuleRequest{newRequest("5.6.7.8/32", ACLRuleDescriptionPrefix)},
+			managed:  isOwnedACLRule,
+			toAdd:    []string{"5.6.7.8/32"},
+			toDelete: []string{"1.2.3.4/32"},
+		},
+		{
+			existing: []*rdb.ACLRule{newRule("10.0.0.0/24", "other tool")},
+			wanted:   []*rdb.ACLRuleRequest{newRequest("10.0.0.0/24", ACLRuleDescriptionPrefix)},
+			managed:  isOwnedACLRule,
+		},
+	}
+
+	for i, c := range cases {
+		toAdd, toDelete := diffACLRules(c.existing, c.wanted, c.managed)
+		var toAddRanges []string
+		for _, rule := range toAdd {
+			toAddRanges = append(toAddRanges, rule.IP.String())
+		}
+		var toDeleteRanges []string
+		for _, rule := range toDelete {
+			toDeleteRanges = append(toDeleteRanges, rule.IP.String())
+		}
+		if !reflect.DeepEqual(toAddRanges, c.toAdd) {
+			t.Errorf("case %d: got %v instead of %v", i, toAddRanges, c.toAdd)
+		}
+		if !reflect.DeepEqual(toDeleteRanges, c.toDelete) {
+			t.Errorf("case %d: got %v instead of %v", i, toDeleteRanges, c.toDelete)
+		}
+	}
+}
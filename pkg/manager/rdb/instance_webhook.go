@@ -3,12 +3,68 @@ package rdb
 import (
 	"context"
 
+	"github.com/scaleway/scaleway-operator/pkg/utils"
 	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+)
+
+const (
+	defaultBackupFrequency int32 = 24
+	defaultBackupRetention int32 = 7
 )
 
+// Default sets the default values of a RDB Instance
+func (m *InstanceManager) Default(ctx context.Context, obj runtime.Object) error {
+	instance, err := convertInstance(obj)
+	if err != nil {
+		return err
+	}
+
+	defaultInstance(instance, m.DefaultRegion)
+
+	return nil
+}
+
+// defaultInstance sets the region when the instance is not created from another one,
+// the auto backup schedule, the ACL ownership and the CIDR notation of the ACL rules
+func defaultInstance(instance *rdbv1beta1.RDBInstance, defaultRegion scw.Region) {
+	// the region of an instance created from another one is the region of the source
+	if instance.Spec.Region == "" && instance.Spec.InstanceFrom == nil {
+		instance.Spec.Region = defaultRegion.String()
+	}
+
+	if instance.Spec.InstanceFrom != nil {
+		defaultInstanceRef(instance.Spec.InstanceFrom, defaultRegion)
+	}
+
+	if instance.Spec.AutoBackup != nil {
+		if instance.Spec.AutoBackup.Frequency == nil {
+			frequency := defaultBackupFrequency
+			instance.Spec.AutoBackup.Frequency = &frequency
+		}
+		if instance.Spec.AutoBackup.Retention == nil {
+			retention := defaultBackupRetention
+			instance.Spec.AutoBackup.Retention = &retention
+		}
+	}
+
+	if instance.Spec.ACL != nil {
+		if instance.Spec.ACL.Ownership == "" {
+			instance.Spec.ACL.Ownership = rdbv1beta1.RDBACLOwnershipFull
+		}
+		for i, rule := range instance.Spec.ACL.Rules {
+			// invalid ranges are left untouched and ignored by the manager
+			if ipRange, err := utils.NormalizeIPRange(rule.IPRange); err == nil {
+				instance.Spec.ACL.Rules[i].IPRange = ipRange
+			}
+		}
+	}
+}
+
 // ValidateCreate validates the creation of a RDB Instance
 func (m *InstanceManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
 	var allErrs field.ErrorList
@@ -23,9 +79,11 @@ func (m *InstanceManager) ValidateCreate(ctx context.Context, obj runtime.Object
 		return allErrs, nil // stop validation here since future calls will fail
 	}
 
+	allErrs = append(allErrs, validateACLSources(instance)...)
+
 	enginesResp, err := m.API.ListDatabaseEngines(&rdb.ListDatabaseEnginesRequest{
 		Region: scw.Region(instance.Spec.Region),
-	}, scw.WithAllPages())
+	}, scw.WithAllPages(), scw.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +92,7 @@ func (m *InstanceManager) ValidateCreate(ctx context.Context, obj runtime.Object
 		rdbInstance, err := m.API.GetInstance(&rdb.GetInstanceRequest{
 			Region:     scw.Region(instance.Spec.Region),
 			InstanceID: instance.Spec.InstanceID,
-		})
+		}, scw.WithContext(ctx))
 		if err != nil {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("instanceID"), instance.Spec.InstanceID, err.Error()))
 			return allErrs, nil
@@ -101,6 +159,8 @@ func (m *InstanceManager) ValidateUpdate(ctx context.Context, oldObj runtime.Obj
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("isHaCluster"), instance.Spec.Engine, "HA instance can't be downgraded"))
 	}
 
+	allErrs = append(allErrs, validateACLSources(instance)...)
+
 	if oldInstance.Spec.NodeType != instance.Spec.NodeType {
 		nodeTypeErrs, err := m.checkNodeType(ctx, scw.Region(instance.Spec.Region), instance.Spec.NodeType)
 		if err != nil {
@@ -112,12 +172,59 @@ func (m *InstanceManager) ValidateUpdate(ctx context.Context, oldObj runtime.Obj
 	return allErrs, nil
 }
 
+// validateACLSources checks that each ACL source references exactly one object
+func validateACLSources(instance *rdbv1beta1.RDBInstance) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if instance.Spec.ACL == nil {
+		return nil
+	}
+
+	for i, source := range instance.Spec.ACL.Sources {
+		sourcePath := field.NewPath("spec").Child("acl").Child("sources").Index(i)
+
+		specified := 0
+		if source.Service != nil {
+			specified++
+		}
+		if source.ConfigMap != nil {
+			specified++
+		}
+		if source.RDBInstance != nil {
+			specified++
+		}
+		if source.PublicGateway != nil {
+			specified++
+		}
+		if source.FlexibleIP != nil {
+			specified++
+		}
+		if specified != 1 {
+			allErrs = append(allErrs, field.Invalid(sourcePath, source, "exactly one of service, configMap, rdbInstance, publicGateway and flexibleIP must be specified"))
+			continue
+		}
+
+		_, ref := GetACLSourceKindAndRef(source)
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(sourcePath, "name of the referenced object must be specified"))
+		}
+		if source.ConfigMap != nil && source.ConfigMap.Key == "" {
+			allErrs = append(allErrs, field.Required(sourcePath.Child("configMap").Child("key"), "key must be specified"))
+		}
+		if source.RDBInstance != nil && source.RDBInstance.Name == instance.Name && (source.RDBInstance.Namespace == "" || source.RDBInstance.Namespace == instance.Namespace) {
+			allErrs = append(allErrs, field.Invalid(sourcePath.Child("rdbInstance"), source.RDBInstance.Name, "instance can't reference itself"))
+		}
+	}
+
+	return allErrs
+}
+
 func (m *InstanceManager) checkNodeType(ctx context.Context, region scw.Region, instanceNodeType string) (field.ErrorList, error) {
 	var allErrs field.ErrorList
 
 	nodeTypesResp, err := m.API.ListNodeTypes(&rdb.ListNodeTypesRequest{
 		Region: scw.Region(region),
-	}, scw.WithAllPages())
+	}, scw.WithAllPages(), scw.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,284 @@
+package rdb
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	scalewaymetav1alpha1 "github.com/scaleway/scaleway-operator/apis/meta/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+	"github.com/scaleway/scaleway-operator/internal/testhelpers/fakerdb"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+)
+
+func newFakeManagers(t *testing.T, objs ...runtime.Object) (*fakerdb.Server, client.Client, *InstanceManager, *DatabaseManager, *UserManager) {
+	server := fakerdb.NewServer()
+
+	scwClient, err := server.Client()
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = rdbv1beta1.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme, objs...)
+
+	api := rdb.NewAPI(scwClient)
+	return server,
+		c,
+		&InstanceManager{Client: c, API: api, Log: logf.Log},
+		&DatabaseManager{Client: c, API: api},
+		&UserManager{Client: c, API: api}
+}
+
+// ensureUntilReconciled calls Ensure until the object is reconciled, failing after maxCalls
+func ensureUntilReconciled(t *testing.T, ensure func(context.Context, runtime.Object) (bool, error), obj runtime.Object, maxCalls int) {
+	for i := 0; i < maxCalls; i++ {
+		reconciled, err := ensure(context.Background(), obj)
+		if err != nil {
+			t.Fatalf("call %d: got unexpected error %v", i, err)
+		}
+		if reconciled {
+			return
+		}
+	}
+	t.Fatalf("object not reconciled after %d calls", maxCalls)
+}
+
+func Test_Managers(t *testing.T) {
+	instance := &rdbv1beta1.RDBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+		Spec: rdbv1beta1.RDBInstanceSpec{
+			Engine:   "PostgreSQL-12",
+			NodeType: "db-dev-s",
+			Region:   "fr-par",
+		},
+	}
+	database := &rdbv1beta1.RDBDatabase{
+		ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "default"},
+		Spec: rdbv1beta1.RDBDatabaseSpec{
+			InstanceRef: rdbv1beta1.RDBInstanceRef{Object: &rdbv1beta1.RDBInstanceObjectRef{Name: "instance"}},
+		},
+	}
+
+	password := "password"
+	user := &rdbv1beta1.RDBUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "default"},
+		Spec: rdbv1beta1.RDBUserSpec{
+			UserName:    "user",
+			Password:    rdbv1beta1.RDBUserPassword{Value: &password},
+			InstanceRef: rdbv1beta1.RDBInstanceRef{Object: &rdbv1beta1.RDBInstanceObjectRef{Name: "instance"}},
+		},
+	}
+
+	server, c, instanceManager, databaseManager, userManager := newFakeManagers(t, instance, database, user)
+	defer server.Close()
+	ctx := context.Background()
+
+	ensureUntilReconciled(t, instanceManager.Ensure, instance, 5)
+	if instance.Spec.InstanceID == "" {
+		t.Fatalf("instance ID not set")
+	}
+	if instance.Status.Endpoint.IP == "" {
+		t.Errorf("instance endpoint not set")
+	}
+
+	// the instance ID is persisted for the databases to find it
+	err := c.Get(ctx, client.ObjectKey{Name: "instance", Namespace: "default"}, &rdbv1beta1.RDBInstance{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ensureUntilReconciled(t, databaseManager.Ensure, database, 2)
+
+	_, err = userManager.Ensure(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdbUser, err := userManager.getByName(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rdbUser == nil {
+		t.Errorf("user not created")
+	}
+
+	instance.Spec.NodeType = "db-dev-m"
+	ensureUntilReconciled(t, instanceManager.Ensure, instance, 5)
+	rdbInstance, _ := server.Instance(instance.Spec.InstanceID)
+	if rdbInstance.NodeType != "db-dev-m" {
+		t.Errorf("got node type %s instead of db-dev-m", rdbInstance.NodeType)
+	}
+
+	server.LockInstance(instance.Spec.InstanceID)
+	instance.Spec.NodeType = "db-gp-xs"
+	_, err = instanceManager.Ensure(ctx, instance)
+	if _, ok := err.(*scw.ResourceLockedError); !ok {
+		t.Errorf("got error %v instead of a resource locked error", err)
+	}
+
+	other := &rdbv1beta1.RDBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		Spec: rdbv1beta1.RDBInstanceSpec{
+			Engine:   "PostgreSQL-12",
+			NodeType: "db-dev-s",
+			Region:   "fr-par",
+		},
+	}
+	server.InstanceQuota = 1
+	_, err = instanceManager.Ensure(ctx, other)
+	if _, ok := err.(*scw.QuotasExceededError); !ok {
+		t.Errorf("got error %v instead of a quotas exceeded error", err)
+	}
+}
+
+func Test_Managers_Delete(t *testing.T) {
+	instance := &rdbv1beta1.RDBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+		Spec: rdbv1beta1.RDBInstanceSpec{
+			Engine:   "PostgreSQL-12",
+			NodeType: "db-dev-s",
+			Region:   "fr-par",
+		},
+	}
+
+	server, _, instanceManager, _, _ := newFakeManagers(t, instance)
+	defer server.Close()
+	ctx := context.Background()
+
+	ensureUntilReconciled(t, instanceManager.Ensure, instance, 5)
+
+	// the instance stays in the deleting state, reported as a transient state, for a few calls
+	deleted := false
+	for i := 0; i < 5 && !deleted; i++ {
+		var err error
+		deleted, err = instanceManager.Delete(ctx, instance)
+		if _, ok := err.(*scw.TransientStateError); err != nil && !ok {
+			t.Fatalf("call %d: got unexpected error %v", i, err)
+		}
+		if !deleted {
+			_, _ = instanceManager.API.GetInstance(&rdb.GetInstanceRequest{
+				Region:     scw.Region(instance.Spec.Region),
+				InstanceID: instance.Spec.InstanceID,
+			})
+		}
+	}
+	if !deleted {
+		t.Errorf("instance not deleted")
+	}
+	if len(server.InstanceIDs()) != 0 {
+		t.Errorf("got instances %v instead of none", server.InstanceIDs())
+	}
+}
+
+func Test_Managers_OwnedACL(t *testing.T) {
+	instance := &rdbv1beta1.RDBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+		Spec: rdbv1beta1.RDBInstanceSpec{
+			Engine:   "PostgreSQL-12",
+			NodeType: "db-dev-s",
+			Region:   "fr-par",
+			ACL: &rdbv1beta1.RDBACL{
+				Rules:     []scalewaymetav1alpha1.ACLRule{{IPRange: "10.0.0.0/24", Description: "office"}},
+				Ownership: rdbv1beta1.RDBACLOwnershipOwned,
+			},
+		},
+	}
+
+	server, _, instanceManager, _, _ := newFakeManagers(t, instance)
+	defer server.Close()
+
+	ensureUntilReconciled(t, instanceManager.Ensure, instance, 5)
+
+	// a rule added by another tool must be kept
+	_, foreignRange, _ := net.ParseCIDR("1.2.3.4/32")
+	_, err := instanceManager.API.AddInstanceACLRules(&rdb.AddInstanceACLRulesRequest{
+		Region:     scw.RegionFrPar,
+		InstanceID: instance.Spec.InstanceID,
+		Rules:      []*rdb.ACLRuleRequest{{IP: scw.IPNet{IPNet: *foreignRange}, Description: "other tool"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instance.Spec.ACL.Rules[0].IPRange = "10.0.1.0/24"
+	ensureUntilReconciled(t, instanceManager.Ensure, instance, 2)
+
+	expected := []scalewaymetav1alpha1.ACLRule{
+		{IPRange: "1.2.3.4/32", Description: "other tool"},
+		{IPRange: "10.0.1.0/24", Description: ACLRuleDescriptionPrefix + "office"},
+	}
+	if !reflect.DeepEqual(instance.Status.ACL, expected) {
+		t.Errorf("got status acl %v instead of %v", instance.Status.ACL, expected)
+	}
+
+	var rules []scalewaymetav1alpha1.ACLRule
+	for _, rule := range server.ACLRules(instance.Spec.InstanceID) {
+		rules = append(rules, scalewaymetav1alpha1.ACLRule{IPRange: rule.IP.String(), Description: rule.Description})
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("got acl %v instead of %v", rules, expected)
+	}
+
+	drift, err := instanceManager.GetDrift(context.Background(), instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range drift {
+		if field == "spec.acl" {
+			t.Errorf("got unexpected acl drift")
+		}
+	}
+}
+
+func Test_Managers_Events(t *testing.T) {
+	instance := &rdbv1beta1.RDBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+		Spec: rdbv1beta1.RDBInstanceSpec{
+			Engine:   "PostgreSQL-12",
+			NodeType: "db-dev-s",
+			Region:   "fr-par",
+		},
+	}
+
+	server, _, instanceManager, _, _ := newFakeManagers(t, instance)
+	defer server.Close()
+
+	recorder := record.NewFakeRecorder(10)
+	ctx := scaleway.WithEventRecorder(context.Background(), recorder)
+	ensure := func(_ context.Context, obj runtime.Object) (bool, error) {
+		return instanceManager.Ensure(ctx, obj)
+	}
+
+	ensureUntilReconciled(t, ensure, instance, 5)
+	instance.Spec.NodeType = "db-dev-m"
+	ensureUntilReconciled(t, ensure, instance, 5)
+
+	expected := []string{
+		"Normal InstanceCreated Created instance " + instance.Spec.InstanceID + " with engine PostgreSQL-12 and node type db-dev-s",
+		"Normal NodeTypeUpgradeStarted Started upgrade of instance " + instance.Spec.InstanceID + " node type from db-dev-s to db-dev-m",
+	}
+	for i, expectedEvent := range expected {
+		select {
+		case event := <-recorder.Events:
+			if event != expectedEvent {
+				t.Errorf("event %d: got %s instead of %s", i, event, expectedEvent)
+			}
+		default:
+			t.Errorf("event %d: got no event instead of %s", i, expectedEvent)
+		}
+	}
+}
@@ -12,11 +12,11 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
 )
 
 const (
-	// SecretPasswordKey is the key for accessing the pasword in the given secret
+	// SecretPasswordKey is the default key for accessing the pasword in the given secret
 	SecretPasswordKey = "password"
 )
 
@@ -25,6 +25,8 @@ type UserManager struct {
 	client.Client
 	API *rdb.API
 	scaleway.Manager
+	// DefaultRegion is the region used when none is specified
+	DefaultRegion scw.Region
 }
 
 // Ensure reconciles the RDB user resource
@@ -46,44 +48,60 @@ func (m *UserManager) Ensure(ctx context.Context, obj runtime.Object) (bool, err
 
 	if rdbUser != nil {
 		if rdbUser.IsAdmin != user.Spec.Admin {
+			if scaleway.PlanAction(ctx, "update user %s admin to %t on instance %s", rdbUser.Name, user.Spec.Admin, instanceID) {
+				return false, nil
+			}
 			_, err := m.API.UpdateUser(&rdb.UpdateUserRequest{
 				Region:     region,
 				InstanceID: instanceID,
 				Name:       rdbUser.Name,
 				IsAdmin:    scw.BoolPtr(user.Spec.Admin),
-			})
+			}, scw.WithContext(ctx))
 			if err != nil {
 				return false, err
 			}
+			scaleway.RecordEvent(ctx, user, corev1.EventTypeNormal, scaleway.ReasonUserUpdated, "Updated user %s admin to %t on instance %s", rdbUser.Name, user.Spec.Admin, instanceID)
 		}
 		// pass for now if password changed
 	} else {
 		password := ""
 
-		if user.Spec.Password.ValueFrom != nil {
+		if user.Spec.Password.ValueFrom != nil && user.Spec.Password.ValueFrom.SecretKeyRef != nil {
+			secretKeyRef := user.Spec.Password.ValueFrom.SecretKeyRef
+
 			secret := corev1.Secret{}
 			err := m.Get(ctx, types.NamespacedName{
-				Name:      user.Spec.Password.ValueFrom.SecretKeyRef.Name,
-				Namespace: user.Spec.Password.ValueFrom.SecretKeyRef.Namespace,
+				Name:      secretKeyRef.Name,
+				Namespace: GetPasswordSecretNamespace(user),
 			}, &secret)
 			if err != nil {
 				return false, err
 			}
-			password = string(secret.Data[SecretPasswordKey])
+
+			key := secretKeyRef.Key
+			if key == "" {
+				key = SecretPasswordKey
+			}
+			password = string(secret.Data[key])
 		} else if user.Spec.Password.Value != nil {
 			password = *user.Spec.Password.Value
 		}
 
+		if scaleway.PlanAction(ctx, "create user %s on instance %s", user.Spec.UserName, instanceID) {
+			return false, nil
+		}
+
 		rdbUser, err = m.API.CreateUser(&rdb.CreateUserRequest{
 			Region:     region,
 			InstanceID: instanceID,
 			IsAdmin:    user.Spec.Admin,
 			Name:       user.Spec.UserName,
 			Password:   password,
-		})
+		}, scw.WithContext(ctx))
 		if err != nil {
 			return false, err
 		}
+		scaleway.RecordEvent(ctx, user, corev1.EventTypeNormal, scaleway.ReasonUserCreated, "Created user %s on instance %s", user.Spec.UserName, instanceID)
 	}
 
 	return false, nil
@@ -105,17 +123,22 @@ func (m *UserManager) Delete(ctx context.Context, obj runtime.Object) (bool, err
 		return true, nil
 	}
 
+	if scaleway.PlanAction(ctx, "delete user %s on instance %s", user.Spec.UserName, instanceID) {
+		return false, nil
+	}
+
 	err = m.API.DeleteUser(&rdb.DeleteUserRequest{
 		Region:     region,
 		InstanceID: instanceID,
 		Name:       user.Spec.UserName,
-	})
+	}, scw.WithContext(ctx))
 	if err != nil {
 		if _, ok := err.(*scw.ResourceNotFoundError); ok {
 			return true, nil
 		}
 		return false, err
 	}
+	scaleway.RecordEvent(ctx, user, corev1.EventTypeNormal, scaleway.ReasonUserDeleted, "Deleted user %s on instance %s", user.Spec.UserName, instanceID)
 
 	return false, nil
 }
@@ -127,54 +150,29 @@ func (m *UserManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scal
 		return nil, err
 	}
 
-	if user.Spec.InstanceRef.Name == "" {
-		return nil, nil
-	}
-
-	instance := &rdbv1alpha1.RDBInstance{}
-
-	userNamespace := user.Spec.InstanceRef.Namespace
-	if userNamespace == "" {
-		userNamespace = user.Namespace
-	}
-
-	err = m.Get(ctx, client.ObjectKey{Name: user.Spec.InstanceRef.Name, Namespace: userNamespace}, instance)
-	if err != nil {
-		return nil, err
-	}
-
-	return []scaleway.Owner{
-		{
-			Key: types.NamespacedName{
-				Name:      instance.Name,
-				Namespace: instance.Namespace,
-			},
-			Object: &rdbv1alpha1.RDBInstance{},
-		},
-	}, nil
+	return getInstanceRefOwners(ctx, m.Client, user.Spec.InstanceRef, user.Namespace)
 }
 
-func (m *UserManager) getInstanceIDAndRegion(ctx context.Context, user *rdbv1alpha1.RDBUser) (string, scw.Region, error) {
-	instance := &rdbv1alpha1.RDBInstance{}
-
-	if user.Spec.InstanceRef.Name != "" {
-		userNamespace := user.Spec.InstanceRef.Namespace
-		if userNamespace == "" {
-			userNamespace = user.Namespace
-		}
+// SupportsDryRun returns whether the manager supports the dry-run mode
+func (m *UserManager) SupportsDryRun() bool {
+	return true
+}
 
-		err := m.Get(ctx, client.ObjectKey{Name: user.Spec.InstanceRef.Name, Namespace: userNamespace}, instance)
-		if err != nil {
-			return "", "", err
-		}
+func (m *UserManager) getInstanceIDAndRegion(ctx context.Context, user *rdbv1beta1.RDBUser) (string, scw.Region, error) {
+	return getInstanceRefIDAndRegion(ctx, m.Client, user.Spec.InstanceRef, user.Namespace)
+}
 
-		return instance.Spec.InstanceID, scw.Region(instance.Spec.Region), nil
+// GetPasswordSecretNamespace returns the namespace of the password secret of the RDB user
+// It is the namespace of the user, unless the user was converted from a v1alpha1 RDBUser
+// referencing a secret in another namespace
+func GetPasswordSecretNamespace(user *rdbv1beta1.RDBUser) string {
+	if namespace, ok := user.Annotations[rdbv1beta1.PasswordSecretNamespaceAnnotation]; ok && namespace != "" {
+		return namespace
 	}
-
-	return user.Spec.InstanceRef.ExternalID, scw.Region(user.Spec.InstanceRef.Region), nil
+	return user.Namespace
 }
 
-func (m *UserManager) getByName(ctx context.Context, user *rdbv1alpha1.RDBUser) (*rdb.User, error) {
+func (m *UserManager) getByName(ctx context.Context, user *rdbv1beta1.RDBUser) (*rdb.User, error) {
 	instanceID, region, err := m.getInstanceIDAndRegion(ctx, user)
 	if err != nil {
 		return nil, err
@@ -184,7 +182,7 @@ func (m *UserManager) getByName(ctx context.Context, user *rdbv1alpha1.RDBUser)
 		Region:     region,
 		InstanceID: instanceID,
 		Name:       scw.StringPtr(user.Spec.UserName),
-	}, scw.WithAllPages())
+	}, scw.WithAllPages(), scw.WithContext(ctx))
 	if err != nil {
 		if _, ok := err.(*scw.ResourceNotFoundError); ok {
 			return nil, nil
@@ -208,8 +206,8 @@ func (m *UserManager) getByName(ctx context.Context, user *rdbv1alpha1.RDBUser)
 	return nil, nil
 }
 
-func convertUser(obj runtime.Object) (*rdbv1alpha1.RDBUser, error) {
-	user, ok := obj.(*rdbv1alpha1.RDBUser)
+func convertUser(obj runtime.Object) (*rdbv1beta1.RDBUser, error) {
+	user, ok := obj.(*rdbv1beta1.RDBUser)
 	if !ok {
 		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
 	}
@@ -0,0 +1,90 @@
+package rdb
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+)
+
+// Default sets the default values of a RDB User
+func (m *UserManager) Default(ctx context.Context, obj runtime.Object) error {
+	user, err := convertUser(obj)
+	if err != nil {
+		return err
+	}
+
+	defaultInstanceRef(&user.Spec.InstanceRef, m.DefaultRegion)
+
+	if user.Spec.Password.ValueFrom != nil && user.Spec.Password.ValueFrom.SecretKeyRef != nil && user.Spec.Password.ValueFrom.SecretKeyRef.Key == "" {
+		user.Spec.Password.ValueFrom.SecretKeyRef.Key = SecretPasswordKey
+	}
+
+	return nil
+}
+
+// ValidateCreate validates the creation of a RDB User
+func (m *UserManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	user, err := convertUser(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Spec.UserName == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("userName"), "userName must be specified"))
+	}
+
+	allErrs = append(allErrs, validateUserPassword(user)...)
+	allErrs = append(allErrs, validateInstanceRef(ctx, m.API, user.Spec.InstanceRef, field.NewPath("spec").Child("instanceRef"))...)
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a RDB User
+func (m *UserManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	user, err := convertUser(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldUser, err := convertUser(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	// the user is looked up by name on the instance, so a rename would leave the old user behind
+	if oldUser.Spec.UserName != user.Spec.UserName {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("userName"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateUserPassword(user)...)
+	allErrs = append(allErrs, validateInstanceRefUpdate(oldUser.Spec.InstanceRef, user.Spec.InstanceRef, field.NewPath("spec").Child("instanceRef"))...)
+
+	return allErrs, nil
+}
+
+// validateUserPassword checks that exactly one of the password value and secret is specified
+func validateUserPassword(user *rdbv1beta1.RDBUser) field.ErrorList {
+	var allErrs field.ErrorList
+
+	password := user.Spec.Password
+	passwordPath := field.NewPath("spec").Child("password")
+
+	if password.Value == nil && password.ValueFrom == nil {
+		allErrs = append(allErrs, field.Required(passwordPath, "value or valueFrom must be specified"))
+	}
+	if password.Value != nil && password.ValueFrom != nil {
+		allErrs = append(allErrs, field.Forbidden(passwordPath, "only one of value and valueFrom must be specified"))
+	}
+	if password.ValueFrom != nil && password.ValueFrom.SecretKeyRef == nil {
+		allErrs = append(allErrs, field.Required(passwordPath.Child("valueFrom").Child("secretKeyRef"), "secretKeyRef must be specified"))
+	}
+
+	return allErrs
+}
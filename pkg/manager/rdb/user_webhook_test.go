@@ -0,0 +1,73 @@
+package rdb
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+)
+
+func Test_UserManager_ValidateUpdate(t *testing.T) {
+	newUser := func(userName string, password rdbv1beta1.RDBUserPassword) *rdbv1beta1.RDBUser {
+		return &rdbv1beta1.RDBUser{
+			Spec: rdbv1beta1.RDBUserSpec{
+				UserName: userName,
+				Password: password,
+				InstanceRef: rdbv1beta1.RDBInstanceRef{
+					Object: &rdbv1beta1.RDBInstanceObjectRef{Name: "instance"},
+				},
+			},
+		}
+	}
+	value := rdbv1beta1.RDBUserPassword{Value: new(string)}
+	secret := rdbv1beta1.RDBUserPassword{
+		ValueFrom: &rdbv1beta1.RDBUserPasswordSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "password"}},
+		},
+	}
+
+	cases := []struct {
+		oldUser *rdbv1beta1.RDBUser
+		user    *rdbv1beta1.RDBUser
+		errs    int
+	}{
+		{
+			oldUser: newUser("app", value),
+			user:    newUser("app", secret),
+			errs:    0,
+		},
+		{
+			oldUser: newUser("app", value),
+			user:    newUser("other", value),
+			errs:    1,
+		},
+		{
+			oldUser: newUser("app", value),
+			user:    newUser("app", rdbv1beta1.RDBUserPassword{}),
+			errs:    1,
+		},
+		{
+			oldUser: newUser("app", value),
+			user:    newUser("app", rdbv1beta1.RDBUserPassword{Value: value.Value, ValueFrom: secret.ValueFrom}),
+			errs:    1,
+		},
+		{
+			oldUser: newUser("app", value),
+			user:    newUser("app", rdbv1beta1.RDBUserPassword{ValueFrom: &rdbv1beta1.RDBUserPasswordSource{}}),
+			errs:    1,
+		},
+	}
+
+	m := &UserManager{}
+	for i, c := range cases {
+		errs, err := m.ValidateUpdate(context.Background(), c.oldUser, c.user)
+		if err != nil {
+			t.Fatalf("case %d: got error %v", i, err)
+		}
+		if len(errs) != c.errs {
+			t.Errorf("case %d: got errors %v instead of %d errors", i, errs, c.errs)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	redisv1alpha1 "github.com/scaleway/scaleway-operator/apis/redis/v1alpha1"
+)
+
+const (
+	// SecretPasswordKey is the key for accessing the pasword in the given secret
+	SecretPasswordKey = "password"
+
+	// ConnectionSecretSuffix is the suffix of the connection secret name
+	ConnectionSecretSuffix = "-redis-connection"
+
+	// ConnectionSecretHostKey is the connection secret key holding the host
+	ConnectionSecretHostKey = "host"
+	// ConnectionSecretPortKey is the connection secret key holding the port
+	ConnectionSecretPortKey = "port"
+	// ConnectionSecretUserNameKey is the connection secret key holding the user name
+	ConnectionSecretUserNameKey = "username"
+	// ConnectionSecretPasswordKey is the connection secret key holding the password
+	ConnectionSecretPasswordKey = "password"
+	// ConnectionSecretTLSKey is the connection secret key holding whether TLS is enabled
+	ConnectionSecretTLSKey = "tls"
+	// ConnectionSecretCAKey is the connection secret key holding the cluster certificate
+	ConnectionSecretCAKey = "ca.crt"
+)
+
+// ClusterManager manages the Redis clusters
+type ClusterManager struct {
+	client.Client
+	API *redis.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the Redis cluster resource
+func (m *ClusterManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	cluster, err := convertCluster(obj)
+	if err != nil {
+		return false, err
+	}
+
+	zone := scw.Zone(cluster.Spec.Zone)
+
+	// if clusterID is empty, we need to create the cluster
+	if cluster.Spec.ClusterID == "" {
+		return false, m.createCluster(ctx, cluster)
+	}
+
+	redisClusterResp, err := m.API.GetCluster(&redis.GetClusterRequest{
+		Zone:      zone,
+		ClusterID: cluster.Spec.ClusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	needReturn, err := m.updateCluster(ctx, cluster, redisClusterResp)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	needReturn, err = m.migrateCluster(ctx, cluster, redisClusterResp)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	if cluster.Spec.ACL != nil {
+		err = m.updateACLs(ctx, cluster, redisClusterResp)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	cluster.Status.Endpoints = nil
+	for _, endpoint := range redisClusterResp.Endpoints {
+		clusterEndpoint := redisv1alpha1.RedisClusterEndpoint{
+			Port: int32(endpoint.Port),
+		}
+		for _, ip := range endpoint.IPs {
+			clusterEndpoint.IPs = append(clusterEndpoint.IPs, ip.String())
+		}
+		if endpoint.PrivateNetwork != nil {
+			clusterEndpoint.PrivateNetworkID = endpoint.PrivateNetwork.ID
+		}
+		cluster.Status.Endpoints = append(cluster.Status.Endpoints, clusterEndpoint)
+	}
+
+	if redisClusterResp.Status != redis.ClusterStatusReady {
+		return false, nil
+	}
+
+	err = m.ensureConnectionSecret(ctx, cluster, redisClusterResp)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Delete deletes the Redis cluster resource
+func (m *ClusterManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	cluster, err := convertCluster(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := cluster.Spec.ClusterID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	_, err = m.API.DeleteCluster(&redis.DeleteClusterRequest{
+		Zone:      scw.Zone(cluster.Spec.Zone),
+		ClusterID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return false, nil
+}
+
+// GetOwners returns the owners of the Redis cluster resource
+func (m *ClusterManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+func (m *ClusterManager) createCluster(ctx context.Context, cluster *redisv1alpha1.RedisCluster) error {
+	password, err := m.getPassword(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	createRequest := &redis.CreateClusterRequest{
+		Zone:        scw.Zone(cluster.Spec.Zone),
+		Name:        cluster.Name,
+		Version:     cluster.Spec.Version,
+		NodeType:    cluster.Spec.NodeType,
+		ClusterSize: cluster.Spec.ClusterSize,
+		TLSEnabled:  cluster.Spec.TLSEnabled,
+		UserName:    cluster.Spec.User.UserName,
+		Password:    password,
+		Tags:        utils.LabelsToTags(cluster.Labels),
+	}
+
+	if cluster.Spec.ACL != nil {
+		createRequest.ACLRules, err = m.getWantedRules(ctx, cluster)
+		if err != nil {
+			return err
+		}
+	}
+
+	redisClusterResp, err := m.API.CreateCluster(createRequest, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	cluster.Spec.ClusterID = redisClusterResp.ID
+	cluster.Spec.Zone = redisClusterResp.Zone.String()
+	status := cluster.Status.DeepCopy()
+	err = m.Client.Update(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	cluster.Status = *status
+	cluster.Status.PasswordHash = hashPassword(cluster, password)
+
+	return nil
+}
+
+func (m *ClusterManager) updateCluster(ctx context.Context, cluster *redisv1alpha1.RedisCluster, redisCluster *redis.Cluster) (bool, error) {
+	needsUpdate := false
+	updateRequest := &redis.UpdateClusterRequest{
+		Zone:      scw.Zone(cluster.Spec.Zone),
+		ClusterID: cluster.Spec.ClusterID,
+	}
+
+	if !utils.CompareTagsLabels(redisCluster.Tags, cluster.Labels) {
+		updateRequest.Tags = scw.StringsPtr(utils.LabelsToTags(cluster.Labels))
+		needsUpdate = true
+	}
+
+	if redisCluster.UserName != cluster.Spec.User.UserName {
+		updateRequest.UserName = scw.StringPtr(cluster.Spec.User.UserName)
+		needsUpdate = true
+	}
+
+	password, err := m.getPassword(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+	passwordHash := hashPassword(cluster, password)
+	// the API does not return the password, so the pushed one is compared through its hash
+	if passwordHash != cluster.Status.PasswordHash {
+		updateRequest.Password = scw.StringPtr(password)
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		_, err := m.API.UpdateCluster(updateRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		cluster.Status.PasswordHash = passwordHash
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (m *ClusterManager) migrateCluster(ctx context.Context, cluster *redisv1alpha1.RedisCluster, redisCluster *redis.Cluster) (bool, error) {
+	migrateRequest := &redis.MigrateClusterRequest{
+		Zone:      scw.Zone(cluster.Spec.Zone),
+		ClusterID: cluster.Spec.ClusterID,
+	}
+
+	// only one migration can be done at a time
+	if redisCluster.Version != cluster.Spec.Version {
+		migrateRequest.Version = scw.StringPtr(cluster.Spec.Version)
+	} else if redisCluster.NodeType != cluster.Spec.NodeType {
+		migrateRequest.NodeType = scw.StringPtr(cluster.Spec.NodeType)
+	} else if cluster.Spec.ClusterSize != nil && uint32(*cluster.Spec.ClusterSize) != redisCluster.ClusterSize {
+		migrateRequest.ClusterSize = scw.Uint32Ptr(uint32(*cluster.Spec.ClusterSize))
+	} else {
+		return false, nil
+	}
+
+	_, err := m.API.MigrateCluster(migrateRequest, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *ClusterManager) getWantedRules(ctx context.Context, cluster *redisv1alpha1.RedisCluster) ([]*redis.ACLRuleSpec, error) {
+	rules := []*redis.ACLRuleSpec{}
+	for _, wantedRule := range cluster.Spec.ACL.Rules {
+		_, wantedRuleParsed, err := net.ParseCIDR(wantedRule.IPRange)
+		if err != nil {
+			m.Log.Error(err, "error parsing ip range, ignoring")
+			continue
+		}
+		rules = append(rules, &redis.ACLRuleSpec{
+			IPCidr: scw.IPNet{
+				IPNet: *wantedRuleParsed,
+			},
+			Description: wantedRule.Description,
+		})
+	}
+
+	if cluster.Spec.ACL.AllowCluster {
+		nodesIP, err := utils.GetNodesIP(ctx, m.Client)
+		if err != nil {
+			return nil, err
+		}
+		for _, nodeIP := range nodesIP {
+			rules = append(rules, &redis.ACLRuleSpec{
+				IPCidr: scw.IPNet{
+					IPNet: nodeIP,
+				},
+				Description: "Kubernetes node",
+			})
+		}
+	}
+
+	return rules, nil
+}
+
+func checkRulesUpdate(existingRules []*redis.ACLRule, wantedRules []*redis.ACLRuleSpec) bool {
+	if len(existingRules) != len(wantedRules) {
+		return true
+	}
+
+	for _, wantedRule := range wantedRules {
+		foundRule := false
+		for _, existingRule := range existingRules {
+			if existingRule.IPCidr != nil && existingRule.IPCidr.String() == wantedRule.IPCidr.String() {
+				foundRule = true
+				break
+			}
+		}
+		if !foundRule {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ClusterManager) updateACLs(ctx context.Context, cluster *redisv1alpha1.RedisCluster, redisCluster *redis.Cluster) error {
+	wantedRules, err := m.getWantedRules(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	if checkRulesUpdate(redisCluster.ACLRules, wantedRules) {
+		_, err = m.API.SetACLRules(&redis.SetACLRulesRequest{
+			Zone:      scw.Zone(cluster.Spec.Zone),
+			ClusterID: cluster.Spec.ClusterID,
+			ACLRules:  wantedRules,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ClusterManager) getPassword(ctx context.Context, cluster *redisv1alpha1.RedisCluster) (string, error) {
+	if cluster.Spec.User.Password.ValueFrom != nil {
+		secretNamespace := cluster.Spec.User.Password.ValueFrom.SecretKeyRef.Namespace
+		if secretNamespace == "" {
+			secretNamespace = cluster.Namespace
+		}
+		secret := corev1.Secret{}
+		err := m.Get(ctx, types.NamespacedName{
+			Name:      cluster.Spec.User.Password.ValueFrom.SecretKeyRef.Name,
+			Namespace: secretNamespace,
+		}, &secret)
+		if err != nil {
+			return "", err
+		}
+		return string(secret.Data[SecretPasswordKey]), nil
+	}
+
+	if cluster.Spec.User.Password.Value != nil {
+		return *cluster.Spec.User.Password.Value, nil
+	}
+
+	return "", nil
+}
+
+// hashPassword returns the hash of the given password, salted with the UID of the cluster
+func hashPassword(cluster *redisv1alpha1.RedisCluster, password string) string {
+	return utils.HashSecretValues(string(cluster.UID), map[string]string{
+		SecretPasswordKey: password,
+	})
+}
+
+func (m *ClusterManager) ensureConnectionSecret(ctx context.Context, cluster *redisv1alpha1.RedisCluster, redisCluster *redis.Cluster) error {
+	password, err := m.getPassword(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	data := map[string][]byte{
+		ConnectionSecretUserNameKey: []byte(redisCluster.UserName),
+		ConnectionSecretPasswordKey: []byte(password),
+		ConnectionSecretTLSKey:      []byte(strconv.FormatBool(redisCluster.TLSEnabled)),
+	}
+
+	if len(redisCluster.Endpoints) > 0 && len(redisCluster.Endpoints[0].IPs) > 0 {
+		data[ConnectionSecretHostKey] = []byte(redisCluster.Endpoints[0].IPs[0].String())
+		data[ConnectionSecretPortKey] = []byte(strconv.Itoa(int(redisCluster.Endpoints[0].Port)))
+	}
+
+	if redisCluster.TLSEnabled {
+		certificate, err := m.API.GetClusterCertificate(&redis.GetClusterCertificateRequest{
+			Zone:      scw.Zone(cluster.Spec.Zone),
+			ClusterID: cluster.Spec.ClusterID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadAll(certificate.Content)
+		if err != nil {
+			return err
+		}
+		data[ConnectionSecretCAKey] = content
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.Name + ConnectionSecretSuffix,
+			Namespace: cluster.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, m.Client, secret, func() error {
+		// refuse to overwrite a secret the operator did not create
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, cluster) {
+			return fmt.Errorf("secret %s already exists and is not controlled by this RedisCluster", secret.Name)
+		}
+		secret.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(cluster, redisv1alpha1.GroupVersion.WithKind("RedisCluster")),
+		}
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = data
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	cluster.Status.ConnectionSecret = secret.Name
+
+	return nil
+}
+
+func convertCluster(obj runtime.Object) (*redisv1alpha1.RedisCluster, error) {
+	cluster, ok := obj.(*redisv1alpha1.RedisCluster)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return cluster, nil
+}
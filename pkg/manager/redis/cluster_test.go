@@ -0,0 +1,132 @@
+package redis
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	redisv1alpha1 "github.com/scaleway/scaleway-operator/apis/redis/v1alpha1"
+)
+
+func mustIPNet(t *testing.T, cidr string) scw.IPNet {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return scw.IPNet{IPNet: *ipNet}
+}
+
+func Test_checkRulesUpdate(t *testing.T) {
+	existingRule := func(cidr string) *redis.ACLRule {
+		ipNet := mustIPNet(t, cidr)
+		return &redis.ACLRule{IPCidr: &ipNet}
+	}
+	wantedRule := func(cidr string) *redis.ACLRuleSpec {
+		return &redis.ACLRuleSpec{IPCidr: mustIPNet(t, cidr)}
+	}
+
+	cases := []struct {
+		existing []*redis.ACLRule
+		wanted   []*redis.ACLRuleSpec
+		update   bool
+	}{
+		{
+			existing: nil,
+			wanted:   nil,
+			update:   false,
+		},
+		{
+			existing: []*redis.ACLRule{existingRule("10.0.0.0/24"), existingRule("1.2.3.4/32")},
+			wanted:   []*redis.ACLRuleSpec{wantedRule("1.2.3.4/32"), wantedRule("10.0.0.0/24")},
+			update:   false,
+		},
+		{
+			existing: []*redis.ACLRule{existingRule("10.0.0.0/24")},
+			wanted:   []*redis.ACLRuleSpec{wantedRule("10.0.0.0/16")},
+			update:   true,
+		},
+		{
+			existing: []*redis.ACLRule{existingRule("10.0.0.0/24")},
+			wanted:   []*redis.ACLRuleSpec{wantedRule("10.0.0.0/24"), wantedRule("1.2.3.4/32")},
+			update:   true,
+		},
+	}
+
+	for i, c := range cases {
+		if update := checkRulesUpdate(c.existing, c.wanted); update != c.update {
+			t.Errorf("case %d: got %t instead of %t", i, update, c.update)
+		}
+	}
+}
+
+func Test_hashPassword(t *testing.T) {
+	cluster := &redisv1alpha1.RedisCluster{ObjectMeta: metav1.ObjectMeta{UID: "uid"}}
+	otherCluster := &redisv1alpha1.RedisCluster{ObjectMeta: metav1.ObjectMeta{UID: "other-uid"}}
+
+	if hashPassword(cluster, "password") != hashPassword(cluster, "password") {
+		t.Errorf("Got different hashes for the same password")
+	}
+	if hashPassword(cluster, "password") == hashPassword(cluster, "new-password") {
+		t.Errorf("Got the same hash for different passwords")
+	}
+	if hashPassword(cluster, "password") == hashPassword(otherCluster, "password") {
+		t.Errorf("Got the same hash for different clusters")
+	}
+}
+
+func Test_ensureConnectionSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = redisv1alpha1.AddToScheme(scheme)
+
+	password := "password"
+	owned := &redisv1alpha1.RedisCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "owned", Namespace: "default", UID: "owned-uid"},
+		Spec: redisv1alpha1.RedisClusterSpec{
+			User: redisv1alpha1.RedisUser{Password: redisv1alpha1.RedisPassword{Value: &password}},
+		},
+	}
+	unowned := owned.DeepCopy()
+	unowned.Name = "unowned"
+	unowned.UID = "unowned-uid"
+
+	m := &ClusterManager{
+		Client: fake.NewFakeClientWithScheme(scheme, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "unowned" + ConnectionSecretSuffix, Namespace: "default", ResourceVersion: "1"},
+		}),
+	}
+
+	cases := []struct {
+		cluster *redisv1alpha1.RedisCluster
+		err     bool
+	}{
+		{
+			cluster: owned,
+			err:     false,
+		},
+		{
+			// the secret created on the previous case is updated
+			cluster: owned,
+			err:     false,
+		},
+		{
+			cluster: unowned,
+			err:     true,
+		},
+	}
+
+	for i, c := range cases {
+		err := m.ensureConnectionSecret(context.Background(), c.cluster, &redis.Cluster{UserName: "user"})
+		if (err != nil) != c.err {
+			t.Errorf("case %d: got error %v", i, err)
+		}
+	}
+}
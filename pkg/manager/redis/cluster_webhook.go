@@ -0,0 +1,179 @@
+package redis
+
+import (
+	"context"
+	"net"
+
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	redisv1alpha1 "github.com/scaleway/scaleway-operator/apis/redis/v1alpha1"
+)
+
+// ValidateCreate validates the creation of a Redis Cluster
+func (m *ClusterManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	cluster, err := convertCluster(obj)
+	if err != nil {
+		return nil, err
+	}
+	_, err = scw.ParseZone(cluster.Spec.Zone)
+	if cluster.Spec.Zone != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("zone"), cluster.Spec.Zone, "zone is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validatePassword(cluster)...)
+	allErrs = append(allErrs, validateACL(cluster)...)
+
+	if cluster.Spec.ClusterID != "" {
+		redisCluster, err := m.API.GetCluster(&redis.GetClusterRequest{
+			Zone:      scw.Zone(cluster.Spec.Zone),
+			ClusterID: cluster.Spec.ClusterID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("clusterID"), cluster.Spec.ClusterID, err.Error()))
+			return allErrs, nil
+		}
+		if cluster.Spec.TLSEnabled != redisCluster.TLSEnabled {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("tlsEnabled"), cluster.Spec.TLSEnabled, "tlsEnabled does not match"))
+		}
+
+		return allErrs, nil
+	}
+
+	versionsResp, err := m.API.ListClusterVersions(&redis.ListClusterVersionsRequest{
+		Zone:              scw.Zone(cluster.Spec.Zone),
+		IncludeBeta:       true,
+		IncludeDeprecated: true,
+		Version:           scw.StringPtr(cluster.Spec.Version),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	versionFound := false
+	for _, version := range versionsResp.Versions {
+		if version.Version == cluster.Spec.Version {
+			versionFound = true
+			break
+		}
+	}
+	if !versionFound {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("version"), cluster.Spec.Version, "version does not exist"))
+	}
+
+	nodeTypeErrs, err := m.checkNodeType(ctx, scw.Zone(cluster.Spec.Zone), cluster.Spec.NodeType)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, nodeTypeErrs...)
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Redis Cluster
+func (m *ClusterManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	cluster, err := convertCluster(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldCluster, err := convertCluster(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldCluster.Spec.ClusterID != "" && oldCluster.Spec.ClusterID != cluster.Spec.ClusterID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("clusterID"), "field is immutable"))
+	}
+
+	if oldCluster.Spec.Zone != "" && oldCluster.Spec.Zone != cluster.Spec.Zone {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("zone"), "field is immutable"))
+	}
+
+	if oldCluster.Spec.TLSEnabled != cluster.Spec.TLSEnabled {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("tlsEnabled"), "field is immutable"))
+	}
+
+	if oldCluster.Spec.ClusterSize != nil && cluster.Spec.ClusterSize != nil && *cluster.Spec.ClusterSize < *oldCluster.Spec.ClusterSize {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("clusterSize"), *cluster.Spec.ClusterSize, "cluster size can't be decreased"))
+	}
+
+	allErrs = append(allErrs, validatePassword(cluster)...)
+	allErrs = append(allErrs, validateACL(cluster)...)
+
+	if oldCluster.Spec.NodeType != cluster.Spec.NodeType {
+		nodeTypeErrs, err := m.checkNodeType(ctx, scw.Zone(cluster.Spec.Zone), cluster.Spec.NodeType)
+		if err != nil {
+			return nil, err
+		}
+		allErrs = append(allErrs, nodeTypeErrs...)
+	}
+
+	return allErrs, nil
+}
+
+func (m *ClusterManager) checkNodeType(ctx context.Context, zone scw.Zone, clusterNodeType string) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	nodeTypesResp, err := m.API.ListNodeTypes(&redis.ListNodeTypesRequest{
+		Zone:                 zone,
+		IncludeDisabledTypes: true,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	nodeTypeFound := false
+	for _, nodeType := range nodeTypesResp.NodeTypes {
+		if nodeType.Name == clusterNodeType {
+			if nodeType.Disabled {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("nodeType"), clusterNodeType, "node type is disabled"))
+			}
+			nodeTypeFound = true
+			break
+		}
+	}
+	if !nodeTypeFound {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("nodeType"), clusterNodeType, "node type does not exist"))
+	}
+
+	return allErrs, nil
+}
+
+func validatePassword(cluster *redisv1alpha1.RedisCluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	password := cluster.Spec.User.Password
+	if password.Value == nil && password.ValueFrom == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("user").Child("password"), "value or valueFrom must be specified"))
+	}
+	if password.Value != nil && password.ValueFrom != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("user").Child("password"), "only one of value and valueFrom must be specified"))
+	}
+
+	return allErrs
+}
+
+func validateACL(cluster *redisv1alpha1.RedisCluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cluster.Spec.ACL == nil {
+		return allErrs
+	}
+
+	for i, rule := range cluster.Spec.ACL.Rules {
+		_, _, err := net.ParseCIDR(rule.IPRange)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("acl").Child("rules").Index(i).Child("ipRange"), rule.IPRange, "ip range is not a valid CIDR"))
+		}
+	}
+
+	return allErrs
+}
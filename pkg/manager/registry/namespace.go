@@ -0,0 +1,426 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	registryv1alpha1 "github.com/scaleway/scaleway-operator/apis/registry/v1alpha1"
+)
+
+const (
+	// PullSecretOwnerNameLabel is the label holding the name of the RegistryNamespace
+	// owning a generated pull secret
+	PullSecretOwnerNameLabel = "registry.scaleway.com/owner-name"
+	// PullSecretOwnerNamespaceLabel is the label holding the namespace of the RegistryNamespace
+	// owning a generated pull secret
+	PullSecretOwnerNamespaceLabel = "registry.scaleway.com/owner-namespace"
+
+	// registryUserName is the user name used to login on the Scaleway registry
+	registryUserName = "nologin"
+)
+
+// NamespaceManager manages the registry namespaces
+type NamespaceManager struct {
+	client.Client
+	API *registry.API
+	// IAMAPI is used to create the API keys pulling the images
+	IAMAPI *iam.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the registry namespace resource
+func (m *NamespaceManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	namespace, err := convertNamespace(obj)
+	if err != nil {
+		return false, err
+	}
+
+	// if namespaceID is empty, we need to create the namespace
+	if namespace.Spec.NamespaceID == "" {
+		return false, m.createNamespace(ctx, namespace)
+	}
+
+	registryNamespace, err := m.API.GetNamespace(&registry.GetNamespaceRequest{
+		Region:      scw.Region(namespace.Spec.Region),
+		NamespaceID: namespace.Spec.NamespaceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	needReturn, err := m.updateNamespace(ctx, namespace, registryNamespace)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	namespace.Status.Endpoint = registryNamespace.Endpoint
+	namespace.Status.ImageCount = int32(registryNamespace.ImageCount)
+
+	if registryNamespace.Status != registry.NamespaceStatusReady {
+		return false, nil
+	}
+
+	err = m.ensurePullSecrets(ctx, namespace, registryNamespace)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Delete deletes the registry namespace resource
+func (m *NamespaceManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	namespace, err := convertNamespace(obj)
+	if err != nil {
+		return false, err
+	}
+
+	err = m.deletePullSecrets(ctx, namespace, namespace.Status.PullSecrets)
+	if err != nil {
+		return false, err
+	}
+	namespace.Status.PullSecrets = nil
+
+	err = m.deletePullKey(ctx, namespace)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := namespace.Spec.NamespaceID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	_, err = m.API.DeleteNamespace(&registry.DeleteNamespaceRequest{
+		Region:      scw.Region(namespace.Spec.Region),
+		NamespaceID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return false, nil
+}
+
+// GetOwners returns the owners of the registry namespace resource
+func (m *NamespaceManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+func (m *NamespaceManager) createNamespace(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace) error {
+	registryNamespace, err := m.API.CreateNamespace(&registry.CreateNamespaceRequest{
+		Region:      scw.Region(namespace.Spec.Region),
+		Name:        getNamespaceName(namespace),
+		Description: namespace.Spec.Description,
+		IsPublic:    namespace.Spec.IsPublic,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	namespace.Spec.NamespaceID = registryNamespace.ID
+	namespace.Spec.Region = registryNamespace.Region.String()
+	err = m.Client.Update(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateSpec saves the spec of the registry namespace, keeping its status
+// since the spec update overwrites it
+func (m *NamespaceManager) updateSpec(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace) error {
+	status := namespace.Status.DeepCopy()
+	err := m.Client.Update(ctx, namespace)
+	namespace.Status = *status
+	return err
+}
+
+func (m *NamespaceManager) updateNamespace(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace, registryNamespace *registry.Namespace) (bool, error) {
+	needsUpdate := false
+	updateRequest := &registry.UpdateNamespaceRequest{
+		Region:      scw.Region(namespace.Spec.Region),
+		NamespaceID: namespace.Spec.NamespaceID,
+	}
+
+	if registryNamespace.Description != namespace.Spec.Description {
+		updateRequest.Description = scw.StringPtr(namespace.Spec.Description)
+		needsUpdate = true
+	}
+
+	if registryNamespace.IsPublic != namespace.Spec.IsPublic {
+		updateRequest.IsPublic = scw.BoolPtr(namespace.Spec.IsPublic)
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		_, err := m.API.UpdateNamespace(updateRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (m *NamespaceManager) ensurePullSecrets(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace, registryNamespace *registry.Namespace) error {
+	// the generated API key is deleted once the pull secrets use another one
+	if !needsPullKey(namespace) && hasPullKey(namespace) {
+		err := m.deletePullKey(ctx, namespace)
+		if err != nil {
+			return err
+		}
+		namespace.Spec.PullApplicationID = ""
+		namespace.Spec.PullPolicyID = ""
+		namespace.Spec.PullAccessKey = ""
+		err = m.updateSpec(ctx, namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	wantedSecrets := getWantedPullSecrets(namespace)
+
+	if len(wantedSecrets) > 0 {
+		secretKey, err := m.getSecretKey(ctx, namespace, registryNamespace)
+		if err != nil {
+			return err
+		}
+
+		dockerConfig, err := renderDockerConfig(registryNamespace.Endpoint, secretKey)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range wantedSecrets {
+			err = m.checkPullSecretNamespace(ctx, namespace, key.Namespace)
+			if err != nil {
+				return err
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+			}
+			_, err = controllerutil.CreateOrUpdate(ctx, m.Client, secret, func() error {
+				// refuse to adopt a secret the operator did not create
+				if secret.ResourceVersion != "" && !isOwnedPullSecret(namespace, secret) {
+					return fmt.Errorf("secret %s/%s already exists and is not owned by this registry namespace", secret.Namespace, secret.Name)
+				}
+				if secret.Labels == nil {
+					secret.Labels = map[string]string{}
+				}
+				secret.Labels[PullSecretOwnerNameLabel] = namespace.Name
+				secret.Labels[PullSecretOwnerNamespaceLabel] = namespace.Namespace
+				secret.Type = corev1.SecretTypeDockerConfigJson
+				secret.Data = map[string][]byte{
+					corev1.DockerConfigJsonKey: dockerConfig,
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err := m.deletePullSecrets(ctx, namespace, getStalePullSecrets(wantedSecrets, namespace.Status.PullSecrets))
+	if err != nil {
+		return err
+	}
+
+	namespace.Status.PullSecrets = nil
+	for key := range wantedSecrets {
+		namespace.Status.PullSecrets = append(namespace.Status.PullSecrets, key)
+	}
+	sort.Strings(namespace.Status.PullSecrets)
+
+	return nil
+}
+
+// checkPullSecretNamespace returns an error if the registry namespace is not allowed
+// to generate pull secrets in the given Kubernetes namespace
+func (m *NamespaceManager) checkPullSecretNamespace(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace, secretNamespace string) error {
+	if secretNamespace == namespace.Namespace {
+		return nil
+	}
+
+	targetNamespace := corev1.Namespace{}
+	err := m.Get(ctx, types.NamespacedName{Name: secretNamespace}, &targetNamespace)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range strings.Split(targetNamespace.Annotations[registryv1alpha1.PullSecretSourcesAnnotation], ",") {
+		if strings.TrimSpace(source) == namespace.Namespace {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("namespace %s does not allow pull secrets from namespace %s", secretNamespace, namespace.Namespace)
+}
+
+// deletePullSecrets deletes the given pull secrets, skipping the ones not owned
+// by the registry namespace
+func (m *NamespaceManager) deletePullSecrets(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace, pullSecrets []string) error {
+	for _, pullSecret := range pullSecrets {
+		parts := strings.SplitN(pullSecret, string(types.Separator), 2)
+		if len(parts) != 2 {
+			continue
+		}
+		secret := corev1.Secret{}
+		err := m.Get(ctx, types.NamespacedName{
+			Namespace: parts[0],
+			Name:      parts[1],
+		}, &secret)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !isOwnedPullSecret(namespace, &secret) {
+			m.Log.Info("skipping deletion of pull secret not owned by the registry namespace", "secret", pullSecret)
+			continue
+		}
+		err = m.Client.Delete(ctx, &secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSecretKey returns the secret key used to pull the images, read from the referenced
+// secret or generated by the operator
+func (m *NamespaceManager) getSecretKey(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace, registryNamespace *registry.Namespace) (string, error) {
+	if namespace.Spec.PullSecret.SecretKeyRef == nil {
+		return m.ensurePullKey(ctx, namespace, registryNamespace)
+	}
+
+	secret := corev1.Secret{}
+	err := m.Get(ctx, types.NamespacedName{
+		Name:      namespace.Spec.PullSecret.SecretKeyRef.Name,
+		Namespace: namespace.Namespace,
+	}, &secret)
+	if err != nil {
+		return "", err
+	}
+
+	secretKey, ok := secret.Data[namespace.Spec.PullSecret.SecretKeyRef.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s", namespace.Spec.PullSecret.SecretKeyRef.Key, secret.Name)
+	}
+
+	return string(secretKey), nil
+}
+
+// getWantedPullSecrets returns the pull secrets to generate, indexed by their namespace/name
+func getWantedPullSecrets(namespace *registryv1alpha1.RegistryNamespace) map[string]types.NamespacedName {
+	wantedSecrets := map[string]types.NamespacedName{}
+
+	if namespace.Spec.PullSecret == nil {
+		return wantedSecrets
+	}
+
+	secretName := namespace.Spec.PullSecret.Name
+	if secretName == "" {
+		secretName = namespace.Name
+	}
+
+	secretNamespaces := namespace.Spec.PullSecret.Namespaces
+	if len(secretNamespaces) == 0 {
+		secretNamespaces = []string{namespace.Namespace}
+	}
+
+	for _, secretNamespace := range secretNamespaces {
+		key := types.NamespacedName{Name: secretName, Namespace: secretNamespace}
+		wantedSecrets[key.String()] = key
+	}
+
+	return wantedSecrets
+}
+
+// getStalePullSecrets returns the existing pull secrets which are not wanted anymore
+func getStalePullSecrets(wantedSecrets map[string]types.NamespacedName, existingSecrets []string) []string {
+	staleSecrets := []string{}
+	for _, existingSecret := range existingSecrets {
+		if _, ok := wantedSecrets[existingSecret]; !ok {
+			staleSecrets = append(staleSecrets, existingSecret)
+		}
+	}
+	return staleSecrets
+}
+
+func isOwnedPullSecret(namespace *registryv1alpha1.RegistryNamespace, secret *corev1.Secret) bool {
+	return secret.Labels[PullSecretOwnerNameLabel] == namespace.Name &&
+		secret.Labels[PullSecretOwnerNamespaceLabel] == namespace.Namespace
+}
+
+type dockerConfigAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+type dockerConfig struct {
+	Auths map[string]dockerConfigAuth `json:"auths"`
+}
+
+// renderDockerConfig returns the .dockerconfigjson content to pull from the given endpoint
+func renderDockerConfig(endpoint string, secretKey string) ([]byte, error) {
+	// the endpoint is in the form registry/namespace
+	server := strings.SplitN(endpoint, "/", 2)[0]
+
+	return json.Marshal(dockerConfig{
+		Auths: map[string]dockerConfigAuth{
+			server: {
+				Username: registryUserName,
+				Password: secretKey,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(registryUserName + ":" + secretKey)),
+			},
+		},
+	})
+}
+
+func getNamespaceName(namespace *registryv1alpha1.RegistryNamespace) string {
+	if namespace.Spec.OverrideName != "" {
+		return namespace.Spec.OverrideName
+	}
+	return namespace.Name
+}
+
+func convertNamespace(obj runtime.Object) (*registryv1alpha1.RegistryNamespace, error) {
+	namespace, ok := obj.(*registryv1alpha1.RegistryNamespace)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return namespace, nil
+}
@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	registryv1alpha1 "github.com/scaleway/scaleway-operator/apis/registry/v1alpha1"
+)
+
+func Test_getWantedPullSecrets(t *testing.T) {
+	cases := []struct {
+		pullSecret *registryv1alpha1.RegistryPullSecret
+		wanted     []string
+	}{
+		{
+			pullSecret: nil,
+			wanted:     []string{},
+		},
+		{
+			pullSecret: &registryv1alpha1.RegistryPullSecret{},
+			wanted:     []string{"default/images"},
+		},
+		{
+			pullSecret: &registryv1alpha1.RegistryPullSecret{Name: "pull"},
+			wanted:     []string{"default/pull"},
+		},
+		{
+			pullSecret: &registryv1alpha1.RegistryPullSecret{Name: "pull", Namespaces: []string{"team-a", "team-b", "team-a"}},
+			wanted:     []string{"team-a/pull", "team-b/pull"},
+		},
+	}
+
+	for _, c := range cases {
+		namespace := &registryv1alpha1.RegistryNamespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "images", Namespace: "default"},
+			Spec:       registryv1alpha1.RegistryNamespaceSpec{PullSecret: c.pullSecret},
+		}
+		wantedSecrets := getWantedPullSecrets(namespace)
+		if len(wantedSecrets) != len(c.wanted) {
+			t.Errorf("Got %d secrets instead of %d", len(wantedSecrets), len(c.wanted))
+		}
+		for _, key := range c.wanted {
+			if _, ok := wantedSecrets[key]; !ok {
+				t.Errorf("Secret %s is missing from %v", key, wantedSecrets)
+			}
+		}
+	}
+}
+
+func Test_getStalePullSecrets(t *testing.T) {
+	cases := []struct {
+		namespaces []string
+		existing   []string
+		stale      []string
+	}{
+		{
+			namespaces: []string{"team-a"},
+			existing:   nil,
+			stale:      []string{},
+		},
+		{
+			namespaces: []string{"team-a", "team-b"},
+			existing:   []string{"team-a/pull", "team-b/pull"},
+			stale:      []string{},
+		},
+		{
+			namespaces: []string{"team-a"},
+			existing:   []string{"team-a/pull", "team-b/pull"},
+			stale:      []string{"team-b/pull"},
+		},
+		{
+			namespaces: []string{"team-c"},
+			existing:   []string{"team-a/pull", "team-b/pull"},
+			stale:      []string{"team-a/pull", "team-b/pull"},
+		},
+	}
+
+	for _, c := range cases {
+		namespace := &registryv1alpha1.RegistryNamespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "images", Namespace: "default"},
+			Spec: registryv1alpha1.RegistryNamespaceSpec{
+				PullSecret: &registryv1alpha1.RegistryPullSecret{Name: "pull", Namespaces: c.namespaces},
+			},
+		}
+		stale := getStalePullSecrets(getWantedPullSecrets(namespace), c.existing)
+		if !reflect.DeepEqual(stale, c.stale) {
+			t.Errorf("Got stale secrets %v instead of %v", stale, c.stale)
+		}
+	}
+}
+
+func Test_isOwnedPullSecret(t *testing.T) {
+	namespace := &registryv1alpha1.RegistryNamespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "images", Namespace: "default"},
+	}
+
+	cases := []struct {
+		labels map[string]string
+		owned  bool
+	}{
+		{
+			labels: nil,
+			owned:  false,
+		},
+		{
+			labels: map[string]string{PullSecretOwnerNameLabel: "images"},
+			owned:  false,
+		},
+		{
+			labels: map[string]string{PullSecretOwnerNameLabel: "images", PullSecretOwnerNamespaceLabel: "other"},
+			owned:  false,
+		},
+		{
+			labels: map[string]string{PullSecretOwnerNameLabel: "images", PullSecretOwnerNamespaceLabel: "default"},
+			owned:  true,
+		},
+	}
+
+	for _, c := range cases {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: c.labels}}
+		if owned := isOwnedPullSecret(namespace, secret); owned != c.owned {
+			t.Errorf("Got owned %t instead of %t for labels %v", owned, c.owned, c.labels)
+		}
+	}
+}
+
+func Test_renderDockerConfig(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		server   string
+	}{
+		{
+			endpoint: "rg.fr-par.scw.cloud/images",
+			server:   "rg.fr-par.scw.cloud",
+		},
+		{
+			endpoint: "rg.nl-ams.scw.cloud",
+			server:   "rg.nl-ams.scw.cloud",
+		},
+	}
+
+	for _, c := range cases {
+		content, err := renderDockerConfig(c.endpoint, "secret-key")
+		if err != nil {
+			t.Fatalf("Got error %v", err)
+		}
+
+		config := dockerConfig{}
+		err = json.Unmarshal(content, &config)
+		if err != nil {
+			t.Fatalf("Got invalid json %s: %v", content, err)
+		}
+
+		expected := dockerConfig{
+			Auths: map[string]dockerConfigAuth{
+				c.server: {
+					Username: "nologin",
+					Password: "secret-key",
+					Auth:     base64.StdEncoding.EncodeToString([]byte("nologin:secret-key")),
+				},
+			},
+		}
+		if !reflect.DeepEqual(config, expected) {
+			t.Errorf("Got docker config %+v instead of %+v", config, expected)
+		}
+	}
+}
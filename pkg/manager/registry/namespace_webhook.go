@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	registryv1alpha1 "github.com/scaleway/scaleway-operator/apis/registry/v1alpha1"
+)
+
+// ValidateCreate validates the creation of a Registry Namespace
+func (m *NamespaceManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	namespace, err := convertNamespace(obj)
+	if err != nil {
+		return nil, err
+	}
+	_, err = scw.ParseRegion(namespace.Spec.Region)
+	if namespace.Spec.Region != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("region"), namespace.Spec.Region, "region is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validatePullSecret(namespace)...)
+
+	if namespace.Spec.NamespaceID != "" {
+		registryNamespace, err := m.API.GetNamespace(&registry.GetNamespaceRequest{
+			Region:      scw.Region(namespace.Spec.Region),
+			NamespaceID: namespace.Spec.NamespaceID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("namespaceID"), namespace.Spec.NamespaceID, err.Error()))
+			return allErrs, nil
+		}
+		if namespace.Spec.OverrideName != "" && namespace.Spec.OverrideName != registryNamespace.Name {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("overrideName"), namespace.Spec.OverrideName, "name does not match"))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Registry Namespace
+func (m *NamespaceManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	namespace, err := convertNamespace(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldNamespace, err := convertNamespace(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldNamespace.Spec.NamespaceID != "" && oldNamespace.Spec.NamespaceID != namespace.Spec.NamespaceID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("namespaceID"), "field is immutable"))
+	}
+
+	if oldNamespace.Spec.Region != "" && oldNamespace.Spec.Region != namespace.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("region"), "field is immutable"))
+	}
+
+	if oldNamespace.Spec.OverrideName != namespace.Spec.OverrideName {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("overrideName"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validatePullSecret(namespace)...)
+
+	return allErrs, nil
+}
+
+func validatePullSecret(namespace *registryv1alpha1.RegistryNamespace) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if namespace.Spec.PullSecret == nil {
+		return allErrs
+	}
+
+	if namespace.Spec.PullSecret.SecretKeyRef == nil {
+		if namespace.Spec.PullSecret.Name == namespace.Name+PullKeySecretSuffix {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("pullSecret").Child("name"), namespace.Spec.PullSecret.Name, "name is used by the generated API key secret"))
+		}
+		return allErrs
+	}
+
+	if namespace.Spec.PullSecret.SecretKeyRef.Name == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("pullSecret").Child("secretKeyRef").Child("name"), "secret name must be specified"))
+	}
+	if namespace.Spec.PullSecret.SecretKeyRef.Key == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("pullSecret").Child("secretKeyRef").Child("key"), "secret key must be specified"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,273 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	registryv1alpha1 "github.com/scaleway/scaleway-operator/apis/registry/v1alpha1"
+	iammanager "github.com/scaleway/scaleway-operator/pkg/manager/iam"
+)
+
+const (
+	// PullKeySecretSuffix is the suffix of the name of the secret holding the API key
+	// generated to pull the images of a registry namespace
+	PullKeySecretSuffix = "-pull-key"
+
+	// registryReadPermissionSet is the IAM permission set allowing to pull the images of a project
+	registryReadPermissionSet = "ContainerRegistryReadOnly"
+)
+
+// ensurePullKey creates the IAM application, policy and API key used to pull the images
+// of the registry namespace, and returns the secret key of the API key
+// The secret key is only returned on creation, so it is kept in the pull key secret
+// and a new API key is created whenever this secret is lost
+func (m *NamespaceManager) ensurePullKey(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace, registryNamespace *registry.Namespace) (string, error) {
+	if namespace.Spec.PullApplicationID == "" {
+		applicationResp, err := m.IAMAPI.CreateApplication(&iam.CreateApplicationRequest{
+			Name:        getPullApplicationName(namespace),
+			Description: fmt.Sprintf("Pulls the images of the registry namespace %s", registryNamespace.ID),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return "", err
+		}
+
+		namespace.Spec.PullApplicationID = applicationResp.ID
+		err = m.updateSpec(ctx, namespace)
+		if err != nil {
+			deleteErr := m.IAMAPI.DeleteApplication(&iam.DeleteApplicationRequest{
+				ApplicationID: applicationResp.ID,
+			}, scw.WithContext(ctx))
+			if deleteErr != nil {
+				m.Log.Error(deleteErr, "failed to delete unsaved pull application", "applicationID", applicationResp.ID)
+			}
+			return "", err
+		}
+	}
+
+	if namespace.Spec.PullPolicyID == "" {
+		policyResp, err := m.IAMAPI.CreatePolicy(&iam.CreatePolicyRequest{
+			Name:          getPullApplicationName(namespace),
+			Description:   fmt.Sprintf("Allows to pull the images of the registry namespace %s", registryNamespace.ID),
+			ApplicationID: scw.StringPtr(namespace.Spec.PullApplicationID),
+			Rules: []*iam.RuleSpecs{
+				{
+					PermissionSetNames: &[]string{registryReadPermissionSet},
+					ProjectIDs:         &[]string{registryNamespace.ProjectID},
+				},
+			},
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return "", err
+		}
+
+		namespace.Spec.PullPolicyID = policyResp.ID
+		err = m.updateSpec(ctx, namespace)
+		if err != nil {
+			deleteErr := m.IAMAPI.DeletePolicy(&iam.DeletePolicyRequest{
+				PolicyID: policyResp.ID,
+			}, scw.WithContext(ctx))
+			if deleteErr != nil {
+				m.Log.Error(deleteErr, "failed to delete unsaved pull policy", "policyID", policyResp.ID)
+			}
+			return "", err
+		}
+	}
+
+	secret, err := m.getPullKeySecret(ctx, namespace)
+	if err != nil {
+		return "", err
+	}
+	if secret != nil && !metav1.IsControlledBy(secret, namespace) {
+		return "", fmt.Errorf("secret %s/%s already exists and is not controlled by this registry namespace", secret.Namespace, secret.Name)
+	}
+
+	if secretKey, ok := getPullKey(namespace, secret); ok {
+		return secretKey, nil
+	}
+
+	apiKeyResp, err := m.IAMAPI.CreateAPIKey(&iam.CreateAPIKeyRequest{
+		ApplicationID:    scw.StringPtr(namespace.Spec.PullApplicationID),
+		DefaultProjectID: scw.StringPtr(registryNamespace.ProjectID),
+		Description:      fmt.Sprintf("Pulls the images of the registry namespace %s", registryNamespace.ID),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	// the API keys saved in the spec or written in the secret are replaced by the new one
+	staleAccessKeys := []string{namespace.Spec.PullAccessKey}
+	if secret != nil {
+		staleAccessKeys = append(staleAccessKeys, string(secret.Data[iammanager.APIKeySecretAccessKeyKey]))
+	}
+
+	// the access key is saved before writing the secret, so the API key is never leaked
+	namespace.Spec.PullAccessKey = apiKeyResp.AccessKey
+	err = m.updateSpec(ctx, namespace)
+	if err != nil {
+		deleteErr := m.deleteAPIKey(ctx, apiKeyResp.AccessKey)
+		if deleteErr != nil {
+			m.Log.Error(deleteErr, "failed to delete unsaved pull API key", "accessKey", apiKeyResp.AccessKey)
+		}
+		return "", err
+	}
+
+	if apiKeyResp.SecretKey == nil {
+		return "", fmt.Errorf("no secret key returned for API key %s", apiKeyResp.AccessKey)
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespace.Name + PullKeySecretSuffix,
+			Namespace: namespace.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, m.Client, secret, func() error {
+		secret.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(namespace, registryv1alpha1.GroupVersion.WithKind("RegistryNamespace")),
+		}
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[PullSecretOwnerNameLabel] = namespace.Name
+		secret.Labels[PullSecretOwnerNamespaceLabel] = namespace.Namespace
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{
+			iammanager.APIKeySecretAccessKeyKey:        []byte(apiKeyResp.AccessKey),
+			iammanager.APIKeySecretSecretKeyKey:        []byte(*apiKeyResp.SecretKey),
+			iammanager.APIKeySecretDefaultProjectIDKey: []byte(apiKeyResp.DefaultProjectID),
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, accessKey := range staleAccessKeys {
+		if accessKey == "" || accessKey == apiKeyResp.AccessKey {
+			continue
+		}
+		err = m.deleteAPIKey(ctx, accessKey)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return *apiKeyResp.SecretKey, nil
+}
+
+// deletePullKey deletes the IAM application, policy and API key created to pull the images
+// of the registry namespace, along with the pull key secret
+func (m *NamespaceManager) deletePullKey(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace) error {
+	if namespace.Spec.PullAccessKey != "" {
+		err := m.deleteAPIKey(ctx, namespace.Spec.PullAccessKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	if namespace.Spec.PullPolicyID != "" {
+		err := m.IAMAPI.DeletePolicy(&iam.DeletePolicyRequest{
+			PolicyID: namespace.Spec.PullPolicyID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+				return err
+			}
+		}
+	}
+
+	if namespace.Spec.PullApplicationID != "" {
+		err := m.IAMAPI.DeleteApplication(&iam.DeleteApplicationRequest{
+			ApplicationID: namespace.Spec.PullApplicationID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+				return err
+			}
+		}
+	}
+
+	secret, err := m.getPullKeySecret(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if secret != nil && metav1.IsControlledBy(secret, namespace) {
+		err = m.Client.Delete(ctx, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteAPIKey deletes the given API key, ignoring already deleted ones
+func (m *NamespaceManager) deleteAPIKey(ctx context.Context, accessKey string) error {
+	err := m.IAMAPI.DeleteAPIKey(&iam.DeleteAPIKeyRequest{
+		AccessKey: accessKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// getPullKeySecret returns the pull key secret, or nil if it does not exist
+func (m *NamespaceManager) getPullKeySecret(ctx context.Context, namespace *registryv1alpha1.RegistryNamespace) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := m.Get(ctx, types.NamespacedName{
+		Name:      namespace.Name + PullKeySecretSuffix,
+		Namespace: namespace.Namespace,
+	}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// getPullKey returns the secret key of the current pull API key held by the given secret,
+// and false if the secret does not hold it
+func getPullKey(namespace *registryv1alpha1.RegistryNamespace, secret *corev1.Secret) (string, bool) {
+	if namespace.Spec.PullAccessKey == "" || secret == nil {
+		return "", false
+	}
+	if string(secret.Data[iammanager.APIKeySecretAccessKeyKey]) != namespace.Spec.PullAccessKey {
+		return "", false
+	}
+	secretKey := secret.Data[iammanager.APIKeySecretSecretKeyKey]
+	if len(secretKey) == 0 {
+		return "", false
+	}
+	return string(secretKey), true
+}
+
+// needsPullKey returns whether the operator generates the API key used to pull the images
+func needsPullKey(namespace *registryv1alpha1.RegistryNamespace) bool {
+	return namespace.Spec.PullSecret != nil && namespace.Spec.PullSecret.SecretKeyRef == nil
+}
+
+// hasPullKey returns whether some of the IAM resources used to pull the images were created
+func hasPullKey(namespace *registryv1alpha1.RegistryNamespace) bool {
+	return namespace.Spec.PullApplicationID != "" || namespace.Spec.PullPolicyID != "" || namespace.Spec.PullAccessKey != ""
+}
+
+// getPullApplicationName returns the name of the IAM application and policy created
+// to pull the images of the registry namespace
+func getPullApplicationName(namespace *registryv1alpha1.RegistryNamespace) string {
+	return "registry-pull-" + namespace.Namespace + "-" + namespace.Name
+}
@@ -0,0 +1,108 @@
+package registry
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	registryv1alpha1 "github.com/scaleway/scaleway-operator/apis/registry/v1alpha1"
+	iammanager "github.com/scaleway/scaleway-operator/pkg/manager/iam"
+)
+
+func Test_getPullKey(t *testing.T) {
+	cases := []struct {
+		accessKey string
+		secret    *corev1.Secret
+		secretKey string
+		ok        bool
+	}{
+		{
+			accessKey: "",
+			secret: &corev1.Secret{Data: map[string][]byte{
+				iammanager.APIKeySecretAccessKeyKey: []byte("SCWXXX"),
+				iammanager.APIKeySecretSecretKeyKey: []byte("secret"),
+			}},
+			ok: false,
+		},
+		{
+			accessKey: "SCWXXX",
+			secret:    nil,
+			ok:        false,
+		},
+		{
+			accessKey: "SCWXXX",
+			secret: &corev1.Secret{Data: map[string][]byte{
+				iammanager.APIKeySecretAccessKeyKey: []byte("SCWYYY"),
+				iammanager.APIKeySecretSecretKeyKey: []byte("secret"),
+			}},
+			ok: false,
+		},
+		{
+			accessKey: "SCWXXX",
+			secret: &corev1.Secret{Data: map[string][]byte{
+				iammanager.APIKeySecretAccessKeyKey: []byte("SCWXXX"),
+			}},
+			ok: false,
+		},
+		{
+			accessKey: "SCWXXX",
+			secret: &corev1.Secret{Data: map[string][]byte{
+				iammanager.APIKeySecretAccessKeyKey: []byte("SCWXXX"),
+				iammanager.APIKeySecretSecretKeyKey: []byte("secret"),
+			}},
+			secretKey: "secret",
+			ok:        true,
+		},
+	}
+
+	for i, c := range cases {
+		namespace := &registryv1alpha1.RegistryNamespace{
+			Spec: registryv1alpha1.RegistryNamespaceSpec{
+				PullAccessKey: c.accessKey,
+			},
+		}
+		secretKey, ok := getPullKey(namespace, c.secret)
+		if ok != c.ok {
+			t.Errorf("case %d: got %t instead of %t", i, ok, c.ok)
+		}
+		if secretKey != c.secretKey {
+			t.Errorf("case %d: got secret key %s instead of %s", i, secretKey, c.secretKey)
+		}
+	}
+}
+
+func Test_needsPullKey(t *testing.T) {
+	cases := []struct {
+		pullSecret *registryv1alpha1.RegistryPullSecret
+		needed     bool
+	}{
+		{
+			pullSecret: nil,
+			needed:     false,
+		},
+		{
+			pullSecret: &registryv1alpha1.RegistryPullSecret{},
+			needed:     true,
+		},
+		{
+			pullSecret: &registryv1alpha1.RegistryPullSecret{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "registry-api-key"},
+					Key:                  iammanager.APIKeySecretSecretKeyKey,
+				},
+			},
+			needed: false,
+		},
+	}
+
+	for i, c := range cases {
+		namespace := &registryv1alpha1.RegistryNamespace{
+			Spec: registryv1alpha1.RegistryNamespaceSpec{
+				PullSecret: c.pullSecret,
+			},
+		}
+		if needed := needsPullKey(namespace); needed != c.needed {
+			t.Errorf("case %d: got %t instead of %t", i, needed, c.needed)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package scaleway
+
+import (
+	"context"
+)
+
+type reconcileCacheKey struct{}
+
+// ReconcileCache holds the remote state fetched by the managers during a single reconcile,
+// so the state fetched to detect the drift is reused to reconcile the resource
+type ReconcileCache struct {
+	values map[string]interface{}
+}
+
+// WithReconcileCache returns a context holding a new reconcile cache
+func WithReconcileCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reconcileCacheKey{}, &ReconcileCache{values: map[string]interface{}{}})
+}
+
+// CacheValue stores the given value in the reconcile cache, if any
+func CacheValue(ctx context.Context, key string, value interface{}) {
+	cache, ok := ctx.Value(reconcileCacheKey{}).(*ReconcileCache)
+	if !ok {
+		return
+	}
+	cache.values[key] = value
+}
+
+// TakeCachedValue returns the value stored in the reconcile cache and removes it,
+// so a value is never reused once the resource may have been updated
+func TakeCachedValue(ctx context.Context, key string) (interface{}, bool) {
+	cache, ok := ctx.Value(reconcileCacheKey{}).(*ReconcileCache)
+	if !ok {
+		return nil, false
+	}
+	value, ok := cache.values[key]
+	if ok {
+		delete(cache.values, key)
+	}
+	return value, ok
+}
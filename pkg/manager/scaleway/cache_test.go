@@ -0,0 +1,25 @@
+package scaleway
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_TakeCachedValue(t *testing.T) {
+	CacheValue(context.Background(), "key", "value")
+	if _, ok := TakeCachedValue(context.Background(), "key"); ok {
+		t.Errorf("value cached without reconcile cache")
+	}
+
+	ctx := WithReconcileCache(context.Background())
+	CacheValue(ctx, "key", "value")
+
+	value, ok := TakeCachedValue(ctx, "key")
+	if !ok || value != "value" {
+		t.Errorf("Got %v instead of value", value)
+	}
+
+	if _, ok := TakeCachedValue(ctx, "key"); ok {
+		t.Errorf("value reused after being taken")
+	}
+}
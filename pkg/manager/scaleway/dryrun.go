@@ -0,0 +1,48 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+)
+
+type plannerKey struct{}
+
+// Planner records the actions the managers would execute in dry-run mode
+type Planner struct {
+	actions []string
+}
+
+// Actions returns the recorded actions
+func (p *Planner) Actions() []string {
+	return p.actions
+}
+
+// WithPlanner returns a context in which the managers run in dry-run mode,
+// recording their actions in the given planner instead of executing them
+func WithPlanner(ctx context.Context, planner *Planner) context.Context {
+	return context.WithValue(ctx, plannerKey{}, planner)
+}
+
+// PlanAction records the given action when running in dry-run mode
+// It returns true when the action was planned, and therefore must not be executed
+func PlanAction(ctx context.Context, format string, args ...interface{}) bool {
+	planner, ok := ctx.Value(plannerKey{}).(*Planner)
+	if !ok {
+		return false
+	}
+	planner.actions = append(planner.actions, fmt.Sprintf(format, args...))
+	return true
+}
+
+// DryRunner is the interface implemented by the managers supporting the dry-run mode,
+// meaning they call PlanAction before each mutating call
+type DryRunner interface {
+	// SupportsDryRun returns whether the manager supports the dry-run mode
+	SupportsDryRun() bool
+}
+
+// SupportsDryRun returns whether the given manager supports the dry-run mode
+func SupportsDryRun(manager Manager) bool {
+	dryRunner, ok := manager.(DryRunner)
+	return ok && dryRunner.SupportsDryRun()
+}
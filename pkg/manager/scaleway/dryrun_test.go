@@ -0,0 +1,62 @@
+package scaleway
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_PlanAction(t *testing.T) {
+	if PlanAction(context.Background(), "create %s", "instance") {
+		t.Errorf("action planned without planner")
+	}
+
+	planner := &Planner{}
+	ctx := WithPlanner(context.Background(), planner)
+	if !PlanAction(ctx, "create %s", "instance") {
+		t.Errorf("action not planned with planner")
+	}
+	if !PlanAction(ctx, "set %d acl rules", 2) {
+		t.Errorf("action not planned with planner")
+	}
+
+	expected := []string{"create instance", "set 2 acl rules"}
+	if !reflect.DeepEqual(planner.Actions(), expected) {
+		t.Errorf("got %v instead of %v", planner.Actions(), expected)
+	}
+}
+
+type dryRunManager struct {
+	Manager
+	supported bool
+}
+
+func (m *dryRunManager) SupportsDryRun() bool {
+	return m.supported
+}
+
+func Test_SupportsDryRun(t *testing.T) {
+	cases := []struct {
+		manager   Manager
+		supported bool
+	}{
+		{
+			manager:   nil,
+			supported: false,
+		},
+		{
+			manager:   &dryRunManager{supported: false},
+			supported: false,
+		},
+		{
+			manager:   &dryRunManager{supported: true},
+			supported: true,
+		},
+	}
+
+	for i, c := range cases {
+		if supported := SupportsDryRun(c.manager); supported != c.supported {
+			t.Errorf("case %d: got %t instead of %t", i, supported, c.supported)
+		}
+	}
+}
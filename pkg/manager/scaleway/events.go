@@ -0,0 +1,81 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the events emitted for the remote mutations, shared by all the products
+const (
+	ReasonInstanceCreated        = "InstanceCreated"
+	ReasonInstanceCloned         = "InstanceCloned"
+	ReasonInstanceUpdated        = "InstanceUpdated"
+	ReasonInstanceDeleted        = "InstanceDeleted"
+	ReasonNodeTypeUpgradeStarted = "NodeTypeUpgradeStarted"
+	ReasonHAUpgradeStarted       = "HAUpgradeStarted"
+	ReasonBackupScheduleUpdated  = "BackupScheduleUpdated"
+	ReasonACLUpdated             = "ACLUpdated"
+	ReasonDatabaseCreated        = "DatabaseCreated"
+	ReasonDatabaseDeleted        = "DatabaseDeleted"
+	ReasonUserCreated            = "UserCreated"
+	ReasonUserUpdated            = "UserUpdated"
+	ReasonUserDeleted            = "UserDeleted"
+)
+
+// Reasons of the events emitted for the drifts that can't be reconciled
+const (
+	ReasonDHCPMismatch = "DHCPMismatch"
+)
+
+// Reasons of the events and conditions for the classified Scaleway errors
+const (
+	ReasonTransientState    = "TransientState"
+	ReasonResourceNotFound  = "ResourceNotFound"
+	ReasonPermissionsDenied = "PermissionsDenied"
+	ReasonOutOfStock        = "OutOfStock"
+	ReasonQuotasExceeded    = "QuotasExceeded"
+	ReasonResourceLocked    = "ResourceLocked"
+	ReasonInvalidArguments  = "InvalidArguments"
+)
+
+// GetErrorReason returns the reason of the given Scaleway error, and false
+// if the error is not classified
+func GetErrorReason(err error) (string, bool) {
+	switch err.(type) {
+	case *scw.ResourceNotFoundError:
+		return ReasonResourceNotFound, true
+	case *scw.InvalidArgumentsError:
+		return ReasonInvalidArguments, true
+	case *scw.PermissionsDeniedError:
+		return ReasonPermissionsDenied, true
+	case *scw.OutOfStockError:
+		return ReasonOutOfStock, true
+	case *scw.QuotasExceededError:
+		return ReasonQuotasExceeded, true
+	case *scw.ResourceLockedError:
+		return ReasonResourceLocked, true
+	case *scw.TransientStateError:
+		return ReasonTransientState, true
+	}
+	return "", false
+}
+
+type recorderKey struct{}
+
+// WithEventRecorder returns a context in which the managers record their events
+// with the given recorder
+func WithEventRecorder(ctx context.Context, recorder record.EventRecorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// RecordEvent records an event on the given object, when the context has a recorder
+func RecordEvent(ctx context.Context, obj runtime.Object, eventType string, reason string, format string, args ...interface{}) {
+	recorder, ok := ctx.Value(recorderKey{}).(record.EventRecorder)
+	if !ok {
+		return
+	}
+	recorder.Eventf(obj, eventType, reason, format, args...)
+}
@@ -0,0 +1,48 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func Test_RecordEvent(t *testing.T) {
+	obj := &corev1.ConfigMap{}
+
+	// no recorder in the context, nothing to record
+	RecordEvent(context.Background(), obj, corev1.EventTypeNormal, ReasonInstanceCreated, "Created instance %s", "id")
+
+	recorder := record.NewFakeRecorder(1)
+	ctx := WithEventRecorder(context.Background(), recorder)
+	RecordEvent(ctx, obj, corev1.EventTypeNormal, ReasonInstanceCreated, "Created instance %s", "id")
+
+	expected := "Normal InstanceCreated Created instance id"
+	if event := <-recorder.Events; event != expected {
+		t.Errorf("got %s instead of %s", event, expected)
+	}
+}
+
+func Test_GetErrorReason(t *testing.T) {
+	cases := []struct {
+		err        error
+		reason     string
+		classified bool
+	}{
+		{&scw.ResourceNotFoundError{}, ReasonResourceNotFound, true},
+		{&scw.TransientStateError{}, ReasonTransientState, true},
+		{&scw.QuotasExceededError{}, ReasonQuotasExceeded, true},
+		{&scw.ResourceLockedError{}, ReasonResourceLocked, true},
+		{errors.New("error"), "", false},
+	}
+
+	for i, c := range cases {
+		reason, classified := GetErrorReason(c.err)
+		if reason != c.reason || classified != c.classified {
+			t.Errorf("case %d: got %s/%t instead of %s/%t", i, reason, classified, c.reason, c.classified)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package scaleway
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,3 +30,27 @@ type Manager interface {
 	// ValidateUpdate is the method to implement for the update validation
 	ValidateUpdate(context.Context, runtime.Object, runtime.Object) (field.ErrorList, error)
 }
+
+// Refresher is the interface implemented by the managers needing to reconcile
+// their resources periodically, even once reconciled
+type Refresher interface {
+	// GetRefreshInterval returns the duration after which the resource must be reconciled again
+	// A zero duration disables the refresh
+	GetRefreshInterval(runtime.Object) time.Duration
+}
+
+// DriftDetector is the interface implemented by the managers able to compare
+// the remote state of their resources with the spec, without modifying them
+type DriftDetector interface {
+	// GetDrift returns the fields of the resource whose remote state differs from the spec
+	// It is only called on resources already reconciled once
+	GetDrift(context.Context, runtime.Object) ([]string, error)
+}
+
+// Defaulter is the interface implemented by the managers filling the defaults
+// of their resources, so the persisted spec is the one acted upon
+type Defaulter interface {
+	// Default sets the default values of the resource
+	// It is called by the mutating webhook, before the validation
+	Default(context.Context, runtime.Object) error
+}
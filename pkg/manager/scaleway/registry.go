@@ -0,0 +1,134 @@
+package scaleway
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// Product is a Scaleway product handled by the operator
+type Product struct {
+	// Name is the name used to enable or disable the product, such as rdb
+	Name string
+	// AddToScheme adds the kinds of the product to the given scheme
+	AddToScheme func(*runtime.Scheme) error
+	// Kinds are the kinds of the product
+	Kinds []Kind
+}
+
+// Kind is a kind of a Scaleway product
+type Kind struct {
+	// Name is the name of the kind, such as RDBInstance
+	Name string
+	// NewManager returns the manager of the kind
+	NewManager func(c client.Client, scwClient *scw.Client, log logr.Logger) Manager
+	// ControllerOptions are the options of the controller of the kind
+	ControllerOptions controller.Options
+	// ResyncPeriod is the default resync period of the kind
+	// A zero period uses the resync period of the operator
+	ResyncPeriod time.Duration
+	// SetupController registers the controller of the kind
+	SetupController func(mgr ctrl.Manager, manager Manager, config ControllerConfig) error
+	// SetupWebhook registers the validating webhook of the kind
+	// It is nil for kinds without webhook
+	SetupWebhook func(mgr ctrl.Manager, manager Manager, log logr.Logger) error
+}
+
+// ControllerConfig is the configuration of the controller of a kind
+type ControllerConfig struct {
+	// Options are the options of the controller
+	Options controller.Options
+	// ResyncPeriod is the duration after which reconciled resources are reconciled again,
+	// to detect and correct their drift. A zero period disables the resync
+	ResyncPeriod time.Duration
+	// DryRun runs the controller in dry-run mode for all the resources
+	DryRun bool
+	// EnabledProducts is the set of the names of the enabled products
+	// The kinds of the other products are not watched, as their CRDs may not be installed
+	EnabledProducts map[string]bool
+}
+
+var products = map[string]Product{}
+
+// RegisterProduct registers a product, so its controllers and webhooks are run by the operator
+// It is meant to be called from the init function of the product package
+func RegisterProduct(product Product) {
+	if _, ok := products[product.Name]; ok {
+		panic(fmt.Sprintf("product %s is already registered", product.Name))
+	}
+	products[product.Name] = product
+}
+
+// GetProducts returns the registered products, sorted by name
+func GetProducts() []Product {
+	names := make([]string, 0, len(products))
+	for name := range products {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	registered := make([]Product, 0, len(names))
+	for _, name := range names {
+		registered = append(registered, products[name])
+	}
+
+	return registered
+}
+
+// SelectProducts returns the registered products which are enabled and not disabled
+// An empty enabled list enables all the registered products
+func SelectProducts(enabled []string, disabled []string) ([]Product, error) {
+	for _, name := range append(append([]string{}, enabled...), disabled...) {
+		if _, ok := products[name]; !ok {
+			return nil, fmt.Errorf("unknown product %s, available products are %s", name, strings.Join(getProductNames(), ","))
+		}
+	}
+
+	enabledSet := make(map[string]bool, len(enabled))
+	for _, name := range enabled {
+		enabledSet[name] = true
+	}
+
+	disabledSet := make(map[string]bool, len(disabled))
+	for _, name := range disabled {
+		disabledSet[name] = true
+	}
+
+	selected := []Product{}
+	for _, product := range GetProducts() {
+		if len(enabled) > 0 && !enabledSet[product.Name] {
+			continue
+		}
+		if disabledSet[product.Name] {
+			continue
+		}
+		selected = append(selected, product)
+	}
+
+	return selected, nil
+}
+
+// ProductNames returns the set of the names of the given products
+func ProductNames(products []Product) map[string]bool {
+	names := make(map[string]bool, len(products))
+	for _, product := range products {
+		names[product.Name] = true
+	}
+	return names
+}
+
+func getProductNames() []string {
+	names := []string{}
+	for _, product := range GetProducts() {
+		names = append(names, product.Name)
+	}
+	return names
+}
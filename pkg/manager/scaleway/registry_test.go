@@ -0,0 +1,85 @@
+package scaleway
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_SelectProducts(t *testing.T) {
+	defer func(registered map[string]Product) {
+		products = registered
+	}(products)
+
+	products = map[string]Product{}
+	RegisterProduct(Product{Name: "rdb"})
+	RegisterProduct(Product{Name: "redis"})
+	RegisterProduct(Product{Name: "vpc"})
+
+	cases := []struct {
+		enabled  []string
+		disabled []string
+		selected []string
+		err      bool
+	}{
+		{
+			enabled:  nil,
+			disabled: nil,
+			selected: []string{"rdb", "redis", "vpc"},
+		},
+		{
+			enabled:  []string{"vpc", "rdb"},
+			disabled: nil,
+			selected: []string{"rdb", "vpc"},
+		},
+		{
+			enabled:  nil,
+			disabled: []string{"redis"},
+			selected: []string{"rdb", "vpc"},
+		},
+		{
+			enabled:  []string{"rdb", "redis"},
+			disabled: []string{"redis"},
+			selected: []string{"rdb"},
+		},
+		{
+			enabled:  []string{"unknown"},
+			disabled: nil,
+			err:      true,
+		},
+		{
+			enabled:  nil,
+			disabled: []string{"unknown"},
+			err:      true,
+		},
+	}
+
+	for i, c := range cases {
+		selected, err := SelectProducts(c.enabled, c.disabled)
+		if c.err {
+			if err == nil {
+				t.Errorf("case %d: expected an error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case %d: got unexpected error %v", i, err)
+			continue
+		}
+
+		names := []string{}
+		for _, product := range selected {
+			names = append(names, product.Name)
+		}
+		if !reflect.DeepEqual(names, c.selected) {
+			t.Errorf("case %d: got %v instead of %v", i, names, c.selected)
+		}
+	}
+}
+
+func Test_ProductNames(t *testing.T) {
+	names := ProductNames([]Product{{Name: "rdb"}, {Name: "vpc"}})
+	expected := map[string]bool{"rdb": true, "vpc": true}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Got %v instead of %v", names, expected)
+	}
+}
@@ -0,0 +1,408 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/secret/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	secretv1alpha1 "github.com/scaleway/scaleway-operator/apis/secret/v1alpha1"
+)
+
+const (
+	// TargetSecretOwnerNameLabel is the label holding the name of the ScalewaySecret
+	// owning a pulled Kubernetes Secret
+	TargetSecretOwnerNameLabel = "secret.scaleway.com/owner-name"
+
+	// DefaultValueKey is the Kubernetes Secret key holding unstructured secrets
+	DefaultValueKey = "value"
+
+	defaultPath     = "/"
+	defaultRevision = "latest"
+)
+
+// SecretManager manages the Secret Manager secrets
+type SecretManager struct {
+	client.Client
+	API *secret.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the Secret Manager secret resource
+func (m *SecretManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	scwSecret, err := convertSecret(obj)
+	if err != nil {
+		return false, err
+	}
+
+	// if secretID is empty, we need to find or create the secret
+	if scwSecret.Spec.SecretID == "" {
+		return false, m.resolveSecret(ctx, scwSecret)
+	}
+
+	if getDirection(scwSecret) == secretv1alpha1.SecretDirectionPush {
+		return m.push(ctx, scwSecret)
+	}
+
+	return m.pull(ctx, scwSecret)
+}
+
+// Delete deletes the Secret Manager secret resource
+// Only the secrets created by the operator are deleted, so pulled and adopted
+// secrets are left untouched
+func (m *SecretManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	scwSecret, err := convertSecret(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := scwSecret.Spec.SecretID
+	if resourceID == "" || !scwSecret.Status.Created {
+		return true, nil
+	}
+
+	err = m.API.DeleteSecret(&secret.DeleteSecretRequest{
+		Region:   scw.Region(scwSecret.Spec.Region),
+		SecretID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the Secret Manager secret resource
+func (m *SecretManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+// GetRefreshInterval returns the interval at which pulled secrets are pulled again
+func (m *SecretManager) GetRefreshInterval(obj runtime.Object) time.Duration {
+	scwSecret, err := convertSecret(obj)
+	if err != nil {
+		return 0
+	}
+
+	if getDirection(scwSecret) != secretv1alpha1.SecretDirectionPull || scwSecret.Spec.RefreshInterval == nil {
+		return 0
+	}
+
+	return scwSecret.Spec.RefreshInterval.Duration
+}
+
+// resolveSecret looks for the secret by name, and creates it in Push mode if it does not exist
+func (m *SecretManager) resolveSecret(ctx context.Context, scwSecret *secretv1alpha1.ScalewaySecret) error {
+	secretName := getSecretName(scwSecret)
+
+	secretsResp, err := m.API.ListSecrets(&secret.ListSecretsRequest{
+		Region: scw.Region(scwSecret.Spec.Region),
+		Name:   scw.StringPtr(secretName),
+		Path:   scw.StringPtr(getPath(scwSecret)),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	created := false
+	switch {
+	case len(secretsResp.Secrets) > 0:
+		scwSecret.Spec.SecretID = secretsResp.Secrets[0].ID
+	case getDirection(scwSecret) == secretv1alpha1.SecretDirectionPush:
+		secretType := secret.SecretTypeKeyValue
+		if scwSecret.Spec.Source != nil && scwSecret.Spec.Source.Key != "" {
+			secretType = secret.SecretTypeOpaque
+		}
+
+		secretResp, err := m.API.CreateSecret(&secret.CreateSecretRequest{
+			Region:      scw.Region(scwSecret.Spec.Region),
+			Name:        secretName,
+			Path:        scw.StringPtr(getPath(scwSecret)),
+			Type:        secretType,
+			Description: scw.StringPtr("managed by scaleway-operator"),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		scwSecret.Spec.SecretID = secretResp.ID
+		created = true
+	default:
+		return &scw.ResourceNotFoundError{
+			Resource:   "secret",
+			ResourceID: secretName,
+		}
+	}
+
+	err = m.Client.Update(ctx, scwSecret)
+	if err != nil {
+		return err
+	}
+
+	// the status is saved right away, as only created secrets are deleted
+	if created {
+		scwSecret.Status.Created = true
+		err = m.Client.Status().Update(ctx, scwSecret)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pull materializes the secret version into the target Kubernetes Secret
+func (m *SecretManager) pull(ctx context.Context, scwSecret *secretv1alpha1.ScalewaySecret) (bool, error) {
+	revision := scwSecret.Spec.Revision
+	if revision == "" {
+		revision = defaultRevision
+	}
+
+	versionResp, err := m.API.AccessSecretVersion(&secret.AccessSecretVersionRequest{
+		Region:   scw.Region(scwSecret.Spec.Region),
+		SecretID: scwSecret.Spec.SecretID,
+		Revision: revision,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	data, err := getTargetData(versionResp.Data, versionResp.Type, scwSecret.Spec.Target)
+	if err != nil {
+		return false, err
+	}
+
+	targetSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getTargetName(scwSecret),
+			Namespace: scwSecret.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, m.Client, targetSecret, func() error {
+		// refuse to overwrite a secret the operator did not create
+		if targetSecret.ResourceVersion != "" && !isOwnedTargetSecret(scwSecret, targetSecret) {
+			return fmt.Errorf("secret %s already exists and is not owned by this ScalewaySecret", targetSecret.Name)
+		}
+		if targetSecret.Labels == nil {
+			targetSecret.Labels = map[string]string{}
+		}
+		targetSecret.Labels[TargetSecretOwnerNameLabel] = scwSecret.Name
+		targetSecret.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(scwSecret, secretv1alpha1.GroupVersion.WithKind("ScalewaySecret")),
+		}
+		// the type of a secret is immutable
+		if targetSecret.CreationTimestamp.IsZero() {
+			targetSecret.Type = corev1.SecretTypeOpaque
+			if scwSecret.Spec.Target != nil && scwSecret.Spec.Target.Type != "" {
+				targetSecret.Type = scwSecret.Spec.Target.Type
+			}
+		}
+		targetSecret.Data = data
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	dataHash := hashData(scwSecret, data)
+	if scwSecret.Status.DataHash != dataHash || scwSecret.Status.Revision != int64(versionResp.Revision) {
+		now := metav1.Now()
+		scwSecret.Status.LastSyncTime = &now
+	}
+	scwSecret.Status.Revision = int64(versionResp.Revision)
+	scwSecret.Status.DataHash = dataHash
+	scwSecret.Status.TargetSecret = targetSecret.Name
+
+	return true, nil
+}
+
+// push creates a new secret version whenever the source Kubernetes Secret changes
+func (m *SecretManager) push(ctx context.Context, scwSecret *secretv1alpha1.ScalewaySecret) (bool, error) {
+	if scwSecret.Spec.Source == nil {
+		return false, fmt.Errorf("source must be specified in Push mode")
+	}
+
+	sourceSecret := corev1.Secret{}
+	err := m.Get(ctx, types.NamespacedName{
+		Name:      scwSecret.Spec.Source.Name,
+		Namespace: scwSecret.Namespace,
+	}, &sourceSecret)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := getSourceData(&sourceSecret, scwSecret.Spec.Source.Key)
+	if err != nil {
+		return false, err
+	}
+
+	dataHash := hashData(scwSecret, map[string][]byte{DefaultValueKey: data})
+	if scwSecret.Status.DataHash == dataHash {
+		return true, nil
+	}
+
+	versionResp, err := m.API.CreateSecretVersion(&secret.CreateSecretVersionRequest{
+		Region:      scw.Region(scwSecret.Spec.Region),
+		SecretID:    scwSecret.Spec.SecretID,
+		Data:        data,
+		Description: scw.StringPtr(fmt.Sprintf("pushed from %s/%s", sourceSecret.Namespace, sourceSecret.Name)),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	now := metav1.Now()
+	scwSecret.Status.LastSyncTime = &now
+	scwSecret.Status.Revision = int64(versionResp.Revision)
+	scwSecret.Status.DataHash = dataHash
+
+	return true, nil
+}
+
+// getTargetData maps the secret data to the Kubernetes Secret keys
+func getTargetData(data []byte, secretType secret.SecretType, target *secretv1alpha1.ScalewaySecretTarget) (map[string][]byte, error) {
+	var keys []secretv1alpha1.ScalewaySecretKeyMapping
+	if target != nil {
+		keys = target.Keys
+	}
+
+	structured := secretType == secret.SecretTypeKeyValue ||
+		secretType == secret.SecretTypeBasicCredentials ||
+		secretType == secret.SecretTypeDatabaseCredentials
+
+	if len(keys) == 0 && !structured {
+		return map[string][]byte{
+			DefaultValueKey: data,
+		}, nil
+	}
+
+	var properties map[string]interface{}
+	needProperties := len(keys) == 0
+	for _, key := range keys {
+		if key.Property != "" {
+			needProperties = true
+		}
+	}
+	if needProperties {
+		err := json.Unmarshal(data, &properties)
+		if err != nil {
+			return nil, fmt.Errorf("secret data is not a JSON object: %v", err)
+		}
+	}
+
+	targetData := map[string][]byte{}
+
+	if len(keys) == 0 {
+		for property, value := range properties {
+			targetData[property] = getPropertyValue(value)
+		}
+		return targetData, nil
+	}
+
+	for _, key := range keys {
+		if key.Property == "" {
+			targetData[key.Key] = data
+			continue
+		}
+		value, ok := properties[key.Property]
+		if !ok {
+			return nil, fmt.Errorf("property %s not found in secret data", key.Property)
+		}
+		targetData[key.Key] = getPropertyValue(value)
+	}
+
+	return targetData, nil
+}
+
+func getPropertyValue(value interface{}) []byte {
+	if s, ok := value.(string); ok {
+		return []byte(s)
+	}
+	raw, _ := json.Marshal(value)
+	return raw
+}
+
+// getSourceData returns the data to push from the source Kubernetes Secret
+// All the keys are pushed as a JSON object if no key is specified
+func getSourceData(sourceSecret *corev1.Secret, key string) ([]byte, error) {
+	if key != "" {
+		value, ok := sourceSecret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in secret %s", key, sourceSecret.Name)
+		}
+		return value, nil
+	}
+
+	properties := make(map[string]string, len(sourceSecret.Data))
+	for k, v := range sourceSecret.Data {
+		properties[k] = string(v)
+	}
+
+	// map keys are sorted by encoding/json, so the output is stable
+	return json.Marshal(properties)
+}
+
+// hashData returns the hash of the given data, salted with the UID of the ScalewaySecret
+func hashData(scwSecret *secretv1alpha1.ScalewaySecret, data map[string][]byte) string {
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		values[key] = string(value)
+	}
+	return utils.HashSecretValues(string(scwSecret.UID), values)
+}
+
+func isOwnedTargetSecret(scwSecret *secretv1alpha1.ScalewaySecret, targetSecret *corev1.Secret) bool {
+	return targetSecret.Labels[TargetSecretOwnerNameLabel] == scwSecret.Name
+}
+
+func getDirection(scwSecret *secretv1alpha1.ScalewaySecret) secretv1alpha1.ScalewaySecretDirection {
+	if scwSecret.Spec.Direction == "" {
+		return secretv1alpha1.SecretDirectionPull
+	}
+	return scwSecret.Spec.Direction
+}
+
+func getSecretName(scwSecret *secretv1alpha1.ScalewaySecret) string {
+	if scwSecret.Spec.SecretName != "" {
+		return scwSecret.Spec.SecretName
+	}
+	return scwSecret.Name
+}
+
+func getPath(scwSecret *secretv1alpha1.ScalewaySecret) string {
+	if scwSecret.Spec.Path != "" {
+		return scwSecret.Spec.Path
+	}
+	return defaultPath
+}
+
+func getTargetName(scwSecret *secretv1alpha1.ScalewaySecret) string {
+	if scwSecret.Spec.Target != nil && scwSecret.Spec.Target.Name != "" {
+		return scwSecret.Spec.Target.Name
+	}
+	return scwSecret.Name
+}
+
+func convertSecret(obj runtime.Object) (*secretv1alpha1.ScalewaySecret, error) {
+	scwSecret, ok := obj.(*secretv1alpha1.ScalewaySecret)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return scwSecret, nil
+}
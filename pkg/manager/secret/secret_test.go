@@ -0,0 +1,117 @@
+package secret
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/secret/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretv1alpha1 "github.com/scaleway/scaleway-operator/apis/secret/v1alpha1"
+)
+
+func Test_getTargetData(t *testing.T) {
+	jsonData := []byte(`{"username":"admin","password":"s3cr3t","port":5432}`)
+
+	cases := []struct {
+		data       []byte
+		secretType secret.SecretType
+		target     *secretv1alpha1.ScalewaySecretTarget
+		targetData map[string][]byte
+		err        bool
+	}{
+		{
+			data:       []byte("s3cr3t"),
+			secretType: secret.SecretTypeOpaque,
+			target:     nil,
+			targetData: map[string][]byte{"value": []byte("s3cr3t")},
+		},
+		{
+			data:       jsonData,
+			secretType: secret.SecretTypeKeyValue,
+			target:     nil,
+			targetData: map[string][]byte{
+				"username": []byte("admin"),
+				"password": []byte("s3cr3t"),
+				"port":     []byte("5432"),
+			},
+		},
+		{
+			data:       jsonData,
+			secretType: secret.SecretTypeDatabaseCredentials,
+			target: &secretv1alpha1.ScalewaySecretTarget{
+				Keys: []secretv1alpha1.ScalewaySecretKeyMapping{
+					{Key: "DB_PASSWORD", Property: "password"},
+					{Key: "raw"},
+				},
+			},
+			targetData: map[string][]byte{
+				"DB_PASSWORD": []byte("s3cr3t"),
+				"raw":         jsonData,
+			},
+		},
+		{
+			data:       []byte("s3cr3t"),
+			secretType: secret.SecretTypeOpaque,
+			target: &secretv1alpha1.ScalewaySecretTarget{
+				Keys: []secretv1alpha1.ScalewaySecretKeyMapping{
+					{Key: "password", Property: "password"},
+				},
+			},
+			err: true,
+		},
+		{
+			data:       jsonData,
+			secretType: secret.SecretTypeKeyValue,
+			target: &secretv1alpha1.ScalewaySecretTarget{
+				Keys: []secretv1alpha1.ScalewaySecretKeyMapping{
+					{Key: "token", Property: "token"},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for i, c := range cases {
+		targetData, err := getTargetData(c.data, c.secretType, c.target)
+		if (err != nil) != c.err {
+			t.Errorf("case %d: got error %v", i, err)
+			continue
+		}
+		if !c.err && !reflect.DeepEqual(targetData, c.targetData) {
+			t.Errorf("case %d: got %v instead of %v", i, targetData, c.targetData)
+		}
+	}
+}
+
+func Test_isOwnedTargetSecret(t *testing.T) {
+	scwSecret := &secretv1alpha1.ScalewaySecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-password", Namespace: "default"},
+	}
+
+	cases := []struct {
+		labels map[string]string
+		owned  bool
+	}{
+		{
+			labels: nil,
+			owned:  false,
+		},
+		{
+			labels: map[string]string{TargetSecretOwnerNameLabel: "other"},
+			owned:  false,
+		},
+		{
+			labels: map[string]string{TargetSecretOwnerNameLabel: "db-password"},
+			owned:  true,
+		},
+	}
+
+	for _, c := range cases {
+		targetSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: c.labels}}
+		if owned := isOwnedTargetSecret(scwSecret, targetSecret); owned != c.owned {
+			t.Errorf("Got owned %t instead of %t for labels %v", owned, c.owned, c.labels)
+		}
+	}
+}
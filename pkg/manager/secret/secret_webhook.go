@@ -0,0 +1,121 @@
+package secret
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/scaleway/scaleway-sdk-go/api/secret/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	secretv1alpha1 "github.com/scaleway/scaleway-operator/apis/secret/v1alpha1"
+)
+
+// ValidateCreate validates the creation of a Secret Manager secret
+func (m *SecretManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	scwSecret, err := convertSecret(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scw.ParseRegion(scwSecret.Spec.Region)
+	if scwSecret.Spec.Region != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("region"), scwSecret.Spec.Region, "region is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validateSecret(scwSecret)...)
+
+	if scwSecret.Spec.SecretID != "" {
+		_, err = m.API.GetSecret(&secret.GetSecretRequest{
+			Region:   scw.Region(scwSecret.Spec.Region),
+			SecretID: scwSecret.Spec.SecretID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("secretID"), scwSecret.Spec.SecretID, err.Error()))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Secret Manager secret
+func (m *SecretManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	scwSecret, err := convertSecret(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldSecret, err := convertSecret(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldSecret.Spec.SecretID != "" && oldSecret.Spec.SecretID != scwSecret.Spec.SecretID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("secretID"), "field is immutable"))
+	}
+
+	if oldSecret.Spec.Region != scwSecret.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("region"), "field is immutable"))
+	}
+
+	if getDirection(oldSecret) != getDirection(scwSecret) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("direction"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateSecret(scwSecret)...)
+
+	return allErrs, nil
+}
+
+func validateSecret(scwSecret *secretv1alpha1.ScalewaySecret) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if getDirection(scwSecret) == secretv1alpha1.SecretDirectionPush {
+		if scwSecret.Spec.Source == nil || scwSecret.Spec.Source.Name == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("source"), "source must be specified in Push mode"))
+		}
+		if scwSecret.Spec.Target != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("target"), "target is only used in Pull mode"))
+		}
+		if scwSecret.Spec.Revision != "" {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("revision"), "revision is only used in Pull mode"))
+		}
+		if scwSecret.Spec.RefreshInterval != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("refreshInterval"), "refresh interval is only used in Pull mode"))
+		}
+		return allErrs
+	}
+
+	if scwSecret.Spec.Source != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("source"), "source is only used in Push mode"))
+	}
+
+	revision := scwSecret.Spec.Revision
+	if revision != "" && revision != "latest" && revision != "latest_enabled" {
+		if _, err := strconv.ParseUint(revision, 10, 32); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("revision"), revision, "revision must be a number, latest or latest_enabled"))
+		}
+	}
+
+	if scwSecret.Spec.RefreshInterval != nil && scwSecret.Spec.RefreshInterval.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("refreshInterval"), scwSecret.Spec.RefreshInterval.Duration.String(), "refresh interval must be positive"))
+	}
+
+	if scwSecret.Spec.Target != nil {
+		keys := make(map[string]bool)
+		for i, key := range scwSecret.Spec.Target.Keys {
+			if keys[key.Key] {
+				allErrs = append(allErrs, field.Duplicate(field.NewPath("spec").Child("target").Child("keys").Index(i).Child("key"), key.Key))
+			}
+			keys[key.Key] = true
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,363 @@
+package serverless
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/container/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	serverlessv1alpha1 "github.com/scaleway/scaleway-operator/apis/serverless/v1alpha1"
+)
+
+// ContainerManager manages the serverless containers
+type ContainerManager struct {
+	client.Client
+	API *container.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the serverless container resource
+func (m *ContainerManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	serverlessContainer, err := convertContainer(obj)
+	if err != nil {
+		return false, err
+	}
+
+	secretValues, err := getSecretValues(ctx, m.Client, serverlessContainer.Namespace, serverlessContainer.Spec.SecretEnvironmentVariables)
+	if err != nil {
+		return false, err
+	}
+
+	// if containerID is empty, we need to create the container
+	if serverlessContainer.Spec.ContainerID == "" {
+		return false, m.createContainer(ctx, serverlessContainer, secretValues)
+	}
+
+	containerResp, err := m.API.GetContainer(&container.GetContainerRequest{
+		Region:      scw.Region(serverlessContainer.Spec.Region),
+		ContainerID: serverlessContainer.Spec.ContainerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	if containerResp.DomainName != "" {
+		serverlessContainer.Status.Endpoint = "https://" + containerResp.DomainName
+	}
+
+	switch containerResp.Status {
+	case container.ContainerStatusError:
+		// the spec is applied first, as fixing it may be the way out of the error
+		needReturn, err := m.updateContainer(ctx, serverlessContainer, containerResp, secretValues)
+		if err != nil {
+			return false, err
+		}
+		if needReturn {
+			return false, nil
+		}
+		return false, fmt.Errorf("container is in error: %s", getDescription(containerResp.ErrorMessage))
+	case container.ContainerStatusCreated:
+		// the container has never been deployed
+		_, err = m.API.DeployContainer(&container.DeployContainerRequest{
+			Region:      scw.Region(serverlessContainer.Spec.Region),
+			ContainerID: serverlessContainer.Spec.ContainerID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		return false, nil
+	case container.ContainerStatusReady:
+	default:
+		return false, nil
+	}
+
+	needReturn, err := m.updateContainer(ctx, serverlessContainer, containerResp, secretValues)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	return m.ensureDomains(ctx, serverlessContainer)
+}
+
+// Delete deletes the serverless container resource
+func (m *ContainerManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	serverlessContainer, err := convertContainer(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := serverlessContainer.Spec.ContainerID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	_, err = m.API.DeleteContainer(&container.DeleteContainerRequest{
+		Region:      scw.Region(serverlessContainer.Spec.Region),
+		ContainerID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return false, nil
+}
+
+// GetOwners returns the owners of the serverless container resource
+func (m *ContainerManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	serverlessContainer, err := convertContainer(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return getNamespaceOwners(serverlessContainer.Namespace, serverlessContainer.Spec.NamespaceRef), nil
+}
+
+func (m *ContainerManager) createContainer(ctx context.Context, serverlessContainer *serverlessv1alpha1.ServerlessContainer, secretValues map[string]string) error {
+	namespaceID, region, err := getNamespaceRef(ctx, m.Client, serverlessContainer.Namespace, serverlessContainer.Spec.NamespaceRef, serverlessv1alpha1.NamespaceTypeContainer)
+	if err != nil {
+		return err
+	}
+	// the referenced namespace is not created yet
+	if namespaceID == "" {
+		return nil
+	}
+
+	createRequest := &container.CreateContainerRequest{
+		Region:                     scw.Region(region),
+		NamespaceID:                namespaceID,
+		Name:                       serverlessContainer.Name,
+		EnvironmentVariables:       &serverlessContainer.Spec.EnvironmentVariables,
+		MinScale:                   toUint32Ptr(serverlessContainer.Spec.MinScale),
+		MaxScale:                   toUint32Ptr(serverlessContainer.Spec.MaxScale),
+		MemoryLimit:                toUint32Ptr(serverlessContainer.Spec.MemoryLimit),
+		CPULimit:                   toUint32Ptr(serverlessContainer.Spec.CPULimit),
+		Port:                       toUint32Ptr(serverlessContainer.Spec.Port),
+		Timeout:                    toDuration(serverlessContainer.Spec.Timeout),
+		Privacy:                    container.ContainerPrivacy(getPrivacy(serverlessContainer.Spec.Privacy)),
+		RegistryImage:              scw.StringPtr(serverlessContainer.Spec.RegistryImage),
+		SecretEnvironmentVariables: getContainerSecrets(secretValues, nil),
+	}
+
+	if serverlessContainer.Spec.Description != "" {
+		createRequest.Description = scw.StringPtr(serverlessContainer.Spec.Description)
+	}
+
+	containerResp, err := m.API.CreateContainer(createRequest, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	serverlessContainer.Spec.ContainerID = containerResp.ID
+	serverlessContainer.Spec.Region = containerResp.Region.String()
+	err = m.Client.Update(ctx, serverlessContainer)
+	if err != nil {
+		return err
+	}
+
+	serverlessContainer.Status.SecretsHash = utils.HashSecretValues(string(serverlessContainer.UID), secretValues)
+
+	return nil
+}
+
+func (m *ContainerManager) updateContainer(ctx context.Context, serverlessContainer *serverlessv1alpha1.ServerlessContainer, containerResp *container.Container, secretValues map[string]string) (bool, error) {
+	spec := serverlessContainer.Spec
+	needsUpdate := false
+	updateRequest := &container.UpdateContainerRequest{
+		Region:      scw.Region(spec.Region),
+		ContainerID: spec.ContainerID,
+		Redeploy:    scw.BoolPtr(true),
+		Privacy:     container.ContainerPrivacy(getPrivacy(spec.Privacy)),
+		Protocol:    containerResp.Protocol,
+		HTTPOption:  containerResp.HTTPOption,
+		Sandbox:     containerResp.Sandbox,
+	}
+
+	if containerResp.RegistryImage != spec.RegistryImage {
+		updateRequest.RegistryImage = scw.StringPtr(spec.RegistryImage)
+		needsUpdate = true
+	}
+
+	if containerResp.Privacy != updateRequest.Privacy {
+		needsUpdate = true
+	}
+
+	if checkUint32Update(containerResp.MinScale, spec.MinScale) {
+		updateRequest.MinScale = toUint32Ptr(spec.MinScale)
+		needsUpdate = true
+	}
+
+	if checkUint32Update(containerResp.MaxScale, spec.MaxScale) {
+		updateRequest.MaxScale = toUint32Ptr(spec.MaxScale)
+		needsUpdate = true
+	}
+
+	if checkUint32Update(containerResp.MemoryLimit, spec.MemoryLimit) {
+		updateRequest.MemoryLimit = toUint32Ptr(spec.MemoryLimit)
+		needsUpdate = true
+	}
+
+	if checkUint32Update(containerResp.CPULimit, spec.CPULimit) {
+		updateRequest.CPULimit = toUint32Ptr(spec.CPULimit)
+		needsUpdate = true
+	}
+
+	if checkUint32Update(containerResp.Port, spec.Port) {
+		updateRequest.Port = toUint32Ptr(spec.Port)
+		needsUpdate = true
+	}
+
+	if checkDurationUpdate(containerResp.Timeout, spec.Timeout) {
+		updateRequest.Timeout = toDuration(spec.Timeout)
+		needsUpdate = true
+	}
+
+	if getDescription(containerResp.Description) != spec.Description {
+		updateRequest.Description = scw.StringPtr(spec.Description)
+		needsUpdate = true
+	}
+
+	if !compareEnvironmentVariables(containerResp.EnvironmentVariables, spec.EnvironmentVariables) {
+		updateRequest.EnvironmentVariables = &serverlessContainer.Spec.EnvironmentVariables
+		needsUpdate = true
+	}
+
+	existingKeys := getContainerSecretKeys(containerResp.SecretEnvironmentVariables)
+	if checkSecretsUpdate(existingKeys, secretValues, string(serverlessContainer.UID), serverlessContainer.Status.SecretsHash) {
+		updateRequest.SecretEnvironmentVariables = getContainerSecrets(secretValues, getRemovedSecretKeys(existingKeys, secretValues))
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		_, err := m.API.UpdateContainer(updateRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		serverlessContainer.Status.SecretsHash = utils.HashSecretValues(string(serverlessContainer.UID), secretValues)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// ensureDomains creates and deletes the custom domains of the container,
+// and returns whether all of them are ready
+func (m *ContainerManager) ensureDomains(ctx context.Context, serverlessContainer *serverlessv1alpha1.ServerlessContainer) (bool, error) {
+	region := scw.Region(serverlessContainer.Spec.Region)
+
+	domainsResp, err := m.API.ListDomains(&container.ListDomainsRequest{
+		Region:      region,
+		ContainerID: serverlessContainer.Spec.ContainerID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	wantedHostnames := make(map[string]bool)
+	for _, hostname := range serverlessContainer.Spec.Domains {
+		wantedHostnames[hostname] = true
+	}
+
+	ready := true
+	existingHostnames := make(map[string]bool)
+	urls := []string{}
+	for _, domain := range domainsResp.Domains {
+		if !wantedHostnames[domain.Hostname] {
+			if domain.Status == container.DomainStatusDeleting {
+				continue
+			}
+			_, err := m.API.DeleteDomain(&container.DeleteDomainRequest{
+				Region:   region,
+				DomainID: domain.ID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+					return false, err
+				}
+			}
+			continue
+		}
+
+		existingHostnames[domain.Hostname] = true
+		switch domain.Status {
+		case container.DomainStatusReady:
+			urls = append(urls, domain.URL)
+		case container.DomainStatusError:
+			return false, fmt.Errorf("domain %s is in error: %s", domain.Hostname, getDescription(domain.ErrorMessage))
+		default:
+			ready = false
+		}
+	}
+
+	for _, hostname := range serverlessContainer.Spec.Domains {
+		if existingHostnames[hostname] {
+			continue
+		}
+		_, err := m.API.CreateDomain(&container.CreateDomainRequest{
+			Region:      region,
+			Hostname:    hostname,
+			ContainerID: serverlessContainer.Spec.ContainerID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		ready = false
+	}
+
+	sort.Strings(urls)
+	serverlessContainer.Status.Domains = urls
+
+	return ready, nil
+}
+
+func getContainerSecretKeys(secrets []*container.SecretHashedValue) []string {
+	keys := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		keys = append(keys, secret.Key)
+	}
+	return keys
+}
+
+// getContainerSecrets returns the secrets to push, removed keys are sent without value
+func getContainerSecrets(values map[string]string, removedKeys []string) []*container.Secret {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	secrets := make([]*container.Secret, 0, len(keys)+len(removedKeys))
+	for _, key := range keys {
+		secrets = append(secrets, &container.Secret{
+			Key:   key,
+			Value: scw.StringPtr(values[key]),
+		})
+	}
+	for _, key := range removedKeys {
+		secrets = append(secrets, &container.Secret{
+			Key: key,
+		})
+	}
+	return secrets
+}
+
+func convertContainer(obj runtime.Object) (*serverlessv1alpha1.ServerlessContainer, error) {
+	serverlessContainer, ok := obj.(*serverlessv1alpha1.ServerlessContainer)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return serverlessContainer, nil
+}
@@ -0,0 +1,82 @@
+package serverless
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-sdk-go/api/container/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateCreate validates the creation of a Serverless Container
+func (m *ContainerManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	serverlessContainer, err := convertContainer(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	allErrs = append(allErrs, validateNamespaceRef(serverlessContainer.Spec.NamespaceRef)...)
+	if len(allErrs) > 0 {
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validateScale(serverlessContainer.Spec.MinScale, serverlessContainer.Spec.MaxScale)...)
+	allErrs = append(allErrs, validateEnvironment(serverlessContainer.Spec.EnvironmentVariables, serverlessContainer.Spec.SecretEnvironmentVariables)...)
+	allErrs = append(allErrs, validateDomains(serverlessContainer.Spec.Domains)...)
+
+	namespaceRef := serverlessContainer.Spec.NamespaceRef
+	if namespaceRef.ExternalID != "" {
+		_, err = m.API.GetNamespace(&container.GetNamespaceRequest{
+			Region:      scw.Region(namespaceRef.Region),
+			NamespaceID: namespaceRef.ExternalID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("namespaceRef").Child("externalID"), namespaceRef.ExternalID, err.Error()))
+		}
+	}
+
+	if serverlessContainer.Spec.ContainerID != "" {
+		_, err = m.API.GetContainer(&container.GetContainerRequest{
+			Region:      scw.Region(serverlessContainer.Spec.Region),
+			ContainerID: serverlessContainer.Spec.ContainerID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("containerID"), serverlessContainer.Spec.ContainerID, err.Error()))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Serverless Container
+func (m *ContainerManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	serverlessContainer, err := convertContainer(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldServerlessContainer, err := convertContainer(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldServerlessContainer.Spec.ContainerID != "" && oldServerlessContainer.Spec.ContainerID != serverlessContainer.Spec.ContainerID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("containerID"), "field is immutable"))
+	}
+
+	if oldServerlessContainer.Spec.Region != "" && oldServerlessContainer.Spec.Region != serverlessContainer.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("region"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateNamespaceRefUpdate(oldServerlessContainer.Spec.NamespaceRef, serverlessContainer.Spec.NamespaceRef)...)
+	allErrs = append(allErrs, validateScale(serverlessContainer.Spec.MinScale, serverlessContainer.Spec.MaxScale)...)
+	allErrs = append(allErrs, validateEnvironment(serverlessContainer.Spec.EnvironmentVariables, serverlessContainer.Spec.SecretEnvironmentVariables)...)
+	allErrs = append(allErrs, validateDomains(serverlessContainer.Spec.Domains)...)
+
+	return allErrs, nil
+}
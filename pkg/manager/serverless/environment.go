@@ -0,0 +1,75 @@
+package serverless
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	serverlessv1alpha1 "github.com/scaleway/scaleway-operator/apis/serverless/v1alpha1"
+)
+
+// getSecretValues resolves the secret environment variables from the
+// kubernetes secrets of the given namespace
+func getSecretValues(ctx context.Context, c client.Client, namespace string, secretEnvVars []serverlessv1alpha1.ServerlessSecretEnvVar) (map[string]string, error) {
+	values := make(map[string]string, len(secretEnvVars))
+	for _, secretEnvVar := range secretEnvVars {
+		secret := corev1.Secret{}
+		err := c.Get(ctx, types.NamespacedName{
+			Name:      secretEnvVar.SecretKeyRef.Name,
+			Namespace: namespace,
+		}, &secret)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := secret.Data[secretEnvVar.SecretKeyRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in secret %s/%s", secretEnvVar.SecretKeyRef.Key, namespace, secretEnvVar.SecretKeyRef.Name)
+		}
+		values[secretEnvVar.Name] = string(value)
+	}
+	return values, nil
+}
+
+// getRemovedSecretKeys returns the existing secret keys which are not wanted anymore
+func getRemovedSecretKeys(existingKeys []string, wanted map[string]string) []string {
+	var removedKeys []string
+	for _, key := range existingKeys {
+		if _, ok := wanted[key]; !ok {
+			removedKeys = append(removedKeys, key)
+		}
+	}
+	sort.Strings(removedKeys)
+	return removedKeys
+}
+
+// checkSecretsUpdate returns whether the secret environment variables need to be pushed
+// The API only returns salted hashes of the values, so they are compared against the
+// salted hash of the last pushed values
+func checkSecretsUpdate(existingKeys []string, wanted map[string]string, salt string, lastHash string) bool {
+	if len(existingKeys) != len(wanted) {
+		return true
+	}
+	for _, key := range existingKeys {
+		if _, ok := wanted[key]; !ok {
+			return true
+		}
+	}
+	return utils.HashSecretValues(salt, wanted) != lastHash
+}
+
+func compareEnvironmentVariables(existing map[string]string, wanted map[string]string) bool {
+	if len(existing) != len(wanted) {
+		return false
+	}
+	for key, value := range wanted {
+		if existingValue, ok := existing[key]; !ok || existingValue != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package serverless
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+)
+
+func Test_checkSecretsUpdate(t *testing.T) {
+	salt := "9a1b6f9e-5c43-4f6e-9d2a-3b7c8e1f0a42"
+	wanted := map[string]string{
+		"DATABASE_PASSWORD": "s3cr3t",
+		"API_TOKEN":         "t0k3n",
+	}
+
+	cases := []struct {
+		existingKeys []string
+		wanted       map[string]string
+		lastHash     string
+		update       bool
+	}{
+		{
+			existingKeys: nil,
+			wanted:       nil,
+			lastHash:     utils.HashSecretValues(salt, nil),
+			update:       false,
+		},
+		{
+			existingKeys: []string{"API_TOKEN", "DATABASE_PASSWORD"},
+			wanted:       wanted,
+			lastHash:     utils.HashSecretValues(salt, wanted),
+			update:       false,
+		},
+		{
+			existingKeys: nil,
+			wanted:       wanted,
+			lastHash:     "",
+			update:       true,
+		},
+		{
+			existingKeys: []string{"API_TOKEN", "DATABASE_PASSWORD"},
+			wanted:       wanted,
+			lastHash:     utils.HashSecretValues(salt, map[string]string{"DATABASE_PASSWORD": "old", "API_TOKEN": "t0k3n"}),
+			update:       true,
+		},
+		{
+			existingKeys: []string{"API_TOKEN", "OLD_PASSWORD"},
+			wanted:       wanted,
+			lastHash:     utils.HashSecretValues(salt, wanted),
+			update:       true,
+		},
+		{
+			existingKeys: []string{"API_TOKEN", "DATABASE_PASSWORD"},
+			wanted:       wanted,
+			lastHash:     utils.HashValues(wanted),
+			update:       true,
+		},
+		{
+			existingKeys: []string{"API_TOKEN", "DATABASE_PASSWORD"},
+			wanted:       nil,
+			lastHash:     utils.HashSecretValues(salt, wanted),
+			update:       true,
+		},
+	}
+
+	for i, c := range cases {
+		if update := checkSecretsUpdate(c.existingKeys, c.wanted, salt, c.lastHash); update != c.update {
+			t.Errorf("case %d: got %t instead of %t", i, update, c.update)
+		}
+	}
+}
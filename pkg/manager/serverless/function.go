@@ -0,0 +1,456 @@
+package serverless
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	serverlessv1alpha1 "github.com/scaleway/scaleway-operator/apis/serverless/v1alpha1"
+)
+
+// FunctionManager manages the serverless functions
+type FunctionManager struct {
+	client.Client
+	API *function.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the serverless function resource
+func (m *FunctionManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	serverlessFunction, err := convertFunction(obj)
+	if err != nil {
+		return false, err
+	}
+
+	secretValues, err := getSecretValues(ctx, m.Client, serverlessFunction.Namespace, serverlessFunction.Spec.SecretEnvironmentVariables)
+	if err != nil {
+		return false, err
+	}
+
+	// if functionID is empty, we need to create the function
+	if serverlessFunction.Spec.FunctionID == "" {
+		return false, m.createFunction(ctx, serverlessFunction, secretValues)
+	}
+
+	functionResp, err := m.API.GetFunction(&function.GetFunctionRequest{
+		Region:     scw.Region(serverlessFunction.Spec.Region),
+		FunctionID: serverlessFunction.Spec.FunctionID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	if functionResp.DomainName != "" {
+		serverlessFunction.Status.Endpoint = "https://" + functionResp.DomainName
+	}
+
+	switch functionResp.Status {
+	case function.FunctionStatusError:
+		// the code and the spec are applied first, as fixing them may be the way out of the error
+		deployed, err := m.deployCode(ctx, serverlessFunction)
+		if err != nil {
+			return false, err
+		}
+		if deployed {
+			return false, nil
+		}
+		needReturn, err := m.updateFunction(ctx, serverlessFunction, functionResp, secretValues)
+		if err != nil {
+			return false, err
+		}
+		if needReturn {
+			return false, nil
+		}
+		return false, fmt.Errorf("function is in error: %s", getDescription(functionResp.ErrorMessage))
+	case function.FunctionStatusReady, function.FunctionStatusCreated:
+	default:
+		return false, nil
+	}
+
+	deployed, err := m.deployCode(ctx, serverlessFunction)
+	if err != nil {
+		return false, err
+	}
+	if deployed {
+		return false, nil
+	}
+
+	// the function has never been deployed, its code is not available yet
+	if functionResp.Status == function.FunctionStatusCreated {
+		return false, nil
+	}
+
+	needReturn, err := m.updateFunction(ctx, serverlessFunction, functionResp, secretValues)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	return m.ensureDomains(ctx, serverlessFunction)
+}
+
+// Delete deletes the serverless function resource
+func (m *FunctionManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	serverlessFunction, err := convertFunction(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := serverlessFunction.Spec.FunctionID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	_, err = m.API.DeleteFunction(&function.DeleteFunctionRequest{
+		Region:     scw.Region(serverlessFunction.Spec.Region),
+		FunctionID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return false, nil
+}
+
+// GetOwners returns the owners of the serverless function resource
+func (m *FunctionManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	serverlessFunction, err := convertFunction(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return getNamespaceOwners(serverlessFunction.Namespace, serverlessFunction.Spec.NamespaceRef), nil
+}
+
+func (m *FunctionManager) createFunction(ctx context.Context, serverlessFunction *serverlessv1alpha1.ServerlessFunction, secretValues map[string]string) error {
+	namespaceID, region, err := getNamespaceRef(ctx, m.Client, serverlessFunction.Namespace, serverlessFunction.Spec.NamespaceRef, serverlessv1alpha1.NamespaceTypeFunction)
+	if err != nil {
+		return err
+	}
+	// the referenced namespace is not created yet
+	if namespaceID == "" {
+		return nil
+	}
+
+	createRequest := &function.CreateFunctionRequest{
+		Region:                     scw.Region(region),
+		NamespaceID:                namespaceID,
+		Name:                       serverlessFunction.Name,
+		EnvironmentVariables:       &serverlessFunction.Spec.EnvironmentVariables,
+		MinScale:                   toUint32Ptr(serverlessFunction.Spec.MinScale),
+		MaxScale:                   toUint32Ptr(serverlessFunction.Spec.MaxScale),
+		MemoryLimit:                toUint32Ptr(serverlessFunction.Spec.MemoryLimit),
+		Runtime:                    function.FunctionRuntime(serverlessFunction.Spec.Runtime),
+		Timeout:                    toDuration(serverlessFunction.Spec.Timeout),
+		Privacy:                    function.FunctionPrivacy(getPrivacy(serverlessFunction.Spec.Privacy)),
+		SecretEnvironmentVariables: getFunctionSecrets(secretValues, nil),
+	}
+
+	if serverlessFunction.Spec.Handler != "" {
+		createRequest.Handler = scw.StringPtr(serverlessFunction.Spec.Handler)
+	}
+
+	if serverlessFunction.Spec.Description != "" {
+		createRequest.Description = scw.StringPtr(serverlessFunction.Spec.Description)
+	}
+
+	functionResp, err := m.API.CreateFunction(createRequest, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	serverlessFunction.Spec.FunctionID = functionResp.ID
+	serverlessFunction.Spec.Region = functionResp.Region.String()
+	err = m.Client.Update(ctx, serverlessFunction)
+	if err != nil {
+		return err
+	}
+
+	serverlessFunction.Status.SecretsHash = utils.HashSecretValues(string(serverlessFunction.UID), secretValues)
+
+	return nil
+}
+
+func (m *FunctionManager) updateFunction(ctx context.Context, serverlessFunction *serverlessv1alpha1.ServerlessFunction, functionResp *function.Function, secretValues map[string]string) (bool, error) {
+	spec := serverlessFunction.Spec
+	needsUpdate := false
+	updateRequest := &function.UpdateFunctionRequest{
+		Region:     scw.Region(spec.Region),
+		FunctionID: spec.FunctionID,
+		Redeploy:   scw.BoolPtr(true),
+		Runtime:    function.FunctionRuntime(spec.Runtime),
+		Privacy:    function.FunctionPrivacy(getPrivacy(spec.Privacy)),
+		HTTPOption: functionResp.HTTPOption,
+		Sandbox:    functionResp.Sandbox,
+	}
+
+	if functionResp.Runtime != updateRequest.Runtime {
+		needsUpdate = true
+	}
+
+	if functionResp.Privacy != updateRequest.Privacy {
+		needsUpdate = true
+	}
+
+	if spec.Handler != "" && functionResp.Handler != spec.Handler {
+		updateRequest.Handler = scw.StringPtr(spec.Handler)
+		needsUpdate = true
+	}
+
+	if checkUint32Update(functionResp.MinScale, spec.MinScale) {
+		updateRequest.MinScale = toUint32Ptr(spec.MinScale)
+		needsUpdate = true
+	}
+
+	if checkUint32Update(functionResp.MaxScale, spec.MaxScale) {
+		updateRequest.MaxScale = toUint32Ptr(spec.MaxScale)
+		needsUpdate = true
+	}
+
+	if checkUint32Update(functionResp.MemoryLimit, spec.MemoryLimit) {
+		updateRequest.MemoryLimit = toUint32Ptr(spec.MemoryLimit)
+		needsUpdate = true
+	}
+
+	if checkDurationUpdate(functionResp.Timeout, spec.Timeout) {
+		updateRequest.Timeout = toDuration(spec.Timeout)
+		needsUpdate = true
+	}
+
+	if getDescription(functionResp.Description) != spec.Description {
+		updateRequest.Description = scw.StringPtr(spec.Description)
+		needsUpdate = true
+	}
+
+	if !compareEnvironmentVariables(functionResp.EnvironmentVariables, spec.EnvironmentVariables) {
+		updateRequest.EnvironmentVariables = &serverlessFunction.Spec.EnvironmentVariables
+		needsUpdate = true
+	}
+
+	existingKeys := getFunctionSecretKeys(functionResp.SecretEnvironmentVariables)
+	if checkSecretsUpdate(existingKeys, secretValues, string(serverlessFunction.UID), serverlessFunction.Status.SecretsHash) {
+		updateRequest.SecretEnvironmentVariables = getFunctionSecrets(secretValues, getRemovedSecretKeys(existingKeys, secretValues))
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		_, err := m.API.UpdateFunction(updateRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		serverlessFunction.Status.SecretsHash = utils.HashSecretValues(string(serverlessFunction.UID), secretValues)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// deployCode uploads and deploys the code archive of the function when it changed
+func (m *FunctionManager) deployCode(ctx context.Context, serverlessFunction *serverlessv1alpha1.ServerlessFunction) (bool, error) {
+	if serverlessFunction.Spec.Code == nil {
+		return false, nil
+	}
+
+	code, err := m.getCode(ctx, serverlessFunction)
+	if err != nil {
+		return false, err
+	}
+
+	codeHash := fmt.Sprintf("%x", sha256.Sum256(code))
+	if codeHash == serverlessFunction.Status.CodeHash {
+		return false, nil
+	}
+
+	uploadURL, err := m.API.GetFunctionUploadURL(&function.GetFunctionUploadURLRequest{
+		Region:        scw.Region(serverlessFunction.Spec.Region),
+		FunctionID:    serverlessFunction.Spec.FunctionID,
+		ContentLength: uint64(len(code)),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL.URL, bytes.NewReader(code))
+	if err != nil {
+		return false, err
+	}
+	for key, values := range uploadURL.Headers {
+		if values == nil {
+			continue
+		}
+		for _, value := range *values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return false, fmt.Errorf("failed to upload function code: %s", resp.Status)
+	}
+
+	_, err = m.API.DeployFunction(&function.DeployFunctionRequest{
+		Region:     scw.Region(serverlessFunction.Spec.Region),
+		FunctionID: serverlessFunction.Spec.FunctionID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	serverlessFunction.Status.CodeHash = codeHash
+
+	return true, nil
+}
+
+func (m *FunctionManager) getCode(ctx context.Context, serverlessFunction *serverlessv1alpha1.ServerlessFunction) ([]byte, error) {
+	configMapKeyRef := serverlessFunction.Spec.Code.ConfigMapKeyRef
+
+	configMap := corev1.ConfigMap{}
+	err := m.Get(ctx, types.NamespacedName{
+		Name:      configMapKeyRef.Name,
+		Namespace: serverlessFunction.Namespace,
+	}, &configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if code, ok := configMap.BinaryData[configMapKeyRef.Key]; ok {
+		return code, nil
+	}
+	if code, ok := configMap.Data[configMapKeyRef.Key]; ok {
+		return []byte(code), nil
+	}
+
+	return nil, fmt.Errorf("key %s not found in config map %s/%s", configMapKeyRef.Key, serverlessFunction.Namespace, configMapKeyRef.Name)
+}
+
+// ensureDomains creates and deletes the custom domains of the function,
+// and returns whether all of them are ready
+func (m *FunctionManager) ensureDomains(ctx context.Context, serverlessFunction *serverlessv1alpha1.ServerlessFunction) (bool, error) {
+	region := scw.Region(serverlessFunction.Spec.Region)
+
+	domainsResp, err := m.API.ListDomains(&function.ListDomainsRequest{
+		Region:     region,
+		FunctionID: serverlessFunction.Spec.FunctionID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	wantedHostnames := make(map[string]bool)
+	for _, hostname := range serverlessFunction.Spec.Domains {
+		wantedHostnames[hostname] = true
+	}
+
+	ready := true
+	existingHostnames := make(map[string]bool)
+	urls := []string{}
+	for _, domain := range domainsResp.Domains {
+		if !wantedHostnames[domain.Hostname] {
+			if domain.Status == function.DomainStatusDeleting {
+				continue
+			}
+			_, err := m.API.DeleteDomain(&function.DeleteDomainRequest{
+				Region:   region,
+				DomainID: domain.ID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				if _, ok := err.(*scw.ResourceNotFoundError); !ok {
+					return false, err
+				}
+			}
+			continue
+		}
+
+		existingHostnames[domain.Hostname] = true
+		switch domain.Status {
+		case function.DomainStatusReady:
+			urls = append(urls, domain.URL)
+		case function.DomainStatusError:
+			return false, fmt.Errorf("domain %s is in error: %s", domain.Hostname, getDescription(domain.ErrorMessage))
+		default:
+			ready = false
+		}
+	}
+
+	for _, hostname := range serverlessFunction.Spec.Domains {
+		if existingHostnames[hostname] {
+			continue
+		}
+		_, err := m.API.CreateDomain(&function.CreateDomainRequest{
+			Region:     region,
+			Hostname:   hostname,
+			FunctionID: serverlessFunction.Spec.FunctionID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		ready = false
+	}
+
+	sort.Strings(urls)
+	serverlessFunction.Status.Domains = urls
+
+	return ready, nil
+}
+
+func getFunctionSecretKeys(secrets []*function.SecretHashedValue) []string {
+	keys := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		keys = append(keys, secret.Key)
+	}
+	return keys
+}
+
+// getFunctionSecrets returns the secrets to push, removed keys are sent without value
+func getFunctionSecrets(values map[string]string, removedKeys []string) []*function.Secret {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	secrets := make([]*function.Secret, 0, len(keys)+len(removedKeys))
+	for _, key := range keys {
+		secrets = append(secrets, &function.Secret{
+			Key:   key,
+			Value: scw.StringPtr(values[key]),
+		})
+	}
+	for _, key := range removedKeys {
+		secrets = append(secrets, &function.Secret{
+			Key: key,
+		})
+	}
+	return secrets
+}
+
+func convertFunction(obj runtime.Object) (*serverlessv1alpha1.ServerlessFunction, error) {
+	serverlessFunction, ok := obj.(*serverlessv1alpha1.ServerlessFunction)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return serverlessFunction, nil
+}
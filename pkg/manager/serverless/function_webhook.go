@@ -0,0 +1,123 @@
+package serverless
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateCreate validates the creation of a Serverless Function
+func (m *FunctionManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	serverlessFunction, err := convertFunction(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	allErrs = append(allErrs, validateNamespaceRef(serverlessFunction.Spec.NamespaceRef)...)
+	if len(allErrs) > 0 {
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validateScale(serverlessFunction.Spec.MinScale, serverlessFunction.Spec.MaxScale)...)
+	allErrs = append(allErrs, validateEnvironment(serverlessFunction.Spec.EnvironmentVariables, serverlessFunction.Spec.SecretEnvironmentVariables)...)
+	allErrs = append(allErrs, validateDomains(serverlessFunction.Spec.Domains)...)
+
+	namespaceRef := serverlessFunction.Spec.NamespaceRef
+	if namespaceRef.ExternalID != "" {
+		_, err = m.API.GetNamespace(&function.GetNamespaceRequest{
+			Region:      scw.Region(namespaceRef.Region),
+			NamespaceID: namespaceRef.ExternalID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("namespaceRef").Child("externalID"), namespaceRef.ExternalID, err.Error()))
+		}
+	}
+
+	if serverlessFunction.Spec.FunctionID != "" {
+		_, err = m.API.GetFunction(&function.GetFunctionRequest{
+			Region:     scw.Region(serverlessFunction.Spec.Region),
+			FunctionID: serverlessFunction.Spec.FunctionID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("functionID"), serverlessFunction.Spec.FunctionID, err.Error()))
+		}
+	}
+
+	runtimeErrs, err := m.checkRuntime(ctx, scw.Region(namespaceRef.Region), serverlessFunction.Spec.Runtime)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, runtimeErrs...)
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Serverless Function
+func (m *FunctionManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	serverlessFunction, err := convertFunction(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldServerlessFunction, err := convertFunction(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldServerlessFunction.Spec.FunctionID != "" && oldServerlessFunction.Spec.FunctionID != serverlessFunction.Spec.FunctionID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("functionID"), "field is immutable"))
+	}
+
+	if oldServerlessFunction.Spec.Region != "" && oldServerlessFunction.Spec.Region != serverlessFunction.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("region"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateNamespaceRefUpdate(oldServerlessFunction.Spec.NamespaceRef, serverlessFunction.Spec.NamespaceRef)...)
+	allErrs = append(allErrs, validateScale(serverlessFunction.Spec.MinScale, serverlessFunction.Spec.MaxScale)...)
+	allErrs = append(allErrs, validateEnvironment(serverlessFunction.Spec.EnvironmentVariables, serverlessFunction.Spec.SecretEnvironmentVariables)...)
+	allErrs = append(allErrs, validateDomains(serverlessFunction.Spec.Domains)...)
+
+	if oldServerlessFunction.Spec.Runtime != serverlessFunction.Spec.Runtime {
+		runtimeErrs, err := m.checkRuntime(ctx, scw.Region(serverlessFunction.Spec.Region), serverlessFunction.Spec.Runtime)
+		if err != nil {
+			return nil, err
+		}
+		allErrs = append(allErrs, runtimeErrs...)
+	}
+
+	return allErrs, nil
+}
+
+func (m *FunctionManager) checkRuntime(ctx context.Context, region scw.Region, functionRuntime string) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	runtimesResp, err := m.API.ListFunctionRuntimes(&function.ListFunctionRuntimesRequest{
+		Region: region,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeFound := false
+	for _, r := range runtimesResp.Runtimes {
+		if r.Name == functionRuntime {
+			if r.Status == function.RuntimeStatusEndOfLife {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("runtime"), functionRuntime, "runtime is end of life"))
+			}
+			runtimeFound = true
+			break
+		}
+	}
+	if !runtimeFound {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("runtime"), functionRuntime, "runtime does not exist"))
+	}
+
+	return allErrs, nil
+}
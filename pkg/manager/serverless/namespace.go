@@ -0,0 +1,339 @@
+package serverless
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/container/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	serverlessv1alpha1 "github.com/scaleway/scaleway-operator/apis/serverless/v1alpha1"
+)
+
+// NamespaceManager manages the serverless namespaces
+// Container and Function namespaces are backed by different APIs
+type NamespaceManager struct {
+	client.Client
+	ContainerAPI *container.API
+	FunctionAPI  *function.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the serverless namespace resource
+func (m *NamespaceManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	namespace, err := convertNamespace(obj)
+	if err != nil {
+		return false, err
+	}
+
+	secretValues, err := getSecretValues(ctx, m.Client, namespace.Namespace, namespace.Spec.SecretEnvironmentVariables)
+	if err != nil {
+		return false, err
+	}
+
+	if namespace.Spec.Type == serverlessv1alpha1.NamespaceTypeFunction {
+		return m.ensureFunctionNamespace(ctx, namespace, secretValues)
+	}
+	return m.ensureContainerNamespace(ctx, namespace, secretValues)
+}
+
+// Delete deletes the serverless namespace resource
+func (m *NamespaceManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	namespace, err := convertNamespace(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := namespace.Spec.NamespaceID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	if namespace.Spec.Type == serverlessv1alpha1.NamespaceTypeFunction {
+		_, err = m.FunctionAPI.DeleteNamespace(&function.DeleteNamespaceRequest{
+			Region:      scw.Region(namespace.Spec.Region),
+			NamespaceID: resourceID,
+		}, scw.WithContext(ctx))
+	} else {
+		_, err = m.ContainerAPI.DeleteNamespace(&container.DeleteNamespaceRequest{
+			Region:      scw.Region(namespace.Spec.Region),
+			NamespaceID: resourceID,
+		}, scw.WithContext(ctx))
+	}
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return false, nil
+}
+
+// GetOwners returns the owners of the serverless namespace resource
+func (m *NamespaceManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+func (m *NamespaceManager) ensureContainerNamespace(ctx context.Context, namespace *serverlessv1alpha1.ServerlessNamespace, secretValues map[string]string) (bool, error) {
+	// if namespaceID is empty, we need to create the namespace
+	if namespace.Spec.NamespaceID == "" {
+		createRequest := &container.CreateNamespaceRequest{
+			Region:                     scw.Region(namespace.Spec.Region),
+			Name:                       namespace.Name,
+			EnvironmentVariables:       &namespace.Spec.EnvironmentVariables,
+			SecretEnvironmentVariables: getContainerSecrets(secretValues, nil),
+		}
+		if namespace.Spec.Description != "" {
+			createRequest.Description = scw.StringPtr(namespace.Spec.Description)
+		}
+
+		namespaceResp, err := m.ContainerAPI.CreateNamespace(createRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+
+		err = m.setNamespaceID(ctx, namespace, namespaceResp.ID, namespaceResp.Region)
+		if err != nil {
+			return false, err
+		}
+		namespace.Status.SecretsHash = utils.HashSecretValues(string(namespace.UID), secretValues)
+		return false, nil
+	}
+
+	namespaceResp, err := m.ContainerAPI.GetNamespace(&container.GetNamespaceRequest{
+		Region:      scw.Region(namespace.Spec.Region),
+		NamespaceID: namespace.Spec.NamespaceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	needsUpdate := false
+	updateRequest := &container.UpdateNamespaceRequest{
+		Region:      scw.Region(namespace.Spec.Region),
+		NamespaceID: namespace.Spec.NamespaceID,
+	}
+
+	if !compareEnvironmentVariables(namespaceResp.EnvironmentVariables, namespace.Spec.EnvironmentVariables) {
+		updateRequest.EnvironmentVariables = &namespace.Spec.EnvironmentVariables
+		needsUpdate = true
+	}
+
+	if getDescription(namespaceResp.Description) != namespace.Spec.Description {
+		updateRequest.Description = scw.StringPtr(namespace.Spec.Description)
+		needsUpdate = true
+	}
+
+	existingKeys := getContainerSecretKeys(namespaceResp.SecretEnvironmentVariables)
+	if checkSecretsUpdate(existingKeys, secretValues, string(namespace.UID), namespace.Status.SecretsHash) {
+		updateRequest.SecretEnvironmentVariables = getContainerSecrets(secretValues, getRemovedSecretKeys(existingKeys, secretValues))
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		_, err = m.ContainerAPI.UpdateNamespace(updateRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		namespace.Status.SecretsHash = utils.HashSecretValues(string(namespace.UID), secretValues)
+		return false, nil
+	}
+
+	namespace.Status.RegistryEndpoint = namespaceResp.RegistryEndpoint
+	namespace.Status.RegistryNamespaceID = namespaceResp.RegistryNamespaceID
+
+	if namespaceResp.Status == container.NamespaceStatusError && namespaceResp.ErrorMessage != nil {
+		return false, fmt.Errorf("namespace is in error: %s", *namespaceResp.ErrorMessage)
+	}
+
+	return namespaceResp.Status == container.NamespaceStatusReady, nil
+}
+
+func (m *NamespaceManager) ensureFunctionNamespace(ctx context.Context, namespace *serverlessv1alpha1.ServerlessNamespace, secretValues map[string]string) (bool, error) {
+	// if namespaceID is empty, we need to create the namespace
+	if namespace.Spec.NamespaceID == "" {
+		createRequest := &function.CreateNamespaceRequest{
+			Region:                     scw.Region(namespace.Spec.Region),
+			Name:                       namespace.Name,
+			EnvironmentVariables:       &namespace.Spec.EnvironmentVariables,
+			SecretEnvironmentVariables: getFunctionSecrets(secretValues, nil),
+		}
+		if namespace.Spec.Description != "" {
+			createRequest.Description = scw.StringPtr(namespace.Spec.Description)
+		}
+
+		namespaceResp, err := m.FunctionAPI.CreateNamespace(createRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+
+		err = m.setNamespaceID(ctx, namespace, namespaceResp.ID, namespaceResp.Region)
+		if err != nil {
+			return false, err
+		}
+		namespace.Status.SecretsHash = utils.HashSecretValues(string(namespace.UID), secretValues)
+		return false, nil
+	}
+
+	namespaceResp, err := m.FunctionAPI.GetNamespace(&function.GetNamespaceRequest{
+		Region:      scw.Region(namespace.Spec.Region),
+		NamespaceID: namespace.Spec.NamespaceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	needsUpdate := false
+	updateRequest := &function.UpdateNamespaceRequest{
+		Region:      scw.Region(namespace.Spec.Region),
+		NamespaceID: namespace.Spec.NamespaceID,
+	}
+
+	if !compareEnvironmentVariables(namespaceResp.EnvironmentVariables, namespace.Spec.EnvironmentVariables) {
+		updateRequest.EnvironmentVariables = &namespace.Spec.EnvironmentVariables
+		needsUpdate = true
+	}
+
+	if getDescription(namespaceResp.Description) != namespace.Spec.Description {
+		updateRequest.Description = scw.StringPtr(namespace.Spec.Description)
+		needsUpdate = true
+	}
+
+	existingKeys := getFunctionSecretKeys(namespaceResp.SecretEnvironmentVariables)
+	if checkSecretsUpdate(existingKeys, secretValues, string(namespace.UID), namespace.Status.SecretsHash) {
+		updateRequest.SecretEnvironmentVariables = getFunctionSecrets(secretValues, getRemovedSecretKeys(existingKeys, secretValues))
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		_, err = m.FunctionAPI.UpdateNamespace(updateRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		namespace.Status.SecretsHash = utils.HashSecretValues(string(namespace.UID), secretValues)
+		return false, nil
+	}
+
+	namespace.Status.RegistryEndpoint = namespaceResp.RegistryEndpoint
+	namespace.Status.RegistryNamespaceID = namespaceResp.RegistryNamespaceID
+
+	if namespaceResp.Status == function.NamespaceStatusError && namespaceResp.ErrorMessage != nil {
+		return false, fmt.Errorf("namespace is in error: %s", *namespaceResp.ErrorMessage)
+	}
+
+	return namespaceResp.Status == function.NamespaceStatusReady, nil
+}
+
+func (m *NamespaceManager) setNamespaceID(ctx context.Context, namespace *serverlessv1alpha1.ServerlessNamespace, namespaceID string, region scw.Region) error {
+	namespace.Spec.NamespaceID = namespaceID
+	namespace.Spec.Region = region.String()
+	return m.Client.Update(ctx, namespace)
+}
+
+func getDescription(description *string) string {
+	if description == nil {
+		return ""
+	}
+	return *description
+}
+
+// getNamespaceRef returns the ID and region of the referenced namespace
+// The ID is empty when the namespace is not created yet
+func getNamespaceRef(ctx context.Context, c client.Client, objNamespace string, ref serverlessv1alpha1.ServerlessNamespaceRef, wantedType serverlessv1alpha1.ServerlessNamespaceType) (string, string, error) {
+	if ref.Name == "" {
+		return ref.ExternalID, ref.Region, nil
+	}
+
+	namespaceNamespace := ref.Namespace
+	if namespaceNamespace == "" {
+		namespaceNamespace = objNamespace
+	}
+
+	namespace := &serverlessv1alpha1.ServerlessNamespace{}
+	err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespaceNamespace}, namespace)
+	if err != nil {
+		return "", "", err
+	}
+
+	if namespace.Spec.Type != wantedType {
+		return "", "", fmt.Errorf("namespace %s/%s is of type %s instead of %s", namespace.Namespace, namespace.Name, namespace.Spec.Type, wantedType)
+	}
+
+	return namespace.Spec.NamespaceID, namespace.Spec.Region, nil
+}
+
+func getNamespaceOwners(objNamespace string, ref serverlessv1alpha1.ServerlessNamespaceRef) []scaleway.Owner {
+	if ref.Name == "" {
+		return nil
+	}
+
+	namespaceNamespace := ref.Namespace
+	if namespaceNamespace == "" {
+		namespaceNamespace = objNamespace
+	}
+
+	return []scaleway.Owner{
+		{
+			Key: types.NamespacedName{
+				Name:      ref.Name,
+				Namespace: namespaceNamespace,
+			},
+			Object: &serverlessv1alpha1.ServerlessNamespace{},
+		},
+	}
+}
+
+func getPrivacy(privacy serverlessv1alpha1.ServerlessPrivacy) string {
+	if privacy == "" {
+		return string(serverlessv1alpha1.PrivacyPublic)
+	}
+	return string(privacy)
+}
+
+func toUint32Ptr(value *int32) *uint32 {
+	if value == nil {
+		return nil
+	}
+	return scw.Uint32Ptr(uint32(*value))
+}
+
+// checkUint32Update returns whether the wanted value is set and differs from the existing one
+func checkUint32Update(existing uint32, wanted *int32) bool {
+	return wanted != nil && uint32(*wanted) != existing
+}
+
+func toDuration(duration *metav1.Duration) *scw.Duration {
+	if duration == nil {
+		return nil
+	}
+	return scw.NewDurationFromTimeDuration(duration.Duration)
+}
+
+// checkDurationUpdate returns whether the wanted duration is set and differs from the existing one
+func checkDurationUpdate(existing *scw.Duration, wanted *metav1.Duration) bool {
+	if wanted == nil {
+		return false
+	}
+	if existing == nil {
+		return true
+	}
+	return *existing.ToTimeDuration() != wanted.Duration
+}
+
+func convertNamespace(obj runtime.Object) (*serverlessv1alpha1.ServerlessNamespace, error) {
+	namespace, ok := obj.(*serverlessv1alpha1.ServerlessNamespace)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return namespace, nil
+}
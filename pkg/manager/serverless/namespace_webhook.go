@@ -0,0 +1,163 @@
+package serverless
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-sdk-go/api/container/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	serverlessv1alpha1 "github.com/scaleway/scaleway-operator/apis/serverless/v1alpha1"
+)
+
+// ValidateCreate validates the creation of a Serverless Namespace
+func (m *NamespaceManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	namespace, err := convertNamespace(obj)
+	if err != nil {
+		return nil, err
+	}
+	_, err = scw.ParseRegion(namespace.Spec.Region)
+	if namespace.Spec.Region != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("region"), namespace.Spec.Region, "region is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validateEnvironment(namespace.Spec.EnvironmentVariables, namespace.Spec.SecretEnvironmentVariables)...)
+
+	if namespace.Spec.NamespaceID != "" {
+		if namespace.Spec.Type == serverlessv1alpha1.NamespaceTypeFunction {
+			_, err = m.FunctionAPI.GetNamespace(&function.GetNamespaceRequest{
+				Region:      scw.Region(namespace.Spec.Region),
+				NamespaceID: namespace.Spec.NamespaceID,
+			}, scw.WithContext(ctx))
+		} else {
+			_, err = m.ContainerAPI.GetNamespace(&container.GetNamespaceRequest{
+				Region:      scw.Region(namespace.Spec.Region),
+				NamespaceID: namespace.Spec.NamespaceID,
+			}, scw.WithContext(ctx))
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("namespaceID"), namespace.Spec.NamespaceID, err.Error()))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Serverless Namespace
+func (m *NamespaceManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	namespace, err := convertNamespace(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldNamespace, err := convertNamespace(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldNamespace.Spec.NamespaceID != "" && oldNamespace.Spec.NamespaceID != namespace.Spec.NamespaceID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("namespaceID"), "field is immutable"))
+	}
+
+	if oldNamespace.Spec.Region != "" && oldNamespace.Spec.Region != namespace.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("region"), "field is immutable"))
+	}
+
+	if oldNamespace.Spec.Type != namespace.Spec.Type {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("type"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateEnvironment(namespace.Spec.EnvironmentVariables, namespace.Spec.SecretEnvironmentVariables)...)
+
+	return allErrs, nil
+}
+
+func validateEnvironment(environmentVariables map[string]string, secretEnvironmentVariables []serverlessv1alpha1.ServerlessSecretEnvVar) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := make(map[string]bool)
+	for i, secretEnvVar := range secretEnvironmentVariables {
+		secretPath := field.NewPath("spec").Child("secretEnvironmentVariables").Index(i)
+		if secretEnvVar.Name == "" {
+			allErrs = append(allErrs, field.Required(secretPath.Child("name"), "name must be specified"))
+			continue
+		}
+		if names[secretEnvVar.Name] {
+			allErrs = append(allErrs, field.Duplicate(secretPath.Child("name"), secretEnvVar.Name))
+		}
+		if _, ok := environmentVariables[secretEnvVar.Name]; ok {
+			allErrs = append(allErrs, field.Invalid(secretPath.Child("name"), secretEnvVar.Name, "name is already used by an environment variable"))
+		}
+		if secretEnvVar.SecretKeyRef.Name == "" || secretEnvVar.SecretKeyRef.Key == "" {
+			allErrs = append(allErrs, field.Required(secretPath.Child("secretKeyRef"), "name and key must be specified"))
+		}
+		names[secretEnvVar.Name] = true
+	}
+
+	return allErrs
+}
+
+func validateNamespaceRef(ref serverlessv1alpha1.ServerlessNamespaceRef) field.ErrorList {
+	var allErrs field.ErrorList
+
+	byName := ref.Name != "" || ref.Namespace != ""
+	byID := ref.ExternalID != "" || ref.Region != ""
+
+	if ref.Name == "" && ref.ExternalID == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("namespaceRef"), "name/namespace or externalID/region must be specified"))
+		return allErrs
+	}
+	if byName && byID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("namespaceRef"), "only one of name/namespace and externalID/region must be specified"))
+		return allErrs
+	}
+
+	if byID {
+		_, err := scw.ParseRegion(ref.Region)
+		if ref.Region != "" && err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("namespaceRef").Child("region"), ref.Region, "region is not valid"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateNamespaceRefUpdate(oldRef serverlessv1alpha1.ServerlessNamespaceRef, ref serverlessv1alpha1.ServerlessNamespaceRef) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if oldRef != ref {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("namespaceRef"), "field is immutable"))
+	}
+
+	return allErrs
+}
+
+func validateScale(minScale *int32, maxScale *int32) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if minScale != nil && maxScale != nil && *minScale > *maxScale {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("minScale"), *minScale, "minScale must be lower than maxScale"))
+	}
+
+	return allErrs
+}
+
+func validateDomains(domains []string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, domain := range domains {
+		if errs := validation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("domains").Index(i), domain, "domain is not a valid hostname"))
+		}
+	}
+
+	return allErrs
+}
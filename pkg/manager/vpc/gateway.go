@@ -0,0 +1,519 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+)
+
+// GatewayManager manages the VPC public gateways
+type GatewayManager struct {
+	client.Client
+	API *vpcgw.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the public gateway resource
+func (m *GatewayManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	gateway, err := convertGateway(obj)
+	if err != nil {
+		return false, err
+	}
+
+	// if gatewayID is empty, we need to create the gateway
+	if gateway.Spec.GatewayID == "" {
+		return false, m.createGateway(ctx, gateway)
+	}
+
+	gatewayResp, err := m.API.GetGateway(&vpcgw.GetGatewayRequest{
+		Zone:      scw.Zone(gateway.Spec.Zone),
+		GatewayID: gateway.Spec.GatewayID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	if gatewayResp.IP != nil {
+		gateway.Status.IP = gatewayResp.IP.Address.String()
+		gateway.Status.IPID = gatewayResp.IP.ID
+	}
+	gateway.Status.GatewayNetworks = getGatewayNetworksStatus(gatewayResp)
+
+	if gatewayResp.Status != vpcgw.GatewayStatusRunning {
+		return false, nil
+	}
+
+	needReturn, err := m.updateGateway(ctx, gateway, gatewayResp)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	if gatewayResp.Type == nil || gatewayResp.Type.Name != gateway.Spec.Type {
+		_, err = m.API.UpgradeGateway(&vpcgw.UpgradeGatewayRequest{
+			Zone:      scw.Zone(gateway.Spec.Zone),
+			GatewayID: gateway.Spec.GatewayID,
+			Type:      scw.StringPtr(gateway.Spec.Type),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	needReturn, err = m.updateGatewayNetworks(ctx, gateway, gatewayResp)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	err = m.updateNATRules(ctx, gateway)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Delete deletes the public gateway resource
+func (m *GatewayManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	gateway, err := convertGateway(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := gateway.Spec.GatewayID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	err = m.API.DeleteGateway(&vpcgw.DeleteGatewayRequest{
+		Zone:        scw.Zone(gateway.Spec.Zone),
+		GatewayID:   resourceID,
+		CleanupDHCP: true,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return m.releaseIP(ctx, gateway)
+		}
+		return false, err
+	}
+
+	return false, nil
+}
+
+// GetOwners returns the owners of the public gateway resource
+func (m *GatewayManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+func (m *GatewayManager) createGateway(ctx context.Context, gateway *vpcv1alpha1.PublicGateway) error {
+	createRequest := &vpcgw.CreateGatewayRequest{
+		Zone:               scw.Zone(gateway.Spec.Zone),
+		Name:               gateway.Name,
+		Tags:               utils.LabelsToTags(gateway.Labels),
+		Type:               gateway.Spec.Type,
+		UpstreamDNSServers: gateway.Spec.UpstreamDNSServers,
+		EnableBastion:      gateway.Spec.Bastion != nil,
+	}
+
+	if gateway.Spec.Bastion != nil && gateway.Spec.Bastion.Port != nil {
+		createRequest.BastionPort = scw.Uint32Ptr(uint32(*gateway.Spec.Bastion.Port))
+	}
+
+	if gateway.Spec.IPID != "" {
+		createRequest.IPID = scw.StringPtr(gateway.Spec.IPID)
+	}
+
+	gatewayResp, err := m.API.CreateGateway(createRequest, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	gateway.Spec.GatewayID = gatewayResp.ID
+	gateway.Spec.Zone = gatewayResp.Zone.String()
+	err = m.Client.Update(ctx, gateway)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GatewayManager) updateGateway(ctx context.Context, gateway *vpcv1alpha1.PublicGateway, gatewayResp *vpcgw.Gateway) (bool, error) {
+	needsUpdate := false
+	updateRequest := &vpcgw.UpdateGatewayRequest{
+		Zone:      scw.Zone(gateway.Spec.Zone),
+		GatewayID: gateway.Spec.GatewayID,
+	}
+
+	if !utils.CompareTagsLabels(gatewayResp.Tags, gateway.Labels) {
+		updateRequest.Tags = scw.StringsPtr(utils.LabelsToTags(gateway.Labels))
+		needsUpdate = true
+	}
+
+	if !compareStrings(gatewayResp.UpstreamDNSServers, gateway.Spec.UpstreamDNSServers) {
+		updateRequest.UpstreamDNSServers = scw.StringsPtr(gateway.Spec.UpstreamDNSServers)
+		needsUpdate = true
+	}
+
+	enableBastion := gateway.Spec.Bastion != nil
+	if gatewayResp.BastionEnabled != enableBastion {
+		updateRequest.EnableBastion = scw.BoolPtr(enableBastion)
+		needsUpdate = true
+	}
+
+	if enableBastion && gateway.Spec.Bastion.Port != nil && gatewayResp.BastionPort != uint32(*gateway.Spec.Bastion.Port) {
+		updateRequest.BastionPort = scw.Uint32Ptr(uint32(*gateway.Spec.Bastion.Port))
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		_, err := m.API.UpdateGateway(updateRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// updateGatewayNetworks attaches, updates and detaches the private networks
+// of the gateway to match the wanted ones
+func (m *GatewayManager) updateGatewayNetworks(ctx context.Context, gateway *vpcv1alpha1.PublicGateway, gatewayResp *vpcgw.Gateway) (bool, error) {
+	existingNetworks := make(map[string]*vpcgw.GatewayNetwork)
+	for _, gatewayNetwork := range gatewayResp.GatewayNetworks {
+		existingNetworks[gatewayNetwork.PrivateNetworkID] = gatewayNetwork
+	}
+
+	needReturn := false
+	for _, network := range gateway.Spec.PrivateNetworks {
+		privateNetworkID, err := m.getPrivateNetworkID(ctx, gateway, network.PrivateNetworkRef)
+		if err != nil {
+			return false, err
+		}
+		// the referenced private network is not created yet
+		if privateNetworkID == "" {
+			needReturn = true
+			continue
+		}
+
+		gatewayNetwork, ok := existingNetworks[privateNetworkID]
+		delete(existingNetworks, privateNetworkID)
+		if !ok {
+			err = m.createGatewayNetwork(ctx, gateway, privateNetworkID, network)
+			if err != nil {
+				return false, err
+			}
+			needReturn = true
+			continue
+		}
+
+		if gatewayNetwork.Status != vpcgw.GatewayNetworkStatusReady {
+			needReturn = true
+			continue
+		}
+
+		updated, err := m.updateGatewayNetwork(ctx, gateway, gatewayNetwork, network)
+		if err != nil {
+			return false, err
+		}
+		needReturn = needReturn || updated
+	}
+
+	for _, gatewayNetwork := range existingNetworks {
+		err := m.API.DeleteGatewayNetwork(&vpcgw.DeleteGatewayNetworkRequest{
+			Zone:             scw.Zone(gateway.Spec.Zone),
+			GatewayNetworkID: gatewayNetwork.ID,
+			CleanupDHCP:      true,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		needReturn = true
+	}
+
+	return needReturn, nil
+}
+
+func (m *GatewayManager) createGatewayNetwork(ctx context.Context, gateway *vpcv1alpha1.PublicGateway, privateNetworkID string, network vpcv1alpha1.PublicGatewayNetwork) error {
+	createRequest := &vpcgw.CreateGatewayNetworkRequest{
+		Zone:             scw.Zone(gateway.Spec.Zone),
+		GatewayID:        gateway.Spec.GatewayID,
+		PrivateNetworkID: privateNetworkID,
+		EnableMasquerade: network.EnableMasquerade,
+	}
+
+	if network.DHCP != nil {
+		_, subnet, err := net.ParseCIDR(network.DHCP.Subnet)
+		if err != nil {
+			return err
+		}
+		createRequest.EnableDHCP = scw.BoolPtr(true)
+		createRequest.DHCP = &vpcgw.CreateDHCPRequest{
+			Subnet:           scw.IPNet{IPNet: *subnet},
+			Address:          parseIP(network.DHCP.Address),
+			PoolLow:          parseIP(network.DHCP.PoolLow),
+			PoolHigh:         parseIP(network.DHCP.PoolHigh),
+			PushDefaultRoute: scw.BoolPtr(network.DHCP.PushDefaultRoute),
+			PushDNSServer:    scw.BoolPtr(network.DHCP.PushDNSServer),
+		}
+	} else {
+		createRequest.IpamConfig = &vpcgw.CreateGatewayNetworkRequestIpamConfig{
+			PushDefaultRoute: network.IPAM != nil && network.IPAM.PushDefaultRoute,
+		}
+	}
+
+	_, err := m.API.CreateGatewayNetwork(createRequest, scw.WithContext(ctx))
+	return err
+}
+
+func (m *GatewayManager) updateGatewayNetwork(ctx context.Context, gateway *vpcv1alpha1.PublicGateway, gatewayNetwork *vpcgw.GatewayNetwork, network vpcv1alpha1.PublicGatewayNetwork) (bool, error) {
+	needsUpdate := false
+	updateRequest := &vpcgw.UpdateGatewayNetworkRequest{
+		Zone:             scw.Zone(gateway.Spec.Zone),
+		GatewayNetworkID: gatewayNetwork.ID,
+	}
+
+	if gatewayNetwork.EnableMasquerade != network.EnableMasquerade {
+		updateRequest.EnableMasquerade = scw.BoolPtr(network.EnableMasquerade)
+		needsUpdate = true
+	}
+
+	if network.DHCP == nil {
+		pushDefaultRoute := network.IPAM != nil && network.IPAM.PushDefaultRoute
+		if gatewayNetwork.IpamConfig == nil || gatewayNetwork.IpamConfig.PushDefaultRoute != pushDefaultRoute {
+			updateRequest.IpamConfig = &vpcgw.UpdateGatewayNetworkRequestIpamConfig{
+				PushDefaultRoute: scw.BoolPtr(pushDefaultRoute),
+			}
+			needsUpdate = true
+		}
+	}
+
+	if needsUpdate {
+		_, err := m.API.UpdateGatewayNetwork(updateRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if network.DHCP != nil && gatewayNetwork.DHCP != nil {
+		return m.updateDHCP(ctx, gateway, gatewayNetwork.DHCP, network.DHCP)
+	}
+
+	return false, nil
+}
+
+func (m *GatewayManager) updateDHCP(ctx context.Context, gateway *vpcv1alpha1.PublicGateway, dhcp *vpcgw.DHCP, wantedDHCP *vpcv1alpha1.PublicGatewayDHCP) (bool, error) {
+	needsUpdate := false
+	updateRequest := &vpcgw.UpdateDHCPRequest{
+		Zone:   scw.Zone(gateway.Spec.Zone),
+		DHCPID: dhcp.ID,
+	}
+
+	if address := parseIP(wantedDHCP.Address); address != nil && !dhcp.Address.Equal(*address) {
+		updateRequest.Address = address
+		needsUpdate = true
+	}
+
+	if poolLow := parseIP(wantedDHCP.PoolLow); poolLow != nil && !dhcp.PoolLow.Equal(*poolLow) {
+		updateRequest.PoolLow = poolLow
+		needsUpdate = true
+	}
+
+	if poolHigh := parseIP(wantedDHCP.PoolHigh); poolHigh != nil && !dhcp.PoolHigh.Equal(*poolHigh) {
+		updateRequest.PoolHigh = poolHigh
+		needsUpdate = true
+	}
+
+	if dhcp.PushDefaultRoute != wantedDHCP.PushDefaultRoute {
+		updateRequest.PushDefaultRoute = scw.BoolPtr(wantedDHCP.PushDefaultRoute)
+		needsUpdate = true
+	}
+
+	if dhcp.PushDNSServer != wantedDHCP.PushDNSServer {
+		updateRequest.PushDNSServer = scw.BoolPtr(wantedDHCP.PushDNSServer)
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		_, err := m.API.UpdateDHCP(updateRequest, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (m *GatewayManager) updateNATRules(ctx context.Context, gateway *vpcv1alpha1.PublicGateway) error {
+	rulesResp, err := m.API.ListPATRules(&vpcgw.ListPATRulesRequest{
+		Zone:      scw.Zone(gateway.Spec.Zone),
+		GatewayID: scw.StringPtr(gateway.Spec.GatewayID),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	wantedRules := getWantedNATRules(gateway)
+
+	if checkNATRulesUpdate(rulesResp.PatRules, wantedRules) {
+		_, err = m.API.SetPATRules(&vpcgw.SetPATRulesRequest{
+			Zone:      scw.Zone(gateway.Spec.Zone),
+			GatewayID: gateway.Spec.GatewayID,
+			PatRules:  wantedRules,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseIP deletes the IP reserved by the operator for the gateway
+func (m *GatewayManager) releaseIP(ctx context.Context, gateway *vpcv1alpha1.PublicGateway) (bool, error) {
+	if gateway.Spec.IPID != "" || gateway.Status.IPID == "" {
+		return true, nil
+	}
+
+	err := m.API.DeleteIP(&vpcgw.DeleteIPRequest{
+		Zone: scw.Zone(gateway.Spec.Zone),
+		IPID: gateway.Status.IPID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *GatewayManager) getPrivateNetworkID(ctx context.Context, gateway *vpcv1alpha1.PublicGateway, ref vpcv1alpha1.PrivateNetworkRef) (string, error) {
+	if ref.Name == "" {
+		return ref.ExternalID, nil
+	}
+
+	privateNetworkNamespace := ref.Namespace
+	if privateNetworkNamespace == "" {
+		privateNetworkNamespace = gateway.Namespace
+	}
+
+	privateNetwork := &vpcv1alpha1.PrivateNetwork{}
+	err := m.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: privateNetworkNamespace}, privateNetwork)
+	if err != nil {
+		return "", err
+	}
+
+	return privateNetwork.Spec.PrivateNetworkID, nil
+}
+
+func getWantedNATRules(gateway *vpcv1alpha1.PublicGateway) []*vpcgw.SetPATRulesRequestRule {
+	rules := make([]*vpcgw.SetPATRulesRequestRule, 0, len(gateway.Spec.NATRules))
+	for _, rule := range gateway.Spec.NATRules {
+		protocol := vpcgw.PATRuleProtocolBoth
+		if rule.Protocol != "" {
+			protocol = vpcgw.PATRuleProtocol(rule.Protocol)
+		}
+		rules = append(rules, &vpcgw.SetPATRulesRequestRule{
+			PublicPort:  uint32(rule.PublicPort),
+			PrivateIP:   net.ParseIP(rule.PrivateIP),
+			PrivatePort: uint32(rule.PrivatePort),
+			Protocol:    protocol,
+		})
+	}
+	return rules
+}
+
+func checkNATRulesUpdate(existingRules []*vpcgw.PATRule, wantedRules []*vpcgw.SetPATRulesRequestRule) bool {
+	if len(existingRules) != len(wantedRules) {
+		return true
+	}
+
+	existing := make(map[string]bool)
+	for _, rule := range existingRules {
+		existing[natRuleKey(rule.PublicPort, rule.PrivateIP, rule.PrivatePort, rule.Protocol)] = true
+	}
+
+	for _, rule := range wantedRules {
+		if !existing[natRuleKey(rule.PublicPort, rule.PrivateIP, rule.PrivatePort, rule.Protocol)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func natRuleKey(publicPort uint32, privateIP net.IP, privatePort uint32, protocol vpcgw.PATRuleProtocol) string {
+	return fmt.Sprintf("%d-%s-%d-%s", publicPort, privateIP.String(), privatePort, protocol)
+}
+
+func getGatewayNetworksStatus(gatewayResp *vpcgw.Gateway) []vpcv1alpha1.PublicGatewayNetworkStatus {
+	var gatewayNetworks []vpcv1alpha1.PublicGatewayNetworkStatus
+	for _, gatewayNetwork := range gatewayResp.GatewayNetworks {
+		gatewayNetworkStatus := vpcv1alpha1.PublicGatewayNetworkStatus{
+			PrivateNetworkID: gatewayNetwork.PrivateNetworkID,
+			GatewayNetworkID: gatewayNetwork.ID,
+		}
+		if gatewayNetwork.DHCP != nil {
+			gatewayNetworkStatus.DHCPID = gatewayNetwork.DHCP.ID
+		}
+		gatewayNetworks = append(gatewayNetworks, gatewayNetworkStatus)
+	}
+	sort.Slice(gatewayNetworks, func(i, j int) bool {
+		return gatewayNetworks[i].PrivateNetworkID < gatewayNetworks[j].PrivateNetworkID
+	})
+	return gatewayNetworks
+}
+
+func parseIP(ip string) *net.IP {
+	if ip == "" {
+		return nil
+	}
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return nil
+	}
+	return &parsedIP
+}
+
+func compareStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func convertGateway(obj runtime.Object) (*vpcv1alpha1.PublicGateway, error) {
+	gateway, ok := obj.(*vpcv1alpha1.PublicGateway)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return gateway, nil
+}
@@ -0,0 +1,71 @@
+package vpc
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+)
+
+func Test_checkNATRulesUpdate(t *testing.T) {
+	existingRule := func(publicPort uint32, privateIP string, privatePort uint32, protocol vpcgw.PATRuleProtocol) *vpcgw.PATRule {
+		return &vpcgw.PATRule{
+			PublicPort:  publicPort,
+			PrivateIP:   net.ParseIP(privateIP),
+			PrivatePort: privatePort,
+			Protocol:    protocol,
+		}
+	}
+	wantedRule := func(publicPort uint32, privateIP string, privatePort uint32, protocol vpcgw.PATRuleProtocol) *vpcgw.SetPATRulesRequestRule {
+		return &vpcgw.SetPATRulesRequestRule{
+			PublicPort:  publicPort,
+			PrivateIP:   net.ParseIP(privateIP),
+			PrivatePort: privatePort,
+			Protocol:    protocol,
+		}
+	}
+
+	cases := []struct {
+		existing []*vpcgw.PATRule
+		wanted   []*vpcgw.SetPATRulesRequestRule
+		update   bool
+	}{
+		{
+			existing: nil,
+			wanted:   nil,
+			update:   false,
+		},
+		{
+			existing: []*vpcgw.PATRule{
+				existingRule(2222, "192.168.0.2", 22, vpcgw.PATRuleProtocolTCP),
+				existingRule(53, "192.168.0.3", 53, vpcgw.PATRuleProtocolBoth),
+			},
+			wanted: []*vpcgw.SetPATRulesRequestRule{
+				wantedRule(53, "192.168.0.3", 53, vpcgw.PATRuleProtocolBoth),
+				wantedRule(2222, "192.168.0.2", 22, vpcgw.PATRuleProtocolTCP),
+			},
+			update: false,
+		},
+		{
+			existing: []*vpcgw.PATRule{existingRule(2222, "192.168.0.2", 22, vpcgw.PATRuleProtocolTCP)},
+			wanted:   []*vpcgw.SetPATRulesRequestRule{wantedRule(2222, "192.168.0.2", 22, vpcgw.PATRuleProtocolUDP)},
+			update:   true,
+		},
+		{
+			existing: []*vpcgw.PATRule{existingRule(2222, "192.168.0.2", 22, vpcgw.PATRuleProtocolTCP)},
+			wanted:   []*vpcgw.SetPATRulesRequestRule{wantedRule(2222, "192.168.0.4", 22, vpcgw.PATRuleProtocolTCP)},
+			update:   true,
+		},
+		{
+			existing: []*vpcgw.PATRule{existingRule(2222, "192.168.0.2", 22, vpcgw.PATRuleProtocolTCP)},
+			wanted:   nil,
+			update:   true,
+		},
+	}
+
+	for i, c := range cases {
+		if update := checkNATRulesUpdate(c.existing, c.wanted); update != c.update {
+			t.Errorf("case %d: got %t instead of %t", i, update, c.update)
+		}
+	}
+}
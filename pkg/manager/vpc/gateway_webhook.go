@@ -0,0 +1,222 @@
+package vpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+)
+
+// ValidateCreate validates the creation of a Public Gateway
+func (m *GatewayManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	gateway, err := convertGateway(obj)
+	if err != nil {
+		return nil, err
+	}
+	_, err = scw.ParseZone(gateway.Spec.Zone)
+	if gateway.Spec.Zone != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("zone"), gateway.Spec.Zone, "zone is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validateUpstreamDNSServers(gateway)...)
+	allErrs = append(allErrs, validateGatewayNetworks(gateway)...)
+	allErrs = append(allErrs, validateNATRules(gateway)...)
+
+	if gateway.Spec.GatewayID != "" {
+		_, err := m.API.GetGateway(&vpcgw.GetGatewayRequest{
+			Zone:      scw.Zone(gateway.Spec.Zone),
+			GatewayID: gateway.Spec.GatewayID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("gatewayID"), gateway.Spec.GatewayID, err.Error()))
+			return allErrs, nil
+		}
+	}
+
+	typeErrs, err := m.checkGatewayType(ctx, scw.Zone(gateway.Spec.Zone), gateway.Spec.Type)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, typeErrs...)
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Public Gateway
+func (m *GatewayManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	gateway, err := convertGateway(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldGateway, err := convertGateway(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldGateway.Spec.GatewayID != "" && oldGateway.Spec.GatewayID != gateway.Spec.GatewayID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("gatewayID"), "field is immutable"))
+	}
+
+	if oldGateway.Spec.Zone != "" && oldGateway.Spec.Zone != gateway.Spec.Zone {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("zone"), "field is immutable"))
+	}
+
+	if oldGateway.Spec.IPID != gateway.Spec.IPID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("ipID"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateUpstreamDNSServers(gateway)...)
+	allErrs = append(allErrs, validateGatewayNetworks(gateway)...)
+	allErrs = append(allErrs, validateGatewayNetworksUpdate(oldGateway, gateway)...)
+	allErrs = append(allErrs, validateNATRules(gateway)...)
+
+	if oldGateway.Spec.Type != gateway.Spec.Type {
+		typeErrs, err := m.checkGatewayType(ctx, scw.Zone(gateway.Spec.Zone), gateway.Spec.Type)
+		if err != nil {
+			return nil, err
+		}
+		allErrs = append(allErrs, typeErrs...)
+	}
+
+	return allErrs, nil
+}
+
+func (m *GatewayManager) checkGatewayType(ctx context.Context, zone scw.Zone, gatewayType string) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	typesResp, err := m.API.ListGatewayTypes(&vpcgw.ListGatewayTypesRequest{
+		Zone: zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	typeFound := false
+	for _, t := range typesResp.Types {
+		if t.Name == gatewayType {
+			typeFound = true
+			break
+		}
+	}
+	if !typeFound {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("type"), gatewayType, "gateway type does not exist"))
+	}
+
+	return allErrs, nil
+}
+
+func validateUpstreamDNSServers(gateway *vpcv1alpha1.PublicGateway) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, server := range gateway.Spec.UpstreamDNSServers {
+		if net.ParseIP(server) == nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("upstreamDNSServers").Index(i), server, "server is not a valid IP"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateGatewayNetworks(gateway *vpcv1alpha1.PublicGateway) field.ErrorList {
+	var allErrs field.ErrorList
+
+	seenRefs := make(map[vpcv1alpha1.PrivateNetworkRef]bool)
+	for i, network := range gateway.Spec.PrivateNetworks {
+		networkPath := field.NewPath("spec").Child("privateNetworks").Index(i)
+
+		ref := network.PrivateNetworkRef
+		if ref.ExternalID == "" && ref.Name == "" {
+			allErrs = append(allErrs, field.Required(networkPath.Child("privateNetworkRef"), "externalID or name must be specified"))
+		}
+		if ref.ExternalID != "" && (ref.Name != "" || ref.Namespace != "") {
+			allErrs = append(allErrs, field.Forbidden(networkPath.Child("privateNetworkRef"), "only one of externalID or name/namespace must be specified"))
+		}
+		if seenRefs[ref] {
+			allErrs = append(allErrs, field.Duplicate(networkPath.Child("privateNetworkRef"), ref))
+		}
+		seenRefs[ref] = true
+
+		if network.DHCP != nil && network.IPAM != nil {
+			allErrs = append(allErrs, field.Forbidden(networkPath, "only one of dhcp and ipam must be specified"))
+		}
+
+		if network.DHCP == nil {
+			continue
+		}
+
+		dhcpPath := networkPath.Child("dhcp")
+		_, subnet, err := net.ParseCIDR(network.DHCP.Subnet)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(dhcpPath.Child("subnet"), network.DHCP.Subnet, "subnet is not a valid CIDR"))
+			continue
+		}
+		ips := []struct {
+			name  string
+			value string
+		}{
+			{"address", network.DHCP.Address},
+			{"poolLow", network.DHCP.PoolLow},
+			{"poolHigh", network.DHCP.PoolHigh},
+		}
+		for _, ip := range ips {
+			if ip.value == "" {
+				continue
+			}
+			parsedIP := net.ParseIP(ip.value)
+			if parsedIP == nil || !subnet.Contains(parsedIP) {
+				allErrs = append(allErrs, field.Invalid(dhcpPath.Child(ip.name), ip.value, "ip is not a valid IP of the subnet"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateGatewayNetworksUpdate(oldGateway *vpcv1alpha1.PublicGateway, gateway *vpcv1alpha1.PublicGateway) field.ErrorList {
+	var allErrs field.ErrorList
+
+	oldNetworks := make(map[vpcv1alpha1.PrivateNetworkRef]vpcv1alpha1.PublicGatewayNetwork)
+	for _, network := range oldGateway.Spec.PrivateNetworks {
+		oldNetworks[network.PrivateNetworkRef] = network
+	}
+
+	for i, network := range gateway.Spec.PrivateNetworks {
+		oldNetwork, ok := oldNetworks[network.PrivateNetworkRef]
+		if !ok {
+			continue
+		}
+		networkPath := field.NewPath("spec").Child("privateNetworks").Index(i)
+		if (oldNetwork.DHCP == nil) != (network.DHCP == nil) {
+			allErrs = append(allErrs, field.Forbidden(networkPath.Child("dhcp"), "can't switch between dhcp and ipam"))
+			continue
+		}
+		if oldNetwork.DHCP != nil && oldNetwork.DHCP.Subnet != network.DHCP.Subnet {
+			allErrs = append(allErrs, field.Forbidden(networkPath.Child("dhcp").Child("subnet"), "field is immutable"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateNATRules(gateway *vpcv1alpha1.PublicGateway) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, rule := range gateway.Spec.NATRules {
+		if net.ParseIP(rule.PrivateIP) == nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("natRules").Index(i).Child("privateIP"), rule.PrivateIP, "private ip is not a valid IP"))
+		}
+	}
+
+	return allErrs
+}
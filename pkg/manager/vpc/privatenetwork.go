@@ -0,0 +1,265 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+)
+
+// PrivateNetworkManager manages the VPC private networks
+type PrivateNetworkManager struct {
+	client.Client
+	API *vpc.API
+	scaleway.Manager
+	Log logr.Logger
+}
+
+// Ensure reconciles the private network resource
+func (m *PrivateNetworkManager) Ensure(ctx context.Context, obj runtime.Object) (bool, error) {
+	privateNetwork, err := convertPrivateNetwork(obj)
+	if err != nil {
+		return false, err
+	}
+
+	// if privateNetworkID is empty, we need to create the private network
+	if privateNetwork.Spec.PrivateNetworkID == "" {
+		return false, m.createPrivateNetwork(ctx, privateNetwork)
+	}
+
+	privateNetworkResp, err := m.API.GetPrivateNetwork(&vpc.GetPrivateNetworkRequest{
+		Region:           scw.Region(privateNetwork.Spec.Region),
+		PrivateNetworkID: privateNetwork.Spec.PrivateNetworkID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	needReturn, err := m.updatePrivateNetwork(ctx, privateNetwork, privateNetworkResp)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	needReturn, err = m.updateSubnets(ctx, privateNetwork, privateNetworkResp)
+	if err != nil {
+		return false, err
+	}
+	if needReturn {
+		return false, nil
+	}
+
+	needsEnable, mismatch := checkDHCPUpdate(isDHCPWanted(privateNetwork), privateNetworkResp.DHCPEnabled)
+	if mismatch {
+		scaleway.RecordEvent(ctx, privateNetwork, corev1.EventTypeWarning, scaleway.ReasonDHCPMismatch, "DHCP is enabled on the private network and can't be disabled, enableDHCP should be set to true")
+	}
+	if needsEnable {
+		_, err = m.API.EnableDHCP(&vpc.EnableDHCPRequest{
+			Region:           scw.Region(privateNetwork.Spec.Region),
+			PrivateNetworkID: privateNetwork.Spec.PrivateNetworkID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	privateNetwork.Status.VPCID = privateNetworkResp.VpcID
+	privateNetwork.Status.Subnets = getSubnets(privateNetworkResp)
+	privateNetwork.Status.DHCPEnabled = privateNetworkResp.DHCPEnabled
+
+	return true, nil
+}
+
+// Delete deletes the private network resource
+func (m *PrivateNetworkManager) Delete(ctx context.Context, obj runtime.Object) (bool, error) {
+	privateNetwork, err := convertPrivateNetwork(obj)
+	if err != nil {
+		return false, err
+	}
+
+	resourceID := privateNetwork.Spec.PrivateNetworkID
+	if resourceID == "" {
+		return true, nil
+	}
+
+	err = m.API.DeletePrivateNetwork(&vpc.DeletePrivateNetworkRequest{
+		Region:           scw.Region(privateNetwork.Spec.Region),
+		PrivateNetworkID: resourceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if _, ok := err.(*scw.ResourceNotFoundError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetOwners returns the owners of the private network resource
+func (m *PrivateNetworkManager) GetOwners(ctx context.Context, obj runtime.Object) ([]scaleway.Owner, error) {
+	return nil, nil
+}
+
+func (m *PrivateNetworkManager) createPrivateNetwork(ctx context.Context, privateNetwork *vpcv1alpha1.PrivateNetwork) error {
+	subnets, err := parseSubnets(privateNetwork.Spec.Subnets)
+	if err != nil {
+		return err
+	}
+
+	createRequest := &vpc.CreatePrivateNetworkRequest{
+		Region:  scw.Region(privateNetwork.Spec.Region),
+		Name:    privateNetwork.Name,
+		Tags:    utils.LabelsToTags(privateNetwork.Labels),
+		Subnets: subnets,
+	}
+
+	if privateNetwork.Spec.VPCID != "" {
+		createRequest.VpcID = scw.StringPtr(privateNetwork.Spec.VPCID)
+	}
+
+	privateNetworkResp, err := m.API.CreatePrivateNetwork(createRequest, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	privateNetwork.Spec.PrivateNetworkID = privateNetworkResp.ID
+	privateNetwork.Spec.Region = privateNetworkResp.Region.String()
+	err = m.Client.Update(ctx, privateNetwork)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *PrivateNetworkManager) updatePrivateNetwork(ctx context.Context, privateNetwork *vpcv1alpha1.PrivateNetwork, privateNetworkResp *vpc.PrivateNetwork) (bool, error) {
+	if utils.CompareTagsLabels(privateNetworkResp.Tags, privateNetwork.Labels) {
+		return false, nil
+	}
+
+	_, err := m.API.UpdatePrivateNetwork(&vpc.UpdatePrivateNetworkRequest{
+		Region:           scw.Region(privateNetwork.Spec.Region),
+		PrivateNetworkID: privateNetwork.Spec.PrivateNetworkID,
+		Tags:             scw.StringsPtr(utils.LabelsToTags(privateNetwork.Labels)),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// updateSubnets adds and removes the subnets of the private network
+// to match the wanted ones. Nothing is done if no subnet is specified,
+// in which case the subnets are left to IPAM
+func (m *PrivateNetworkManager) updateSubnets(ctx context.Context, privateNetwork *vpcv1alpha1.PrivateNetwork, privateNetworkResp *vpc.PrivateNetwork) (bool, error) {
+	if len(privateNetwork.Spec.Subnets) == 0 {
+		return false, nil
+	}
+
+	wantedSubnets, err := parseSubnets(privateNetwork.Spec.Subnets)
+	if err != nil {
+		return false, err
+	}
+
+	existingSubnets := make(map[string]bool)
+	for _, subnet := range privateNetworkResp.Subnets {
+		existingSubnets[subnet.Subnet.String()] = true
+	}
+
+	var subnetsToAdd []scw.IPNet
+	for _, subnet := range wantedSubnets {
+		if !existingSubnets[subnet.String()] {
+			subnetsToAdd = append(subnetsToAdd, subnet)
+		}
+		delete(existingSubnets, subnet.String())
+	}
+
+	var subnetsToDelete []scw.IPNet
+	for _, subnet := range privateNetworkResp.Subnets {
+		if existingSubnets[subnet.Subnet.String()] {
+			subnetsToDelete = append(subnetsToDelete, subnet.Subnet)
+		}
+	}
+
+	if len(subnetsToAdd) > 0 {
+		_, err := m.API.AddSubnets(&vpc.AddSubnetsRequest{
+			Region:           scw.Region(privateNetwork.Spec.Region),
+			PrivateNetworkID: privateNetwork.Spec.PrivateNetworkID,
+			Subnets:          subnetsToAdd,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(subnetsToDelete) > 0 {
+		_, err := m.API.DeleteSubnets(&vpc.DeleteSubnetsRequest{
+			Region:           scw.Region(privateNetwork.Spec.Region),
+			PrivateNetworkID: privateNetwork.Spec.PrivateNetworkID,
+			Subnets:          subnetsToDelete,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return len(subnetsToAdd) > 0 || len(subnetsToDelete) > 0, nil
+}
+
+func parseSubnets(subnets []string) ([]scw.IPNet, error) {
+	ipNets := make([]scw.IPNet, 0, len(subnets))
+	for _, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, scw.IPNet{IPNet: *ipNet})
+	}
+	return ipNets, nil
+}
+
+func getSubnets(privateNetwork *vpc.PrivateNetwork) []string {
+	subnets := make([]string, 0, len(privateNetwork.Subnets))
+	for _, subnet := range privateNetwork.Subnets {
+		subnets = append(subnets, subnet.Subnet.String())
+	}
+	sort.Strings(subnets)
+	return subnets
+}
+
+// isDHCPWanted returns whether the managed DHCP is wanted on the private network,
+// an unset enableDHCP meaning it is wanted as VPC v2 private networks always have it
+func isDHCPWanted(privateNetwork *vpcv1alpha1.PrivateNetwork) bool {
+	return privateNetwork.Spec.EnableDHCP == nil || *privateNetwork.Spec.EnableDHCP
+}
+
+// checkDHCPUpdate returns whether the managed DHCP needs to be enabled on the private network,
+// and whether it is enabled while the spec does not want it. The DHCP can't be disabled,
+// so such a mismatch is only reported
+func checkDHCPUpdate(wanted bool, enabled bool) (bool, bool) {
+	return wanted && !enabled, !wanted && enabled
+}
+
+func convertPrivateNetwork(obj runtime.Object) (*vpcv1alpha1.PrivateNetwork, error) {
+	privateNetwork, ok := obj.(*vpcv1alpha1.PrivateNetwork)
+	if !ok {
+		return nil, fmt.Errorf("failed type assertion on kind: %s", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	return privateNetwork, nil
+}
@@ -0,0 +1,81 @@
+package vpc
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+)
+
+func Test_checkDHCPUpdate(t *testing.T) {
+	cases := []struct {
+		wanted   bool
+		enabled  bool
+		enable   bool
+		mismatch bool
+	}{
+		{
+			wanted:  false,
+			enabled: false,
+			enable:  false,
+		},
+		{
+			wanted:  true,
+			enabled: false,
+			enable:  true,
+		},
+		{
+			wanted:  true,
+			enabled: true,
+			enable:  false,
+		},
+		{
+			wanted:   false,
+			enabled:  true,
+			enable:   false,
+			mismatch: true,
+		},
+	}
+
+	for i, c := range cases {
+		enable, mismatch := checkDHCPUpdate(c.wanted, c.enabled)
+		if mismatch != c.mismatch {
+			t.Errorf("case %d: got mismatch %t instead of %t", i, mismatch, c.mismatch)
+		}
+		if enable != c.enable {
+			t.Errorf("case %d: got %t instead of %t", i, enable, c.enable)
+		}
+	}
+}
+
+func Test_isDHCPWanted(t *testing.T) {
+	cases := []struct {
+		enableDHCP *bool
+		wanted     bool
+	}{
+		{
+			enableDHCP: nil,
+			wanted:     true,
+		},
+		{
+			enableDHCP: scw.BoolPtr(true),
+			wanted:     true,
+		},
+		{
+			enableDHCP: scw.BoolPtr(false),
+			wanted:     false,
+		},
+	}
+
+	for i, c := range cases {
+		privateNetwork := &vpcv1alpha1.PrivateNetwork{
+			Spec: vpcv1alpha1.PrivateNetworkSpec{
+				EnableDHCP: c.enableDHCP,
+			},
+		}
+		if wanted := isDHCPWanted(privateNetwork); wanted != c.wanted {
+			t.Errorf("case %d: got %t instead of %t", i, wanted, c.wanted)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package vpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	vpcv1alpha1 "github.com/scaleway/scaleway-operator/apis/vpc/v1alpha1"
+)
+
+// ValidateCreate validates the creation of a Private Network
+func (m *PrivateNetworkManager) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	privateNetwork, err := convertPrivateNetwork(obj)
+	if err != nil {
+		return nil, err
+	}
+	_, err = scw.ParseRegion(privateNetwork.Spec.Region)
+	if privateNetwork.Spec.Region != "" && err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("region"), privateNetwork.Spec.Region, "region is not valid"))
+		return allErrs, nil // stop validation here since future calls will fail
+	}
+
+	allErrs = append(allErrs, validateSubnets(privateNetwork)...)
+
+	if privateNetwork.Spec.PrivateNetworkID != "" {
+		privateNetworkResp, err := m.API.GetPrivateNetwork(&vpc.GetPrivateNetworkRequest{
+			Region:           scw.Region(privateNetwork.Spec.Region),
+			PrivateNetworkID: privateNetwork.Spec.PrivateNetworkID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("privateNetworkID"), privateNetwork.Spec.PrivateNetworkID, err.Error()))
+			return allErrs, nil
+		}
+		if privateNetwork.Spec.VPCID != "" && privateNetwork.Spec.VPCID != privateNetworkResp.VpcID {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("vpcID"), privateNetwork.Spec.VPCID, "vpcID does not match"))
+		}
+		if !isDHCPWanted(privateNetwork) && privateNetworkResp.DHCPEnabled {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("enableDHCP"), false, "dhcp can't be disabled"))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// ValidateUpdate validates the update of a Private Network
+func (m *PrivateNetworkManager) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	privateNetwork, err := convertPrivateNetwork(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPrivateNetwork, err := convertPrivateNetwork(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldPrivateNetwork.Spec.PrivateNetworkID != "" && oldPrivateNetwork.Spec.PrivateNetworkID != privateNetwork.Spec.PrivateNetworkID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("privateNetworkID"), "field is immutable"))
+	}
+
+	if oldPrivateNetwork.Spec.Region != "" && oldPrivateNetwork.Spec.Region != privateNetwork.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("region"), "field is immutable"))
+	}
+
+	if oldPrivateNetwork.Spec.VPCID != privateNetwork.Spec.VPCID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("vpcID"), "field is immutable"))
+	}
+
+	if isDHCPWanted(oldPrivateNetwork) && !isDHCPWanted(privateNetwork) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("enableDHCP"), false, "dhcp can't be disabled"))
+	}
+
+	allErrs = append(allErrs, validateSubnets(privateNetwork)...)
+
+	return allErrs, nil
+}
+
+func validateSubnets(privateNetwork *vpcv1alpha1.PrivateNetwork) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, subnet := range privateNetwork.Spec.Subnets {
+		_, _, err := net.ParseCIDR(subnet)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("subnets").Index(i), subnet, "subnet is not a valid CIDR"))
+		}
+	}
+
+	return allErrs
+}
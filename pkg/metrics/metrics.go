@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scaleway_api_requests_total",
+		Help: "Total number of requests to the Scaleway API by product, method, endpoint and status code",
+	}, []string{"product", "method", "endpoint", "code"})
+
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "scaleway_api_request_duration_seconds",
+		Help:    "Duration of the requests to the Scaleway API by product, method and endpoint",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"product", "method", "endpoint"})
+
+	apiErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scaleway_api_errors_total",
+		Help: "Total number of errors of the Scaleway API by product, method, endpoint and reason",
+	}, []string{"product", "method", "endpoint", "reason"})
+
+	resourceConditions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "scaleway_resources",
+		Help: "Number of resources by kind and state of their condition",
+	}, []string{"kind", "condition", "status", "reason"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		apiRequestsTotal,
+		apiRequestDuration,
+		apiErrorsTotal,
+		resourceConditions,
+	)
+}
+
+// ConditionState is the state of a condition of a resource, as exported in the metrics
+type ConditionState struct {
+	Condition string
+	Status    string
+	Reason    string
+}
+
+// ResourceTracker keeps the state of the conditions of the resources of a kind,
+// exporting the number of resources in each state
+type ResourceTracker struct {
+	kind string
+
+	mu     sync.Mutex
+	states map[types.NamespacedName][]ConditionState
+}
+
+// NewResourceTracker returns a tracker for the resources of the given kind
+func NewResourceTracker(kind string) *ResourceTracker {
+	return &ResourceTracker{
+		kind:   kind,
+		states: make(map[types.NamespacedName][]ConditionState),
+	}
+}
+
+// Set records the states of the conditions of the given resource
+func (t *ResourceTracker) Set(key types.NamespacedName, states []ConditionState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.remove(key)
+	for _, state := range states {
+		resourceConditions.WithLabelValues(t.kind, state.Condition, state.Status, state.Reason).Inc()
+	}
+	t.states[key] = states
+}
+
+// Delete forgets the given resource
+func (t *ResourceTracker) Delete(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.remove(key)
+}
+
+func (t *ResourceTracker) remove(key types.NamespacedName) {
+	for _, state := range t.states[key] {
+		resourceConditions.WithLabelValues(t.kind, state.Condition, state.Status, state.Reason).Dec()
+	}
+	delete(t.states, key)
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_ResourceTracker(t *testing.T) {
+	tracker := NewResourceTracker("TestKind")
+	first := types.NamespacedName{Namespace: "default", Name: "first"}
+	second := types.NamespacedName{Namespace: "default", Name: "second"}
+
+	reconciled := ConditionState{Condition: "Reconciled", Status: "True"}
+	transient := ConditionState{Condition: "Reconciled", Status: "False", Reason: "TransientState"}
+
+	count := func(state ConditionState) float64 {
+		return testutil.ToFloat64(resourceConditions.WithLabelValues("TestKind", state.Condition, state.Status, state.Reason))
+	}
+
+	cases := []struct {
+		update     func()
+		reconciled float64
+		transient  float64
+	}{
+		{
+			update:     func() { tracker.Set(first, []ConditionState{transient}) },
+			reconciled: 0,
+			transient:  1,
+		},
+		{
+			update:     func() { tracker.Set(second, []ConditionState{transient}) },
+			reconciled: 0,
+			transient:  2,
+		},
+		{
+			update:     func() { tracker.Set(first, []ConditionState{reconciled}) },
+			reconciled: 1,
+			transient:  1,
+		},
+		{
+			update:     func() { tracker.Delete(second) },
+			reconciled: 1,
+			transient:  0,
+		},
+		{
+			update:     func() { tracker.Delete(second) },
+			reconciled: 1,
+			transient:  0,
+		},
+	}
+
+	for i, c := range cases {
+		c.update()
+		if got := count(reconciled); got != c.reconciled {
+			t.Errorf("case %d: got %v reconciled resources instead of %v", i, got, c.reconciled)
+		}
+		if got := count(transient); got != c.transient {
+			t.Errorf("case %d: got %v transient resources instead of %v", i, got, c.transient)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+)
+
+const (
+	// errorReasonOther is the reason of the errors not classified by the reconciler
+	errorReasonOther = "Other"
+	// errorReasonNetwork is the reason of the requests failing without response
+	errorReasonNetwork = "Network"
+)
+
+// errorReasons maps the Scaleway error types to the reasons used by the reconciler
+var errorReasons = map[string]string{
+	"not_found":          scaleway.ReasonResourceNotFound,
+	"invalid_arguments":  scaleway.ReasonInvalidArguments,
+	"permissions_denied": scaleway.ReasonPermissionsDenied,
+	"out_of_stock":       scaleway.ReasonOutOfStock,
+	"quotas_exceeded":    scaleway.ReasonQuotasExceeded,
+	"locked":             scaleway.ReasonResourceLocked,
+	"transient_state":    scaleway.ReasonTransientState,
+}
+
+// Transport is a http.RoundTripper recording the metrics of the Scaleway API calls
+type Transport struct {
+	// Base is the underlying transport, http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// NewHTTPClient returns a HTTP client recording the metrics of the Scaleway API calls,
+// with the same timeout as the default client of the Scaleway SDK
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &Transport{
+			Base: http.DefaultTransport.(*http.Transport).Clone(),
+		},
+	}
+}
+
+// RoundTrip executes the request and records its metrics
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	apiPath := utils.ParseAPIPath(req.URL.Path)
+	product, endpoint := apiPath.Product, apiPath.Endpoint
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	apiRequestDuration.WithLabelValues(product, req.Method, endpoint).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		apiRequestsTotal.WithLabelValues(product, req.Method, endpoint, "").Inc()
+		apiErrorsTotal.WithLabelValues(product, req.Method, endpoint, errorReasonNetwork).Inc()
+		return resp, err
+	}
+
+	apiRequestsTotal.WithLabelValues(product, req.Method, endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+	if resp.StatusCode >= 400 {
+		apiErrorsTotal.WithLabelValues(product, req.Method, endpoint, getErrorReason(resp)).Inc()
+	}
+
+	return resp, nil
+}
+
+// getErrorReason returns the reason of the error response, reading its type from the body
+// The body is restored for the Scaleway SDK to parse it
+func getErrorReason(resp *http.Response) string {
+	if resp.Body == nil {
+		return errorReasonOther
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return errorReasonOther
+	}
+
+	errorBody := struct {
+		Type string `json:"type"`
+	}{}
+	if json.Unmarshal(body, &errorBody) != nil {
+		return errorReasonOther
+	}
+	if reason, ok := errorReasons[errorBody.Type]; ok {
+		return reason
+	}
+	return errorReasonOther
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+)
+
+func Test_getErrorReason(t *testing.T) {
+	cases := []struct {
+		body   string
+		reason string
+	}{
+		{
+			body:   `{"type":"not_found","resource":"instance"}`,
+			reason: scaleway.ReasonResourceNotFound,
+		},
+		{
+			body:   `{"type":"transient_state","current_state":"provisioning"}`,
+			reason: scaleway.ReasonTransientState,
+		},
+		{
+			body:   `{"type":"unknown_type"}`,
+			reason: errorReasonOther,
+		},
+		{
+			body:   `not json`,
+			reason: errorReasonOther,
+		},
+	}
+
+	for i, c := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(c.body))
+		}))
+
+		errors := apiErrorsTotal.WithLabelValues("rdb", http.MethodGet, "/rdb/v1/regions/{region}/instances", c.reason)
+		before := testutil.ToFloat64(errors)
+
+		client := &http.Client{Transport: &Transport{}}
+		resp, err := client.Get(server.URL + "/rdb/v1/regions/fr-par/instances")
+		if err != nil {
+			t.Fatalf("case %d: got error %v", i, err)
+		}
+		server.Close()
+
+		if after := testutil.ToFloat64(errors); after != before+1 {
+			t.Errorf("case %d: got %v errors instead of %v", i, after, before+1)
+		}
+
+		// the transport already consumed the body once, it must still be readable
+		body, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			t.Fatalf("case %d: got error %v", i, err)
+		}
+		if string(body) != c.body {
+			t.Errorf("case %d: got body %s instead of %s", i, body, c.body)
+		}
+
+		resp.Body = ioutil.NopCloser(strings.NewReader(c.body))
+		if reason := getErrorReason(resp); reason != c.reason {
+			t.Errorf("case %d: got reason %s instead of %s", i, reason, c.reason)
+		}
+	}
+}
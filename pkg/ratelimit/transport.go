@@ -0,0 +1,211 @@
+package ratelimit
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+var errBodyNotRewindable = errors.New("request body can't be sent again")
+
+// Config is the configuration of the rate limiting and of the retries of the Scaleway API calls
+type Config struct {
+	// Rate is the number of requests per second allowed per product and region
+	// A zero rate disables the rate limiting
+	Rate float64
+	// Burst is the number of requests allowed at once per product and region
+	Burst int
+	// MaxRetries is the number of times a throttled or failed request is retried
+	MaxRetries int
+	// MinBackoff is the duration waited before the first retry, doubled on each retry
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum duration waited before a retry
+	// A request whose Retry-After exceeds it is not retried
+	MaxBackoff time.Duration
+}
+
+// Transport is a http.RoundTripper limiting the rate of the Scaleway API calls,
+// shared by all the managers, and retrying the throttled and failed requests
+// with an exponential backoff
+type Transport struct {
+	// Base is the underlying transport, http.DefaultTransport when nil
+	Base   http.RoundTripper
+	Config Config
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// WrapClient makes the given HTTP client limit the rate of its requests and retry them
+func WrapClient(client *http.Client, config Config) *http.Client {
+	client.Transport = &Transport{
+		Base:   client.Transport,
+		Config: config,
+	}
+	return client
+}
+
+// RoundTrip executes the request once allowed by the limiter of its product and region,
+// retrying it on 429 and, for idempotent methods, on 5xx responses
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	limiter := t.getLimiter(req)
+	for attempt := 0; ; attempt++ {
+		if limiter != nil {
+			if err := limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := base.RoundTrip(req)
+		if err != nil || attempt >= t.Config.MaxRetries || !isRetryable(req, resp) {
+			return resp, err
+		}
+
+		backoff, ok := t.getBackoff(resp, attempt)
+		if !ok {
+			return resp, nil
+		}
+
+		body, err := rewindBody(req)
+		if err != nil {
+			return resp, nil
+		}
+
+		// the response is discarded, so the connection can be reused
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		trace.SpanFromContext(req.Context()).AddEvent("retry", trace.WithAttributes(
+			attribute.Int("http.status_code", resp.StatusCode),
+			attribute.Int("retry.attempt", attempt+1),
+			attribute.String("retry.backoff", backoff.String()),
+		))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+}
+
+// getLimiter returns the limiter shared by the requests of the same product and region
+// Zoned requests share the limiter of their region
+func (t *Transport) getLimiter(req *http.Request) *rate.Limiter {
+	if t.Config.Rate <= 0 {
+		return nil
+	}
+
+	apiPath := utils.ParseAPIPath(req.URL.Path)
+	region := apiPath.Region
+	if apiPath.Zone != "" {
+		if zoneRegion, err := scw.Zone(apiPath.Zone).Region(); err == nil {
+			region = zoneRegion.String()
+		} else {
+			region = apiPath.Zone
+		}
+	}
+	key := apiPath.Product + "/" + region
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.limiters == nil {
+		t.limiters = make(map[string]*rate.Limiter)
+	}
+	limiter, ok := t.limiters[key]
+	if !ok {
+		burst := t.Config.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(t.Config.Rate), burst)
+		t.limiters[key] = limiter
+	}
+	return limiter
+}
+
+// getBackoff returns the duration to wait before retrying the request,
+// and false if the Retry-After of the response exceeds the maximum backoff
+func (t *Transport) getBackoff(resp *http.Response, attempt int) (time.Duration, bool) {
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		if t.Config.MaxBackoff > 0 && retryAfter > t.Config.MaxBackoff {
+			return 0, false
+		}
+		return retryAfter, true
+	}
+
+	backoff := t.Config.MinBackoff
+	for i := 0; i < attempt && (t.Config.MaxBackoff <= 0 || backoff < t.Config.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if t.Config.MaxBackoff > 0 && backoff > t.Config.MaxBackoff {
+		backoff = t.Config.MaxBackoff
+	}
+	return backoff, true
+}
+
+// isRetryable returns true if the request can be retried given its response
+// A throttled request was not processed, so it is always retried, while a failed one
+// is only retried if its method is idempotent, so a resource is never created twice
+func isRetryable(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode < 500 || resp.StatusCode == http.StatusNotImplemented {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewindBody returns a new reader of the body of the request, so it can be sent again
+func rewindBody(req *http.Request) (io.ReadCloser, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req.Body, nil
+	}
+	if req.GetBody == nil {
+		return nil, errBodyNotRewindable
+	}
+	return req.GetBody()
+}
+
+// parseRetryAfter parses the Retry-After header, either a number of seconds or a HTTP date
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
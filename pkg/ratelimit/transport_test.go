@@ -0,0 +1,231 @@
+package ratelimit
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_Transport(t *testing.T) {
+	cases := []struct {
+		method     string
+		codes      []int
+		retryAfter string
+		maxRetries int
+		attempts   int
+		code       int
+	}{
+		{
+			method:     http.MethodPost,
+			codes:      []int{http.StatusTooManyRequests, http.StatusOK},
+			maxRetries: 5,
+			attempts:   2,
+			code:       http.StatusOK,
+		},
+		{
+			method:     http.MethodPost,
+			codes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries: 5,
+			attempts:   1,
+			code:       http.StatusServiceUnavailable,
+		},
+		{
+			method:     http.MethodGet,
+			codes:      []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries: 5,
+			attempts:   3,
+			code:       http.StatusOK,
+		},
+		{
+			method:     http.MethodDelete,
+			codes:      []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			maxRetries: 2,
+			attempts:   3,
+			code:       http.StatusTooManyRequests,
+		},
+		{
+			method:     http.MethodGet,
+			codes:      []int{http.StatusNotFound, http.StatusOK},
+			maxRetries: 5,
+			attempts:   1,
+			code:       http.StatusNotFound,
+		},
+		{
+			method:     http.MethodPatch,
+			codes:      []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "0",
+			maxRetries: 5,
+			attempts:   2,
+			code:       http.StatusOK,
+		},
+		{
+			method:     http.MethodPatch,
+			codes:      []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "3600",
+			maxRetries: 5,
+			attempts:   1,
+			code:       http.StatusTooManyRequests,
+		},
+	}
+
+	for i, c := range cases {
+		attempts := 0
+		bodies := []string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			if c.retryAfter != "" {
+				w.Header().Set("Retry-After", c.retryAfter)
+			}
+			w.WriteHeader(c.codes[attempts])
+			attempts++
+		}))
+
+		client := WrapClient(&http.Client{}, Config{
+			Rate:       1000,
+			Burst:      10,
+			MaxRetries: c.maxRetries,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 10 * time.Millisecond,
+		})
+		req, err := http.NewRequest(c.method, server.URL+"/rdb/v1/regions/fr-par/instances", bytes.NewReader([]byte(`{"name":"test"}`)))
+		if err != nil {
+			t.Fatalf("case %d: got error %v", i, err)
+		}
+		resp, err := client.Do(req)
+		server.Close()
+		if err != nil {
+			t.Fatalf("case %d: got error %v", i, err)
+		}
+		_ = resp.Body.Close()
+
+		if attempts != c.attempts {
+			t.Errorf("case %d: got %d attempts instead of %d", i, attempts, c.attempts)
+		}
+		if resp.StatusCode != c.code {
+			t.Errorf("case %d: got code %d instead of %d", i, resp.StatusCode, c.code)
+		}
+		for _, body := range bodies {
+			if body != `{"name":"test"}` {
+				t.Errorf("case %d: got body %s instead of the original one", i, body)
+			}
+		}
+	}
+}
+
+func Test_getBackoff(t *testing.T) {
+	transport := &Transport{
+		Config: Config{
+			MinBackoff: time.Second,
+			MaxBackoff: 10 * time.Second,
+		},
+	}
+
+	cases := []struct {
+		retryAfter string
+		attempt    int
+		backoff    time.Duration
+		retry      bool
+	}{
+		{
+			attempt: 0,
+			backoff: time.Second,
+			retry:   true,
+		},
+		{
+			attempt: 3,
+			backoff: 8 * time.Second,
+			retry:   true,
+		},
+		{
+			attempt: 10,
+			backoff: 10 * time.Second,
+			retry:   true,
+		},
+		{
+			retryAfter: "5",
+			attempt:    0,
+			backoff:    5 * time.Second,
+			retry:      true,
+		},
+		{
+			retryAfter: "60",
+			attempt:    0,
+			retry:      false,
+		},
+		{
+			retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT",
+			attempt:    2,
+			backoff:    0,
+			retry:      true,
+		},
+		{
+			retryAfter: "invalid",
+			attempt:    1,
+			backoff:    2 * time.Second,
+			retry:      true,
+		},
+	}
+
+	for i, c := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		if c.retryAfter != "" {
+			resp.Header.Set("Retry-After", c.retryAfter)
+		}
+		backoff, retry := transport.getBackoff(resp, c.attempt)
+		if retry != c.retry {
+			t.Errorf("case %d: got retry %t instead of %t", i, retry, c.retry)
+		}
+		if retry && backoff != c.backoff {
+			t.Errorf("case %d: got backoff %s instead of %s", i, backoff, c.backoff)
+		}
+	}
+}
+
+func Test_getLimiter(t *testing.T) {
+	transport := &Transport{
+		Config: Config{
+			Rate:  1,
+			Burst: 1,
+		},
+	}
+
+	cases := []struct {
+		first  string
+		second string
+		shared bool
+	}{
+		{
+			first:  "/rdb/v1/regions/fr-par/instances",
+			second: "/rdb/v1/regions/fr-par/instances/11111111-1111-1111-1111-111111111111/acls",
+			shared: true,
+		},
+		{
+			first:  "/rdb/v1/regions/fr-par/instances",
+			second: "/rdb/v1/regions/nl-ams/instances",
+			shared: false,
+		},
+		{
+			first:  "/vpc-gw/v1/zones/fr-par-1/gateways",
+			second: "/vpc-gw/v1/zones/fr-par-2/ips",
+			shared: true,
+		},
+		{
+			first:  "/rdb/v1/regions/fr-par/instances",
+			second: "/redis/v1/zones/fr-par-1/clusters",
+			shared: false,
+		},
+	}
+
+	for i, c := range cases {
+		first, _ := http.NewRequest(http.MethodGet, "https://api.scaleway.com"+c.first, nil)
+		second, _ := http.NewRequest(http.MethodGet, "https://api.scaleway.com"+c.second, nil)
+		shared := transport.getLimiter(first) == transport.getLimiter(second)
+		if shared != c.shared {
+			t.Errorf("case %d: got shared %t instead of %t", i, shared, c.shared)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName  = "github.com/scaleway/scaleway-operator"
+	serviceName = "scaleway-operator"
+
+	// errorReasonKey is the attribute holding the reason of the classified Scaleway errors
+	errorReasonKey = attribute.Key("scaleway.error.reason")
+)
+
+// Config is the configuration of the traces export
+type Config struct {
+	// Endpoint is the host:port of the OTLP HTTP collector, such as localhost:4318
+	// When empty, the OTEL_EXPORTER_OTLP_ENDPOINT and OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+	// environment variables are used
+	Endpoint string
+	// Insecure disables TLS for the export
+	Insecure bool
+	// SampleRatio is the ratio of the reconciliations to trace, between 0 and 1
+	SampleRatio float64
+}
+
+// IsEnabled returns true if a collector is configured, by the config or the environment
+func (c Config) IsEnabled() bool {
+	return c.Endpoint != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup registers the global tracer provider exporting the traces to the configured collector
+// It returns the function flushing the remaining traces on shutdown
+// The spans are not recorded when no collector is configured
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	if !config.IsEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracehttp.Option{}
+	if config.Endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpoint(config.Endpoint))
+	}
+	if config.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(semconv.ServiceNameKey.String(serviceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span with the given name, child of the span of the context if any
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan ends the span, recording the given error if any
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if reason, ok := scaleway.GetErrorReason(err); ok {
+			span.SetAttributes(errorReasonKey.String(reason))
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/scaleway/scaleway-operator/pkg/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes of the Scaleway API calls
+const (
+	productKey    = attribute.Key("scaleway.product")
+	endpointKey   = attribute.Key("scaleway.endpoint")
+	regionKey     = attribute.Key("scaleway.region")
+	zoneKey       = attribute.Key("scaleway.zone")
+	resourceKey   = attribute.Key("scaleway.resource")
+	resourceIDKey = attribute.Key("scaleway.resource_id")
+)
+
+// Transport is a http.RoundTripper tracing the Scaleway API calls
+// The spans are children of the span of the request context,
+// set by the managers with scw.WithContext
+type Transport struct {
+	// Base is the underlying transport, http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// WrapClient makes the given HTTP client trace its requests
+func WrapClient(client *http.Client) *http.Client {
+	client.Transport = &Transport{
+		Base: client.Transport,
+	}
+	return client
+}
+
+// RoundTrip executes the request in a client span
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	apiPath := utils.ParseAPIPath(req.URL.Path)
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), req.Method+" "+apiPath.Endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(getAPIAttributes(req, apiPath)...),
+	)
+	defer span.End()
+
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(resp.StatusCode))
+
+	return resp, nil
+}
+
+// getAPIAttributes returns the attributes of the span of a Scaleway API call
+// The query is left out of the URL, as it may hold filters on resource names
+func getAPIAttributes(req *http.Request, apiPath utils.APIPath) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		semconv.HTTPMethodKey.String(req.Method),
+		semconv.HTTPHostKey.String(req.URL.Host),
+		semconv.HTTPTargetKey.String(req.URL.Path),
+		productKey.String(apiPath.Product),
+		endpointKey.String(apiPath.Endpoint),
+	}
+	if apiPath.Region != "" {
+		attributes = append(attributes, regionKey.String(apiPath.Region))
+	}
+	if apiPath.Zone != "" {
+		attributes = append(attributes, zoneKey.String(apiPath.Zone))
+	}
+	if apiPath.Resource != "" {
+		attributes = append(attributes, resourceKey.String(apiPath.Resource))
+	}
+	if apiPath.ResourceID != "" {
+		attributes = append(attributes, resourceIDKey.String(apiPath.ResourceID))
+	}
+	return attributes
+}
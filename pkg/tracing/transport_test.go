@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_Transport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		method     string
+		path       string
+		name       string
+		attributes map[attribute.Key]attribute.Value
+		code       codes.Code
+	}{
+		{
+			method: http.MethodGet,
+			path:   "/rdb/v1/regions/fr-par/instances/11111111-1111-1111-1111-111111111111",
+			name:   "GET /rdb/v1/regions/{region}/instances/{id}",
+			attributes: map[attribute.Key]attribute.Value{
+				productKey:         attribute.StringValue("rdb"),
+				regionKey:          attribute.StringValue("fr-par"),
+				resourceKey:        attribute.StringValue("instances"),
+				resourceIDKey:      attribute.StringValue("11111111-1111-1111-1111-111111111111"),
+				"http.status_code": attribute.IntValue(http.StatusOK),
+				endpointKey:        attribute.StringValue("/rdb/v1/regions/{region}/instances/{id}"),
+			},
+			code: codes.Unset,
+		},
+		{
+			method: http.MethodDelete,
+			path:   "/vpc-gw/v1/zones/fr-par-1/ips/22222222-2222-2222-2222-222222222222",
+			name:   "DELETE /vpc-gw/v1/zones/{zone}/ips/{id}",
+			attributes: map[attribute.Key]attribute.Value{
+				productKey:         attribute.StringValue("vpc-gw"),
+				zoneKey:            attribute.StringValue("fr-par-1"),
+				resourceIDKey:      attribute.StringValue("22222222-2222-2222-2222-222222222222"),
+				"http.status_code": attribute.IntValue(http.StatusNotFound),
+			},
+			code: codes.Error,
+		},
+	}
+
+	client := WrapClient(&http.Client{})
+	for i, c := range cases {
+		ctx, parent := StartSpan(context.Background(), "Ensure")
+		req, err := http.NewRequestWithContext(ctx, c.method, server.URL+c.path, nil)
+		if err != nil {
+			t.Fatalf("case %d: got error %v", i, err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("case %d: got error %v", i, err)
+		}
+		_ = resp.Body.Close()
+		parent.End()
+
+		ended := recorder.Ended()
+		if len(ended) < 2 {
+			t.Fatalf("case %d: got %d spans instead of at least 2", i, len(ended))
+		}
+		span := ended[len(ended)-2]
+
+		if span.Name() != c.name {
+			t.Errorf("case %d: got name %s instead of %s", i, span.Name(), c.name)
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("case %d: got parent %s instead of %s", i, span.Parent().SpanID(), parent.SpanContext().SpanID())
+		}
+		if span.Status().Code != c.code {
+			t.Errorf("case %d: got status %v instead of %v", i, span.Status().Code, c.code)
+		}
+
+		attributes := map[attribute.Key]attribute.Value{}
+		for _, kv := range span.Attributes() {
+			attributes[kv.Key] = kv.Value
+		}
+		for key, value := range c.attributes {
+			if attributes[key] != value {
+				t.Errorf("case %d: got %s=%v instead of %v", i, key, attributes[key].Emit(), value.Emit())
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package utils
+
+import "strings"
+
+// APIPath is the parsed path of a Scaleway API request
+type APIPath struct {
+	// Product is the API product, such as rdb
+	Product string
+	// Endpoint is the path with its localities and identifiers replaced by placeholders,
+	// such as /rdb/v1/regions/{region}/instances/{id}/acls
+	Endpoint string
+	// Region is the region of the request, if any
+	Region string
+	// Zone is the zone of the request, if any
+	Zone string
+	// Resource is the last collection of the path, such as acls
+	Resource string
+	// ResourceID is the last identifier of the path, if any
+	ResourceID string
+}
+
+// ParseAPIPath parses the path of a Scaleway API request
+// The path segments are expected to alternate between collections and identifiers
+// after the product and the version
+func ParseAPIPath(path string) APIPath {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	apiPath := APIPath{
+		Product: segments[0],
+	}
+	if len(segments) < 2 {
+		apiPath.Endpoint = path
+		return apiPath
+	}
+
+	for i := 2; i < len(segments); i++ {
+		if i%2 == 0 {
+			apiPath.Resource = segments[i]
+			continue
+		}
+		switch segments[i-1] {
+		case "regions":
+			apiPath.Region = segments[i]
+			segments[i] = "{region}"
+		case "zones":
+			apiPath.Zone = segments[i]
+			segments[i] = "{zone}"
+		default:
+			apiPath.ResourceID = segments[i]
+			segments[i] = "{id}"
+		}
+	}
+	if apiPath.Resource == "regions" || apiPath.Resource == "zones" {
+		apiPath.Resource = ""
+	}
+
+	apiPath.Endpoint = "/" + strings.Join(segments, "/")
+	return apiPath
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+)
+
+func Test_ParseAPIPath(t *testing.T) {
+	cases := []struct {
+		path    string
+		apiPath APIPath
+	}{
+		{
+			path: "/rdb/v1/regions/fr-par/instances",
+			apiPath: APIPath{
+				Product:  "rdb",
+				Endpoint: "/rdb/v1/regions/{region}/instances",
+				Region:   "fr-par",
+				Resource: "instances",
+			},
+		},
+		{
+			path: "/rdb/v1/regions/fr-par/instances/11111111-1111-1111-1111-111111111111/acls",
+			apiPath: APIPath{
+				Product:    "rdb",
+				Endpoint:   "/rdb/v1/regions/{region}/instances/{id}/acls",
+				Region:     "fr-par",
+				Resource:   "acls",
+				ResourceID: "11111111-1111-1111-1111-111111111111",
+			},
+		},
+		{
+			path: "/vpc-gw/v1/zones/fr-par-1/ips/22222222-2222-2222-2222-222222222222",
+			apiPath: APIPath{
+				Product:    "vpc-gw",
+				Endpoint:   "/vpc-gw/v1/zones/{zone}/ips/{id}",
+				Zone:       "fr-par-1",
+				Resource:   "ips",
+				ResourceID: "22222222-2222-2222-2222-222222222222",
+			},
+		},
+		{
+			path: "/instance/v1/zones/fr-par-1",
+			apiPath: APIPath{
+				Product:  "instance",
+				Endpoint: "/instance/v1/zones/{zone}",
+				Zone:     "fr-par-1",
+			},
+		},
+		{
+			path: "/account",
+			apiPath: APIPath{
+				Product:  "account",
+				Endpoint: "/account",
+			},
+		},
+	}
+
+	for i, c := range cases {
+		apiPath := ParseAPIPath(c.path)
+		if apiPath != c.apiPath {
+			t.Errorf("case %d: got %+v instead of %+v", i, apiPath, c.apiPath)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NormalizeIPRange returns the canonical CIDR notation of the given IP range
+// A single IP is converted to its single host range, and the host bits of a range are cleared
+func NormalizeIPRange(ipRange string) (string, error) {
+	if !strings.Contains(ipRange, "/") {
+		ip := net.ParseIP(ipRange)
+		if ip == nil {
+			return "", fmt.Errorf("invalid IP %s", ipRange)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		ipNet := GetIPNetFromIP(ip)
+		return ipNet.String(), nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(ipRange)
+	if err != nil {
+		return "", err
+	}
+	return ipNet.String(), nil
+}
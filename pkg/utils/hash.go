@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"sort"
+)
+
+// HashValues returns a stable hash of the given values
+func HashValues(values map[string]string) string {
+	return hashValues(sha256.New(), values)
+}
+
+// HashSecretValues returns a stable hash of the given secret values, keyed with
+// a per-object salt such as its UID, so the hash can't be matched against
+// precomputed hashes of common secrets
+func HashSecretValues(salt string, values map[string]string) string {
+	return hashValues(hmac.New(sha256.New, []byte(salt)), values)
+}
+
+func hashValues(h hash.Hash, values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// keys and values are length prefixed, so no two maps are written the same way
+	for _, key := range keys {
+		fmt.Fprintf(h, "%d:%s%d:%s", len(key), key, len(values[key]), values[key])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
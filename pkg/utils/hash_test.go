@@ -0,0 +1,38 @@
+package utils
+
+import "testing"
+
+func TestHashValues(t *testing.T) {
+	cases := []struct {
+		a     map[string]string
+		b     map[string]string
+		equal bool
+	}{
+		{
+			a:     nil,
+			b:     map[string]string{},
+			equal: true,
+		},
+		{
+			a:     map[string]string{"FifoQueue": "true", "VisibilityTimeout": "30"},
+			b:     map[string]string{"VisibilityTimeout": "30", "FifoQueue": "true"},
+			equal: true,
+		},
+		{
+			a:     map[string]string{"VisibilityTimeout": "30"},
+			b:     map[string]string{"VisibilityTimeout": "60"},
+			equal: false,
+		},
+		{
+			a:     map[string]string{"a": "b\nc=d"},
+			b:     map[string]string{"a": "b", "c": "d"},
+			equal: false,
+		},
+	}
+
+	for _, c := range cases {
+		if equal := HashValues(c.a) == HashValues(c.b); equal != c.equal {
+			t.Errorf("Got equal %t instead of %t for %v and %v", equal, c.equal, c.a, c.b)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package utils
 
+import "sort"
+
 // LabelsToTags transform labels into tags
 func LabelsToTags(labels map[string]string) []string {
 	tags := []string{}
@@ -27,3 +29,22 @@ func CompareTagsLabels(tags []string, labels map[string]string) bool {
 	}
 	return true
 }
+
+// CompareTags returns true if the two tag lists hold the same tags, in any order
+func CompareTags(tags []string, wantedTags []string) bool {
+	if len(tags) != len(wantedTags) {
+		return false
+	}
+
+	sortedTags := append([]string{}, tags...)
+	sortedWantedTags := append([]string{}, wantedTags...)
+	sort.Strings(sortedTags)
+	sort.Strings(sortedWantedTags)
+
+	for i := range sortedTags {
+		if sortedTags[i] != sortedWantedTags[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package utils
+
+import "testing"
+
+func TestCompareTags(t *testing.T) {
+	cases := []struct {
+		tags       []string
+		wantedTags []string
+		equal      bool
+	}{
+		{
+			tags:       nil,
+			wantedTags: []string{},
+			equal:      true,
+		},
+		{
+			tags:       []string{"a", "b=c"},
+			wantedTags: []string{"b=c", "a"},
+			equal:      true,
+		},
+		{
+			tags:       []string{"a", "a"},
+			wantedTags: []string{"a", "b"},
+			equal:      false,
+		},
+		{
+			tags:       []string{"a"},
+			wantedTags: []string{"a", "b"},
+			equal:      false,
+		},
+	}
+
+	for _, c := range cases {
+		if equal := CompareTags(c.tags, c.wantedTags); equal != c.equal {
+			t.Errorf("Got %t instead of %t for %v and %v", equal, c.equal, c.tags, c.wantedTags)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"context"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetNodesIP returns the internal and external IPs of the cluster nodes
+func GetNodesIP(ctx context.Context, c client.Client) ([]net.IPNet, error) {
+	var nodesIP []net.IPNet
+
+	nodesList := corev1.NodeList{}
+	err := c.List(ctx, &nodesList)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodesList.Items {
+		for _, addr := range node.Status.Addresses {
+			if addr.Type == corev1.NodeExternalIP || addr.Type == corev1.NodeInternalIP {
+				parsedIP := net.ParseIP(addr.Address)
+				if parsedIP != nil {
+					nodesIP = append(nodesIP, GetIPNetFromIP(parsedIP))
+				}
+			}
+		}
+	}
+
+	return nodesIP, nil
+}
+
+// GetIPNetFromIP returns the single host IPNet of the given IP
+func GetIPNetFromIP(ip net.IP) net.IPNet {
+	ipNet := net.IPNet{
+		IP: ip,
+	}
+	if ip.To4() != nil {
+		ipNet.Mask = net.CIDRMask(32, 32)
+	} else {
+		ipNet.Mask = net.CIDRMask(128, 128)
+	}
+	return ipNet
+}
@@ -0,0 +1,104 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	blockv1alpha1 "github.com/scaleway/scaleway-operator/apis/block/v1alpha1"
+	"github.com/scaleway/scaleway-operator/webhooks"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-block-scaleway-com-v1alpha1-blockvolume,mutating=false,failurePolicy=fail,groups=block.scaleway.com,resources=blockvolumes,versions=v1alpha1,name=vblockvolume.kb.io
+
+// BlockVolumeValidator is the struct used to validate a BlockVolume
+type BlockVolumeValidator struct {
+	ScalewayWebhook *webhooks.ScalewayWebhook
+	*admission.Decoder
+	Log logr.Logger
+}
+
+// SetupWebhookWithManager registers the BlockVolume webhook
+func (v *BlockVolumeValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookServer := mgr.GetWebhookServer()
+	webhookType, err := apiutil.GVKForObject(&blockv1alpha1.BlockVolume{}, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	webhookServer.Register(webhooks.GenerateValidatePath(webhookType), &webhook.Admission{
+		Handler: v,
+	})
+	return nil
+}
+
+// Handle handles the main logic of the BlockVolume webhook
+func (v *BlockVolumeValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	volume := &blockv1alpha1.BlockVolume{}
+
+	err := v.Decode(req, volume)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var allErrs field.ErrorList
+
+	switch req.Operation {
+	case admissionv1beta1.Create:
+		allErrs, err = v.ScalewayWebhook.ValidateCreate(ctx, volume)
+		if err != nil {
+			v.Log.Error(err, "could not validate block volume creation")
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+
+	case admissionv1beta1.Update:
+		oldVolume := &blockv1alpha1.BlockVolume{}
+		err = v.DecodeRaw(req.OldObject, oldVolume)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		allErrs, err = v.ScalewayWebhook.ValidateUpdate(ctx, oldVolume, volume)
+		if err != nil {
+			v.Log.Error(err, "could not validate block volume update")
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return admission.Allowed("")
+	}
+
+	err = apierrors.NewInvalid(schema.GroupKind{Group: "block.scaleway.com", Kind: "BlockVolume"}, volume.Name, allErrs)
+
+	return admission.Denied(err.Error())
+}
+
+// InjectDecoder injects the decoder.
+func (v *BlockVolumeValidator) InjectDecoder(d *admission.Decoder) error {
+	v.Decoder = d
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	iamv1alpha1 "github.com/scaleway/scaleway-operator/apis/iam/v1alpha1"
+	"github.com/scaleway/scaleway-operator/webhooks"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-iam-scaleway-com-v1alpha1-iamapplication,mutating=false,failurePolicy=fail,groups=iam.scaleway.com,resources=iamapplications,versions=v1alpha1,name=viamapplication.kb.io
+
+// IAMApplicationValidator is the struct used to validate a IAMApplication
+type IAMApplicationValidator struct {
+	ScalewayWebhook *webhooks.ScalewayWebhook
+	*admission.Decoder
+	Log logr.Logger
+}
+
+// SetupWebhookWithManager registers the IAMApplication webhook
+func (v *IAMApplicationValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookServer := mgr.GetWebhookServer()
+	webhookType, err := apiutil.GVKForObject(&iamv1alpha1.IAMApplication{}, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	webhookServer.Register(webhooks.GenerateValidatePath(webhookType), &webhook.Admission{
+		Handler: v,
+	})
+	return nil
+}
+
+// Handle handles the main logic of the IAMApplication webhook
+func (v *IAMApplicationValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	application := &iamv1alpha1.IAMApplication{}
+
+	err := v.Decode(req, application)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var allErrs field.ErrorList
+
+	switch req.Operation {
+	case admissionv1beta1.Create:
+		allErrs, err = v.ScalewayWebhook.ValidateCreate(ctx, application)
+		if err != nil {
+			v.Log.Error(err, "could not validate iam application creation")
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+
+	case admissionv1beta1.Update:
+		oldApplication := &iamv1alpha1.IAMApplication{}
+		err = v.DecodeRaw(req.OldObject, oldApplication)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		allErrs, err = v.ScalewayWebhook.ValidateUpdate(ctx, oldApplication, application)
+		if err != nil {
+			v.Log.Error(err, "could not validate iam application update")
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return admission.Allowed("")
+	}
+
+	err = apierrors.NewInvalid(schema.GroupKind{Group: "iam.scaleway.com", Kind: "IAMApplication"}, application.Name, allErrs)
+
+	return admission.Denied(err.Error())
+}
+
+// InjectDecoder injects the decoder.
+func (v *IAMApplicationValidator) InjectDecoder(d *admission.Decoder) error {
+	v.Decoder = d
+	return nil
+}
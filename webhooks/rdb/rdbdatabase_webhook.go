@@ -30,11 +30,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
-	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
 	"github.com/scaleway/scaleway-operator/webhooks"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-rdb-scaleway-com-v1alpha1-rdbdatabase,mutating=false,failurePolicy=fail,groups=rdb.scaleway.com,resources=rdbdatabases,versions=v1alpha1,name=vrdbdatabase.kb.io
+// +kubebuilder:webhook:verbs=create;update,path=/validate-rdb-scaleway-com-v1beta1-rdbdatabase,mutating=false,failurePolicy=fail,groups=rdb.scaleway.com,resources=rdbdatabases,versions=v1beta1,matchPolicy=Equivalent,name=vrdbdatabase.kb.io
 
 // RDBDatabaseValidator is the struct used to validate a RDBDatabase
 type RDBDatabaseValidator struct {
@@ -46,7 +46,7 @@ type RDBDatabaseValidator struct {
 // SetupWebhookWithManager registers the RDBDatabase webhook
 func (v *RDBDatabaseValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	webhookServer := mgr.GetWebhookServer()
-	webhookType, err := apiutil.GVKForObject(&rdbv1alpha1.RDBDatabase{}, mgr.GetScheme())
+	webhookType, err := apiutil.GVKForObject(&rdbv1beta1.RDBDatabase{}, mgr.GetScheme())
 	if err != nil {
 		return err
 	}
@@ -59,7 +59,7 @@ func (v *RDBDatabaseValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 // Handle handles the main logic of the RDBDatabase webhook
 func (v *RDBDatabaseValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
-	instance := &rdbv1alpha1.RDBDatabase{}
+	instance := &rdbv1beta1.RDBDatabase{}
 
 	err := v.Decode(req, instance)
 	if err != nil {
@@ -77,7 +77,7 @@ func (v *RDBDatabaseValidator) Handle(ctx context.Context, req admission.Request
 		}
 
 	case admissionv1beta1.Update:
-		oldDatabase := &rdbv1alpha1.RDBDatabase{}
+		oldDatabase := &rdbv1beta1.RDBDatabase{}
 		err = v.DecodeRaw(req.OldObject, oldDatabase)
 		if err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
@@ -103,3 +103,52 @@ func (v *RDBDatabaseValidator) InjectDecoder(d *admission.Decoder) error {
 	v.Decoder = d
 	return nil
 }
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-rdb-scaleway-com-v1beta1-rdbdatabase,mutating=true,failurePolicy=fail,groups=rdb.scaleway.com,resources=rdbdatabases,versions=v1beta1,matchPolicy=Equivalent,name=mrdbdatabase.kb.io
+
+// RDBDatabaseDefaulter is the struct used to default a RDBDatabase
+type RDBDatabaseDefaulter struct {
+	ScalewayWebhook *webhooks.ScalewayWebhook
+	*admission.Decoder
+	Log logr.Logger
+}
+
+// SetupWebhookWithManager registers the RDBDatabase defaulting webhook
+func (d *RDBDatabaseDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookServer := mgr.GetWebhookServer()
+	webhookType, err := apiutil.GVKForObject(&rdbv1beta1.RDBDatabase{}, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	webhookServer.Register(webhooks.GenerateMutatePath(webhookType), &webhook.Admission{
+		Handler: d,
+	})
+	return nil
+}
+
+// Handle handles the main logic of the RDBDatabase defaulting webhook
+func (d *RDBDatabaseDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	database := &rdbv1beta1.RDBDatabase{}
+
+	err := d.Decode(req, database)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if database.Namespace == "" {
+		database.Namespace = req.Namespace
+	}
+
+	err = d.ScalewayWebhook.Default(ctx, database)
+	if err != nil {
+		d.Log.Error(err, "could not default rdb database")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return webhooks.PatchResponse(req, database)
+}
+
+// InjectDecoder injects the decoder.
+func (d *RDBDatabaseDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.Decoder = decoder
+	return nil
+}
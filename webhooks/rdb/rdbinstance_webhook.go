@@ -30,11 +30,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
-	rdbv1alpha1 "github.com/scaleway/scaleway-operator/apis/rdb/v1alpha1"
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
 	"github.com/scaleway/scaleway-operator/webhooks"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-rdb-scaleway-com-v1alpha1-rdbinstance,mutating=false,failurePolicy=fail,groups=rdb.scaleway.com,resources=rdbinstances,versions=v1alpha1,name=vrdbinstance.kb.io
+// +kubebuilder:webhook:verbs=create;update,path=/validate-rdb-scaleway-com-v1beta1-rdbinstance,mutating=false,failurePolicy=fail,groups=rdb.scaleway.com,resources=rdbinstances,versions=v1beta1,matchPolicy=Equivalent,name=vrdbinstance.kb.io
 
 // RDBInstanceValidator is the struct used to validate a RDBInstance
 type RDBInstanceValidator struct {
@@ -46,7 +46,7 @@ type RDBInstanceValidator struct {
 // SetupWebhookWithManager registers the RDBInstance webhook
 func (v *RDBInstanceValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	webhookServer := mgr.GetWebhookServer()
-	webhookType, err := apiutil.GVKForObject(&rdbv1alpha1.RDBInstance{}, mgr.GetScheme())
+	webhookType, err := apiutil.GVKForObject(&rdbv1beta1.RDBInstance{}, mgr.GetScheme())
 	if err != nil {
 		return err
 	}
@@ -58,7 +58,7 @@ func (v *RDBInstanceValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 // Handle handles the main logic of the RDBInstance webhook
 func (v *RDBInstanceValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
-	instance := &rdbv1alpha1.RDBInstance{}
+	instance := &rdbv1beta1.RDBInstance{}
 
 	err := v.Decode(req, instance)
 	if err != nil {
@@ -76,7 +76,7 @@ func (v *RDBInstanceValidator) Handle(ctx context.Context, req admission.Request
 		}
 
 	case admissionv1beta1.Update:
-		oldInstance := &rdbv1alpha1.RDBInstance{}
+		oldInstance := &rdbv1beta1.RDBInstance{}
 		err = v.DecodeRaw(req.OldObject, oldInstance)
 		if err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
@@ -102,3 +102,52 @@ func (v *RDBInstanceValidator) InjectDecoder(d *admission.Decoder) error {
 	v.Decoder = d
 	return nil
 }
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-rdb-scaleway-com-v1beta1-rdbinstance,mutating=true,failurePolicy=fail,groups=rdb.scaleway.com,resources=rdbinstances,versions=v1beta1,matchPolicy=Equivalent,name=mrdbinstance.kb.io
+
+// RDBInstanceDefaulter is the struct used to default a RDBInstance
+type RDBInstanceDefaulter struct {
+	ScalewayWebhook *webhooks.ScalewayWebhook
+	*admission.Decoder
+	Log logr.Logger
+}
+
+// SetupWebhookWithManager registers the RDBInstance defaulting webhook
+func (d *RDBInstanceDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookServer := mgr.GetWebhookServer()
+	webhookType, err := apiutil.GVKForObject(&rdbv1beta1.RDBInstance{}, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	webhookServer.Register(webhooks.GenerateMutatePath(webhookType), &webhook.Admission{
+		Handler: d,
+	})
+	return nil
+}
+
+// Handle handles the main logic of the RDBInstance defaulting webhook
+func (d *RDBInstanceDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	instance := &rdbv1beta1.RDBInstance{}
+
+	err := d.Decode(req, instance)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if instance.Namespace == "" {
+		instance.Namespace = req.Namespace
+	}
+
+	err = d.ScalewayWebhook.Default(ctx, instance)
+	if err != nil {
+		d.Log.Error(err, "could not default rdb instance")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return webhooks.PatchResponse(req, instance)
+}
+
+// InjectDecoder injects the decoder.
+func (d *RDBInstanceDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.Decoder = decoder
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rdbv1beta1 "github.com/scaleway/scaleway-operator/apis/rdb/v1beta1"
+	"github.com/scaleway/scaleway-operator/webhooks"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-rdb-scaleway-com-v1beta1-rdbuser,mutating=false,failurePolicy=fail,groups=rdb.scaleway.com,resources=rdbusers,versions=v1beta1,matchPolicy=Equivalent,name=vrdbuser.kb.io
+
+// RDBUserValidator is the struct used to validate a RDBUser
+type RDBUserValidator struct {
+	ScalewayWebhook *webhooks.ScalewayWebhook
+	*admission.Decoder
+	Log logr.Logger
+}
+
+// SetupWebhookWithManager registers the RDBUser webhook
+func (v *RDBUserValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookServer := mgr.GetWebhookServer()
+	webhookType, err := apiutil.GVKForObject(&rdbv1beta1.RDBUser{}, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	webhookServer.Register(webhooks.GenerateValidatePath(webhookType), &webhook.Admission{
+		Handler: v,
+	})
+	return nil
+}
+
+// Handle handles the main logic of the RDBUser webhook
+func (v *RDBUserValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	user := &rdbv1beta1.RDBUser{}
+
+	err := v.Decode(req, user)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var allErrs field.ErrorList
+
+	switch req.Operation {
+	case admissionv1beta1.Create:
+		allErrs, err = v.ScalewayWebhook.ValidateCreate(ctx, user)
+		if err != nil {
+			v.Log.Error(err, "could not validate rdb user creation")
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+
+	case admissionv1beta1.Update:
+		oldUser := &rdbv1beta1.RDBUser{}
+		err = v.DecodeRaw(req.OldObject, oldUser)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		allErrs, err = v.ScalewayWebhook.ValidateUpdate(ctx, oldUser, user)
+		if err != nil {
+			v.Log.Error(err, "could not validate rdb user update")
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return admission.Allowed("")
+	}
+
+	err = apierrors.NewInvalid(schema.GroupKind{Group: "rdb.scaleway.com", Kind: "RDBUser"}, user.Name, allErrs)
+
+	return admission.Denied(err.Error())
+}
+
+// InjectDecoder injects the decoder.
+func (v *RDBUserValidator) InjectDecoder(d *admission.Decoder) error {
+	v.Decoder = d
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-rdb-scaleway-com-v1beta1-rdbuser,mutating=true,failurePolicy=fail,groups=rdb.scaleway.com,resources=rdbusers,versions=v1beta1,matchPolicy=Equivalent,name=mrdbuser.kb.io
+
+// RDBUserDefaulter is the struct used to default a RDBUser
+type RDBUserDefaulter struct {
+	ScalewayWebhook *webhooks.ScalewayWebhook
+	*admission.Decoder
+	Log logr.Logger
+}
+
+// SetupWebhookWithManager registers the RDBUser defaulting webhook
+func (d *RDBUserDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookServer := mgr.GetWebhookServer()
+	webhookType, err := apiutil.GVKForObject(&rdbv1beta1.RDBUser{}, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	webhookServer.Register(webhooks.GenerateMutatePath(webhookType), &webhook.Admission{
+		Handler: d,
+	})
+	return nil
+}
+
+// Handle handles the main logic of the RDBUser defaulting webhook
+func (d *RDBUserDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	user := &rdbv1beta1.RDBUser{}
+
+	err := d.Decode(req, user)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if user.Namespace == "" {
+		user.Namespace = req.Namespace
+	}
+
+	err = d.ScalewayWebhook.Default(ctx, user)
+	if err != nil {
+		d.Log.Error(err, "could not default rdb user")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return webhooks.PatchResponse(req, user)
+}
+
+// InjectDecoder injects the decoder.
+func (d *RDBUserDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.Decoder = decoder
+	return nil
+}
@@ -2,10 +2,14 @@ package webhooks
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 
 	"github.com/scaleway/scaleway-operator/pkg/manager/scaleway"
+	"github.com/scaleway/scaleway-operator/pkg/tracing"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // ScalewayWebhook is the base webhook for Scaleway products
@@ -15,10 +19,37 @@ type ScalewayWebhook struct {
 
 // ValidateCreate calls the ValidateCreate method of the given resource
 func (r *ScalewayWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (field.ErrorList, error) {
-	return r.ScalewayManager.ValidateCreate(ctx, obj)
+	ctx, span := tracing.StartSpan(ctx, "ValidateCreate")
+	errs, err := r.ScalewayManager.ValidateCreate(ctx, obj)
+	tracing.EndSpan(span, err)
+	return errs, err
 }
 
 // ValidateUpdate calls the ValidateUpdate method of the given resource
 func (r *ScalewayWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (field.ErrorList, error) {
-	return r.ScalewayManager.ValidateUpdate(ctx, oldObj, obj)
+	ctx, span := tracing.StartSpan(ctx, "ValidateUpdate")
+	errs, err := r.ScalewayManager.ValidateUpdate(ctx, oldObj, obj)
+	tracing.EndSpan(span, err)
+	return errs, err
+}
+
+// Default calls the Default method of the given resource, if its manager is a Defaulter
+func (r *ScalewayWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	defaulter, ok := r.ScalewayManager.(scaleway.Defaulter)
+	if !ok {
+		return nil
+	}
+	ctx, span := tracing.StartSpan(ctx, "Default")
+	err := defaulter.Default(ctx, obj)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// PatchResponse returns the response patching the object of the request into the given defaulted object
+func PatchResponse(req admission.Request, obj runtime.Object) admission.Response {
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
 }
@@ -11,3 +11,9 @@ func GenerateValidatePath(gvk schema.GroupVersionKind) string {
 	return "/validate-" + strings.Replace(gvk.Group, ".", "-", -1) + "-" +
 		gvk.Version + "-" + strings.ToLower(gvk.Kind)
 }
+
+// GenerateMutatePath returns the path for this mutating webhook
+func GenerateMutatePath(gvk schema.GroupVersionKind) string {
+	return "/mutate-" + strings.Replace(gvk.Group, ".", "-", -1) + "-" +
+		gvk.Version + "-" + strings.ToLower(gvk.Kind)
+}
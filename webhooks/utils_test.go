@@ -36,3 +36,26 @@ func Test_GenerateValidatePath(t *testing.T) {
 		}
 	}
 }
+
+func Test_GenerateMutatePath(t *testing.T) {
+	cases := []struct {
+		gvk    schema.GroupVersionKind
+		output string
+	}{
+		{
+			schema.GroupVersionKind{
+				Group:   "rdb.scaleway.com",
+				Version: "v1alpha1",
+				Kind:    "RDBInstance",
+			},
+			"/mutate-rdb-scaleway-com-v1alpha1-rdbinstance",
+		},
+	}
+
+	for _, c := range cases {
+		output := GenerateMutatePath(c.gvk)
+		if output != c.output {
+			t.Errorf("Got %s instead of %s", output, c.output)
+		}
+	}
+}